@@ -0,0 +1,80 @@
+// Package sqlc generates type-safe code from SQL in-process, for build tools
+// that would otherwise run the sqlc command and read back its files.
+package sqlc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/cmd"
+)
+
+// Options configure a call to Generate
+type Options struct {
+	// The directory that the schema, queries and output paths of the
+	// configuration are relative to. Defaults to the current directory.
+	Dir string
+
+	// Contents of a sqlc.yaml or sqlc.json file. If nil, the configuration
+	// file is read from Dir.
+	Config []byte
+
+	// Names of the packages to generate. All packages are generated if empty.
+	Packages []string
+
+	// Reuse the analysis of unchanged query files from the .sqlc-cache
+	// directory in Dir, as sqlc generate --cache does
+	Cache bool
+
+	// Generate even if the configuration pins a different sqlc version
+	SkipVersionCheck bool
+
+	// Receives the errors of each package, one per line, as sqlc generate
+	// prints them. If nil, they are returned in the error instead.
+	Stderr io.Writer
+}
+
+// Generate runs sqlc generate without writing any files. It returns the
+// contents of each generated file, keyed by its slash-separated path relative
+// to opts.Dir, such as "internal/db/models.go". The schema and query files
+// are read from disk.
+func Generate(opts Options) (map[string][]byte, error) {
+	dir, err := filepath.Abs(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	e := cmd.Env{
+		Config:           opts.Config,
+		Packages:         opts.Packages,
+		SkipVersionCheck: opts.SkipVersionCheck,
+	}
+	if opts.Cache {
+		e.CacheDir = filepath.Join(dir, ".sqlc-cache")
+	}
+
+	var buf bytes.Buffer
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = &buf
+	}
+	output, err := cmd.Generate(e, dir, stderr)
+	if err != nil {
+		if msg := strings.TrimSpace(buf.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(output))
+	for filename, source := range output {
+		rel, err := filepath.Rel(dir, filename)
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.ToSlash(rel)] = []byte(source)
+	}
+	return files, nil
+}
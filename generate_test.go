@@ -0,0 +1,45 @@
+package sqlc
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+const testConfig = `version: "1"
+sqlc_version: "v1.0.1"
+packages:
+  - name: querytest
+    path: out
+    schema: schema.sql
+    queries: query.sql
+`
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join("internal", "endtoend", "testdata", "sqlc_version")
+	files, err := Generate(Options{Dir: dir, Config: []byte(testConfig)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"db.go", "models.go", "query.sql.go"} {
+		source, ok := files["out/"+name]
+		if !ok {
+			t.Fatalf("out/%s wasn't generated: %v", name, files)
+		}
+		blob, err := ioutil.ReadFile(filepath.Join(dir, "go", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(source) != string(blob) {
+			t.Errorf("out/%s differs from the fixture:\n%s", name, source)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := filepath.Join("internal", "endtoend", "testdata", "sqlc_version")
+	_, err := Generate(Options{Dir: dir, Config: []byte(testConfig), Packages: []string{"missing"}})
+	if err == nil || err.Error() != `no package named "missing"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+// Package cache stores the results of expensive analysis on disk, keyed by a
+// hash of everything the result depends on.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entries that haven't been read or written for this long are removed when
+// the cache is opened, so that the entries of old versions of a file don't
+// build up
+const maxAge = 7 * 24 * time.Hour
+
+type Cache struct {
+	dir string
+
+	// Identifies the sqlc binary that wrote the entries, so that upgrading
+	// sqlc invalidates the cache
+	salt string
+}
+
+// Open creates the cache directory if it doesn't exist, and removes the
+// entries that haven't been used recently
+func Open(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := prune(dir, time.Now().Add(-maxAge)); err != nil {
+		return nil, err
+	}
+	salt := "unknown"
+	if exe, err := os.Executable(); err == nil {
+		if info, err := os.Stat(exe); err == nil {
+			salt = fmt.Sprintf("%s:%d:%d", exe, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return &Cache{dir: dir, salt: salt}, nil
+}
+
+// Remove the entries, and the temporary files of interrupted writes, last
+// used before cutoff
+func prune(dir string, cutoff time.Time) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") && !strings.HasSuffix(f.Name(), ".tmp") {
+			continue
+		}
+		if f.ModTime().Before(cutoff) {
+			os.Remove(filepath.Join(dir, f.Name()))
+		}
+	}
+	return nil
+}
+
+// Key hashes the inputs of a result
+func (c *Cache) Key(parts ...string) string {
+	h := sha256.New()
+	for _, part := range append([]string{c.salt}, parts...) {
+		// Prefix each part with its length so that different splits of the same
+		// bytes don't collide
+		fmt.Fprintf(h, "%d:%s", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// Get decodes the entry stored under key into v. It reports false if there's
+// no usable entry.
+func (c *Cache) Get(key string, v interface{}) bool {
+	path := c.path(key)
+	blob, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	if err := json.Unmarshal(blob, v); err != nil {
+		return false
+	}
+	// Mark the entry as used, so that it isn't pruned
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return true
+}
+
+// Put stores v under key. The entry is written to a temporary file first so
+// that concurrent readers never see a partial entry.
+func (c *Cache) Put(key string, v interface{}) error {
+	blob, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(blob); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Key("ab", "c") == c.Key("a", "bc") {
+		t.Fatal("keys for different inputs collide")
+	}
+
+	key := c.Key("query.sql", "SELECT 1;")
+	var out []string
+	if c.Get(key, &out) {
+		t.Fatal("expected a miss for an empty cache")
+	}
+	if err := c.Put(key, []string{"foo", "bar"}); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Get(key, &out) {
+		t.Fatal("expected a hit after Put")
+	}
+	if len(out) != 2 || out[0] != "foo" || out[1] != "bar" {
+		t.Errorf("unexpected entry: %v", out)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, fresh := c.Key("stale.sql"), c.Key("fresh.sql")
+	for _, key := range []string{stale, fresh} {
+		if err := c.Put(key, "SELECT 1;"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * maxAge)
+	if err := os.Chtimes(c.path(stale), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out string
+	if c.Get(stale, &out) {
+		t.Error("expected the stale entry to be pruned")
+	}
+	if !c.Get(fresh, &out) {
+		t.Error("expected the fresh entry to be kept")
+	}
+}
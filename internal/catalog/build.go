@@ -28,6 +28,11 @@ func ParseRange(rv *nodes.RangeVar) (pg.FQN, error) {
 	return fqn, nil
 }
 
+// IsTemporary reports whether the range refers to a TEMP or TEMPORARY table
+func IsTemporary(rv *nodes.RangeVar) bool {
+	return rv != nil && rv.Relpersistence == 't'
+}
+
 func ParseList(list nodes.List) (pg.FQN, error) {
 	parts := stringSlice(list)
 	var fqn pg.FQN
@@ -109,10 +114,16 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 			}
 			from, exists := c.Schemas[fqn.Schema]
 			if !exists {
+				if n.MissingOk {
+					return nil
+				}
 				return wrap(pg.ErrorSchemaDoesNotExist(fqn.Schema), raw.StmtLocation)
 			}
 			table, exists := from.Tables[fqn.Rel]
 			if !exists {
+				if n.MissingOk {
+					return nil
+				}
 				return wrap(pg.ErrorRelationDoesNotExist(fqn.Rel), raw.StmtLocation)
 			}
 			to, exists := c.Schemas[*n.Newschema]
@@ -141,6 +152,11 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 					implemented = true
 				case nodes.AT_SetNotNull:
 					implemented = true
+				case nodes.AT_ColumnDefault:
+					implemented = true
+				case nodes.AT_EnableRowSecurity, nodes.AT_DisableRowSecurity,
+					nodes.AT_ForceRowSecurity, nodes.AT_NoForceRowSecurity:
+					implemented = true
 				}
 			}
 		}
@@ -152,12 +168,19 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 		if err != nil {
 			return err
 		}
+		// ALTER TABLE IF EXISTS does nothing for a missing table
 		schema, exists := c.Schemas[fqn.Schema]
 		if !exists {
+			if n.MissingOk {
+				return nil
+			}
 			return wrap(pg.ErrorSchemaDoesNotExist(fqn.Schema), raw.StmtLocation)
 		}
 		table, exists := schema.Tables[fqn.Rel]
 		if !exists {
+			if n.MissingOk {
+				return nil
+			}
 			return wrap(pg.ErrorRelationDoesNotExist(fqn.Rel), raw.StmtLocation)
 		}
 
@@ -169,6 +192,7 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 				// Lookup column names for column-related commands
 				switch cmd.Subtype {
 				case nodes.AT_AlterColumnType,
+					nodes.AT_ColumnDefault,
 					nodes.AT_DropColumn,
 					nodes.AT_DropNotNull,
 					nodes.AT_SetNotNull:
@@ -192,17 +216,26 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 
 				case nodes.AT_AddColumn:
 					d := cmd.Def.(nodes.ColumnDef)
+					exists := false
 					for _, c := range table.Columns {
 						if c.Name == *d.Colname {
-							return wrap(pg.ErrorColumnAlreadyExists(table.Name, *d.Colname), d.Location)
+							exists = true
 						}
 					}
+					// ADD COLUMN IF NOT EXISTS keeps the existing column as it is
+					if exists && cmd.MissingOk {
+						continue
+					}
+					if exists {
+						return wrap(pg.ErrorColumnAlreadyExists(table.Name, *d.Colname), d.Location)
+					}
 					table.Columns = append(table.Columns, pg.Column{
-						Name:     *d.Colname,
-						DataType: join(d.TypeName.Names, "."),
-						NotNull:  isNotNull(d),
-						IsArray:  isArray(d.TypeName),
-						Table:    fqn,
+						Name:       *d.Colname,
+						DataType:   join(d.TypeName.Names, "."),
+						NotNull:    isNotNull(d),
+						IsArray:    isArray(d.TypeName),
+						HasDefault: hasDefault(d),
+						Table:      fqn,
 					})
 
 				case nodes.AT_AlterColumnType:
@@ -219,6 +252,22 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 				case nodes.AT_SetNotNull:
 					table.Columns[idx].NotNull = true
 
+				case nodes.AT_ColumnDefault:
+					// DROP DEFAULT has no expression
+					table.Columns[idx].HasDefault = cmd.Def != nil
+
+				case nodes.AT_EnableRowSecurity:
+					table.RowSecurity = true
+
+				case nodes.AT_DisableRowSecurity:
+					table.RowSecurity = false
+
+				case nodes.AT_ForceRowSecurity:
+					table.ForceRowSecurity = true
+
+				case nodes.AT_NoForceRowSecurity:
+					table.ForceRowSecurity = false
+
 				}
 
 				schema.Tables[fqn.Rel] = table
@@ -253,11 +302,29 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 		if err != nil {
 			return err
 		}
+		// Temporary tables exist in a special schema, so a schema name cannot
+		// be given when creating a temporary table.
+		//
+		// https://www.postgresql.org/docs/current/sql-createtable.html
+		if IsTemporary(n.Relation) && n.Relation.Schemaname == nil {
+			fqn.Schema = "pg_temp"
+		}
+		if IsTemporary(n.Relation) && fqn.Schema != "pg_temp" {
+			return wrap(pg.Error{
+				Code:    "42P16",
+				Message: "cannot create temporary relation in non-temporary schema",
+			}, raw.StmtLocation)
+		}
 		schema, exists := c.Schemas[fqn.Schema]
 		if !exists {
 			return wrap(pg.ErrorSchemaDoesNotExist(fqn.Schema), raw.StmtLocation)
 		}
 		if _, exists := schema.Tables[fqn.Rel]; exists {
+			// CREATE TABLE IF NOT EXISTS keeps the existing table, even if
+			// its columns differ
+			if n.IfNotExists {
+				return nil
+			}
 			return wrap(pg.ErrorRelationAlreadyExists(fqn.Rel), raw.StmtLocation)
 		}
 		table := pg.Table{
@@ -268,11 +335,12 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 			case nodes.ColumnDef:
 				colName := *n.Colname
 				table.Columns = append(table.Columns, pg.Column{
-					Name:     colName,
-					DataType: join(n.TypeName.Names, "."),
-					NotNull:  isNotNull(n),
-					IsArray:  isArray(n.TypeName),
-					Table:    fqn,
+					Name:       colName,
+					DataType:   join(n.TypeName.Names, "."),
+					NotNull:    isNotNull(n),
+					IsArray:    isArray(n.TypeName),
+					HasDefault: hasDefault(n),
+					Table:      fqn,
 				})
 			}
 		}
@@ -302,6 +370,15 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 			Vals: stringSlice(n.Vals),
 		}
 
+	case nodes.GrantStmt:
+		updateGrants(c, n)
+
+	case nodes.CreatePolicyStmt:
+		return createPolicy(c, n, raw.StmtLocation)
+
+	case nodes.AlterPolicyStmt:
+		return alterPolicy(c, n, raw.StmtLocation)
+
 	case nodes.CreateSchemaStmt:
 		name := *n.Schemaname
 		if _, exists := c.Schemas[name]; exists {
@@ -313,6 +390,9 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 		}
 
 	case nodes.DropStmt:
+		if n.RemoveType == nodes.OBJECT_POLICY {
+			return dropPolicy(c, n, raw.StmtLocation)
+		}
 		for _, obj := range n.Objects.Items {
 			if n.RemoveType == nodes.OBJECT_TABLE || n.RemoveType == nodes.OBJECT_TYPE {
 				var fqn pg.FQN
@@ -332,6 +412,9 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 
 				schema, exists := c.Schemas[fqn.Schema]
 				if !exists {
+					if n.MissingOk {
+						continue
+					}
 					return pg.ErrorSchemaDoesNotExist(fqn.Schema)
 				}
 
@@ -372,6 +455,9 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 
 	case nodes.RenameStmt:
 		switch n.RenameType {
+		case nodes.OBJECT_POLICY:
+			return renamePolicy(c, n, raw.StmtLocation)
+
 		case nodes.OBJECT_COLUMN:
 			fqn, err := ParseRange(n.Relation)
 			if err != nil {
@@ -379,10 +465,16 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 			}
 			schema, exists := c.Schemas[fqn.Schema]
 			if !exists {
+				if n.MissingOk {
+					return nil
+				}
 				return wrap(pg.ErrorSchemaDoesNotExist(fqn.Schema), raw.StmtLocation)
 			}
 			table, exists := schema.Tables[fqn.Rel]
 			if !exists {
+				if n.MissingOk {
+					return nil
+				}
 				return wrap(pg.ErrorRelationDoesNotExist(fqn.Rel), raw.StmtLocation)
 			}
 			idx := -1
@@ -406,10 +498,16 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 			}
 			schema, exists := c.Schemas[fqn.Schema]
 			if !exists {
+				if n.MissingOk {
+					return nil
+				}
 				return wrap(pg.ErrorSchemaDoesNotExist(fqn.Schema), raw.StmtLocation)
 			}
 			table, exists := schema.Tables[fqn.Rel]
 			if !exists {
+				if n.MissingOk {
+					return nil
+				}
 				return wrap(pg.ErrorRelationDoesNotExist(fqn.Rel), raw.StmtLocation)
 			}
 			if _, exists := schema.Tables[*n.Newname]; exists {
@@ -445,15 +543,37 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 				Name:       name,
 				DataType:   join(arg.ArgType.Names, "."),
 				HasDefault: arg.Defexpr != nil,
+				Mode:       argumentMode(arg.Mode),
+			}
+		}
+		fun := pg.Function{
+			Name:      fqn.Rel,
+			Arguments: args,
+		}
+		if n.ReturnType != nil {
+			fun.ReturnType = join(n.ReturnType.Names, ".")
+		} else {
+			// Without RETURNS, the result is made of the OUT arguments
+			switch out := fun.OutArgs(); len(out) {
+			case 0:
+				fun.ReturnType = "void"
+			case 1:
+				fun.ReturnType = out[0].DataType
+			default:
+				fun.ReturnType = "record"
 			}
 		}
-		// TODO: support return parameter:
-		// CREATE FUNCTION foo(bar TEXT, OUT quz bool) AS $$ SELECT true $$ LANGUAGE sql;
-		schema.Funcs[fqn.Rel] = append(schema.Funcs[fqn.Rel], pg.Function{
-			Name:       fqn.Rel,
-			Arguments:  args,
-			ReturnType: join(n.ReturnType.Names, "."),
-		})
+		// CREATE OR REPLACE FUNCTION replaces the function with the same
+		// argument types, so running it again doesn't add an overload
+		if n.Replace {
+			for i, f := range schema.Funcs[fqn.Rel] {
+				if sameArgumentTypes(f.Arguments, args) {
+					schema.Funcs[fqn.Rel][i] = fun
+					return nil
+				}
+			}
+		}
+		schema.Funcs[fqn.Rel] = append(schema.Funcs[fqn.Rel], fun)
 
 	case nodes.CommentStmt:
 		switch n.Objtype {
@@ -560,6 +680,35 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 	return nil
 }
 
+// The parser reports the mode of an argument as the character PostgreSQL uses
+// for it, not as the index of the enum
+func argumentMode(mode nodes.FunctionParameterMode) pg.ArgumentMode {
+	switch mode {
+	case 'o':
+		return pg.ArgumentOut
+	case 'b':
+		return pg.ArgumentInOut
+	case 'v':
+		return pg.ArgumentVariadic
+	case 't':
+		return pg.ArgumentTable
+	default:
+		return pg.ArgumentIn
+	}
+}
+
+func sameArgumentTypes(a, b []pg.Argument) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].DataType != b[i].DataType {
+			return false
+		}
+	}
+	return true
+}
+
 func stringSlice(list nodes.List) []string {
 	items := []string{}
 	for _, item := range list.Items {
@@ -599,6 +748,22 @@ func isNotNull(n nodes.ColumnDef) bool {
 	return false
 }
 
+func hasDefault(n nodes.ColumnDef) bool {
+	if n.RawDefault != nil {
+		return true
+	}
+	for _, c := range n.Constraints.Items {
+		if c, ok := c.(nodes.Constraint); ok && c.Contype == nodes.CONSTR_DEFAULT {
+			return true
+		}
+	}
+	switch join(n.TypeName.Names, ".") {
+	case "serial", "bigserial", "smallserial", "serial2", "serial4", "serial8":
+		return true
+	}
+	return false
+}
+
 func ToColumn(n *nodes.TypeName) pg.Column {
 	if n == nil {
 		panic("can't build column for nil type name")
@@ -342,7 +342,7 @@ func TestUpdate(t *testing.T) {
 							"venues": pg.Table{
 								Name: "venues",
 								Columns: []pg.Column{
-									{Name: "id", DataType: "serial", NotNull: true, Table: pg.FQN{Schema: "public", Rel: "venues"}},
+									{Name: "id", DataType: "serial", NotNull: true, HasDefault: true, Table: pg.FQN{Schema: "public", Rel: "venues"}},
 								},
 							},
 						},
@@ -451,6 +451,36 @@ func TestUpdate(t *testing.T) {
 				},
 			},
 		},
+		{ // the result of a function without RETURNS is its OUT argument
+			`
+			CREATE FUNCTION foo(bar TEXT, OUT baz bool) AS $$ SELECT true $$ LANGUAGE sql;
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Funcs: map[string][]pg.Function{
+							"foo": []pg.Function{
+								{
+									Name: "foo",
+									Arguments: []pg.Argument{
+										{
+											Name:     "bar",
+											DataType: "text",
+										},
+										{
+											Name:     "baz",
+											DataType: "bool",
+											Mode:     pg.ArgumentOut,
+										},
+									},
+									ReturnType: "bool",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			`
 			CREATE FUNCTION foo(bar TEXT, baz TEXT="baz") RETURNS bool AS $$ SELECT true $$ LANGUAGE sql;
@@ -502,6 +532,25 @@ func TestUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			`
+			CREATE TEMP TABLE staging (val INT) ON COMMIT DROP;
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"pg_temp": {
+						Tables: map[string]pg.Table{
+							"staging": pg.Table{
+								Name: "staging",
+								Columns: []pg.Column{
+									{Name: "val", DataType: "pg_catalog.int4", NotNull: false, Table: pg.FQN{Schema: "pg_temp", Rel: "staging"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			`
 			CREATE SCHEMA foo;
@@ -567,6 +616,134 @@ func TestUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			`
+			CREATE ROLE reader;
+			CREATE TABLE bar (baz text, secret text);
+			GRANT SELECT, INSERT ON bar TO reader;
+			GRANT UPDATE (baz), UPDATE (secret) ON bar TO reader;
+			GRANT ALL ON ALL TABLES IN SCHEMA public TO app;
+			REVOKE INSERT ON bar FROM reader;
+			REVOKE UPDATE (secret) ON bar FROM reader;
+			REVOKE ALL ON bar FROM PUBLIC;
+			REVOKE GRANT OPTION FOR SELECT ON bar FROM reader;
+			REVOKE TRUNCATE, REFERENCES, TRIGGER ON bar FROM app;
+			GRANT SELECT ON missing TO reader;
+			GRANT USAGE ON SCHEMA public TO reader;
+			GRANT reader TO app;
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Tables: map[string]pg.Table{
+							"bar": {
+								Name: "bar",
+								Columns: []pg.Column{
+									{Name: "baz", DataType: "text", Table: pg.FQN{Schema: "public", Rel: "bar"}},
+									{Name: "secret", DataType: "text", Table: pg.FQN{Schema: "public", Rel: "bar"}},
+								},
+								Grants: []pg.Grant{
+									{Grantee: "reader", Privilege: "SELECT"},
+									{Grantee: "reader", Privilege: "UPDATE", Columns: []string{"baz"}},
+									{Grantee: "app", Privilege: "SELECT"},
+									{Grantee: "app", Privilege: "INSERT"},
+									{Grantee: "app", Privilege: "UPDATE"},
+									{Grantee: "app", Privilege: "DELETE"},
+								},
+							},
+						},
+						Types: map[string]pg.Type{},
+						Funcs: map[string][]pg.Function{},
+					},
+				},
+			},
+		},
+		{
+			`
+			CREATE TABLE bar (baz text);
+			ALTER TABLE bar ENABLE ROW LEVEL SECURITY;
+			ALTER TABLE bar FORCE ROW LEVEL SECURITY;
+			CREATE POLICY owner ON bar USING (baz = current_user);
+			CREATE POLICY audit ON bar AS RESTRICTIVE FOR INSERT TO reader, app WITH CHECK (true);
+			CREATE POLICY old ON bar FOR DELETE;
+			ALTER POLICY owner ON bar TO app;
+			ALTER POLICY audit ON bar RENAME TO checked;
+			DROP POLICY old ON bar;
+			DROP POLICY IF EXISTS missing ON bar;
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Tables: map[string]pg.Table{
+							"bar": {
+								Name: "bar",
+								Columns: []pg.Column{
+									{Name: "baz", DataType: "text", Table: pg.FQN{Schema: "public", Rel: "bar"}},
+								},
+								RowSecurity:      true,
+								ForceRowSecurity: true,
+								Policies: []pg.Policy{
+									{Name: "owner", Command: "ALL", Permissive: true, Roles: []string{"app"}},
+									{Name: "checked", Command: "INSERT", Roles: []string{"reader", "app"}},
+								},
+							},
+						},
+						Types: map[string]pg.Type{},
+						Funcs: map[string][]pg.Function{},
+					},
+				},
+			},
+		},
+		{ // idempotent migrations, run twice
+			`
+			CREATE TABLE IF NOT EXISTS foo (bar text);
+			ALTER TABLE foo ADD COLUMN IF NOT EXISTS baz int NOT NULL;
+			ALTER TABLE IF EXISTS missing ADD COLUMN bar text;
+			ALTER TABLE IF EXISTS missing RENAME TO other;
+			ALTER TABLE IF EXISTS missing RENAME COLUMN bar TO baz;
+			ALTER TABLE IF EXISTS missing SET SCHEMA public;
+			DROP TABLE IF EXISTS missing;
+			DROP TABLE IF EXISTS nope.missing;
+			DROP TYPE IF EXISTS missing;
+			CREATE OR REPLACE FUNCTION qux(a text) RETURNS text AS $$ SELECT a $$ LANGUAGE sql;
+
+			CREATE TABLE IF NOT EXISTS foo (bar text);
+			ALTER TABLE foo ADD COLUMN IF NOT EXISTS baz int NOT NULL;
+			ALTER TABLE IF EXISTS missing ADD COLUMN bar text;
+			ALTER TABLE IF EXISTS missing RENAME TO other;
+			ALTER TABLE IF EXISTS missing RENAME COLUMN bar TO baz;
+			ALTER TABLE IF EXISTS missing SET SCHEMA public;
+			DROP TABLE IF EXISTS missing;
+			DROP TABLE IF EXISTS nope.missing;
+			DROP TYPE IF EXISTS missing;
+			CREATE OR REPLACE FUNCTION qux(a text) RETURNS bool AS $$ SELECT true $$ LANGUAGE sql;
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Tables: map[string]pg.Table{
+							"foo": {
+								Name: "foo",
+								Columns: []pg.Column{
+									{Name: "bar", DataType: "text", Table: pg.FQN{Schema: "public", Rel: "foo"}},
+									{Name: "baz", DataType: "pg_catalog.int4", NotNull: true, Table: pg.FQN{Schema: "public", Rel: "foo"}},
+								},
+							},
+						},
+						Types: map[string]pg.Type{},
+						Funcs: map[string][]pg.Function{
+							"qux": {
+								{
+									Name:       "qux",
+									Arguments:  []pg.Argument{{Name: "a", DataType: "text"}},
+									ReturnType: "bool",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	} {
 		test := tc
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
@@ -589,6 +766,33 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestTableAllows(t *testing.T) {
+	c, err := buildCatalog(`
+	CREATE TABLE bar (baz text, secret text);
+	GRANT SELECT (baz) ON bar TO reader;
+	GRANT INSERT ON bar TO PUBLIC;
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := c.Schemas["public"].Tables["bar"]
+	for _, tc := range []struct {
+		role, priv, col string
+		allowed         bool
+	}{
+		{"reader", "SELECT", "baz", true},
+		{"reader", "SELECT", "secret", false},
+		{"reader", "SELECT", "", false},
+		{"reader", "INSERT", "", true},
+		{"writer", "INSERT", "secret", true},
+		{"writer", "DELETE", "", false},
+	} {
+		if got := table.Allows(tc.role, tc.priv, tc.col); got != tc.allowed {
+			t.Errorf("Allows(%q, %q, %q) = %t; want %t", tc.role, tc.priv, tc.col, got, tc.allowed)
+		}
+	}
+}
+
 func TestUpdateErrors(t *testing.T) {
 	for i, tc := range []struct {
 		stmt string
@@ -614,6 +818,12 @@ func TestUpdateErrors(t *testing.T) {
 			`,
 			pg.Error{Code: "42P01", Message: "relation \"foo\" does not exist"},
 		},
+		{
+			`
+			CREATE TEMP TABLE public.staging (val INT);
+			`,
+			pg.Error{Code: "42P16", Message: "cannot create temporary relation in non-temporary schema"},
+		},
 		{
 			`
 			DROP TYPE foo;
@@ -724,6 +934,27 @@ func TestUpdateErrors(t *testing.T) {
 			`,
 			pg.Error{Code: "42701", Message: "column \"baz\" of relation \"foo\" already exists"},
 		},
+		{
+			`
+			CREATE TABLE foo ();
+			CREATE POLICY bar ON foo USING (true);
+			CREATE POLICY bar ON foo USING (true);
+			`,
+			pg.Error{Code: "42710", Message: "policy \"bar\" for table \"foo\" already exists"},
+		},
+		{
+			`
+			CREATE POLICY bar ON foo USING (true);
+			`,
+			pg.Error{Code: "42P01", Message: "relation \"foo\" does not exist"},
+		},
+		{
+			`
+			CREATE TABLE foo ();
+			DROP POLICY bar ON foo;
+			`,
+			pg.Error{Code: "42704", Message: "policy \"bar\" for table \"foo\" does not exist"},
+		},
 	} {
 		test := tc
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
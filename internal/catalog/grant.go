@@ -0,0 +1,194 @@
+package catalog
+
+import (
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/pg"
+
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// The privileges granted by ALL PRIVILEGES on a table, and on its columns
+var (
+	tablePrivileges  = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"}
+	columnPrivileges = []string{"SELECT", "INSERT", "UPDATE", "REFERENCES"}
+)
+
+// Record the table privileges granted or revoked by a GRANT or REVOKE
+// statement. Privileges on other kinds of objects aren't tracked. Neither are
+// privileges on relations missing from the catalog, such as views and
+// sequences, so these statements never fail.
+func updateGrants(c *pg.Catalog, n nodes.GrantStmt) {
+	if n.Objtype != nodes.ACL_OBJECT_RELATION {
+		return
+	}
+	// REVOKE GRANT OPTION FOR only takes away the right to grant the
+	// privilege to others
+	if !n.IsGrant && n.GrantOption {
+		return
+	}
+
+	var tables []pg.FQN
+	switch n.Targtype {
+	case nodes.ACL_TARGET_OBJECT:
+		for _, obj := range n.Objects.Items {
+			rv, ok := obj.(nodes.RangeVar)
+			if !ok {
+				continue
+			}
+			fqn, err := ParseRange(&rv)
+			if err != nil {
+				continue
+			}
+			tables = append(tables, fqn)
+		}
+	case nodes.ACL_TARGET_ALL_IN_SCHEMA:
+		for _, name := range stringSlice(n.Objects) {
+			for rel := range c.Schemas[name].Tables {
+				tables = append(tables, pg.FQN{Schema: name, Rel: rel})
+			}
+		}
+	}
+
+	privs := privileges(n.Privileges)
+	roles := grantees(n.Grantees)
+	for _, fqn := range tables {
+		table, exists := c.Schemas[fqn.Schema].Tables[fqn.Rel]
+		if !exists {
+			continue
+		}
+		for _, p := range privs {
+			for _, role := range roles {
+				if n.IsGrant {
+					grant(&table, role, p.name, p.cols)
+				} else {
+					revoke(&table, role, p.name, p.cols)
+				}
+			}
+		}
+		c.Schemas[fqn.Schema].Tables[fqn.Rel] = table
+	}
+}
+
+type privilege struct {
+	name string
+	cols []string
+}
+
+// A nil list of privileges means ALL PRIVILEGES
+func privileges(list nodes.List) []privilege {
+	if len(list.Items) == 0 {
+		list.Items = []nodes.Node{nodes.AccessPriv{}}
+	}
+	var privs []privilege
+	for _, item := range list.Items {
+		ap, ok := item.(nodes.AccessPriv)
+		if !ok {
+			continue
+		}
+		cols := stringSlice(ap.Cols)
+		if len(cols) == 0 {
+			cols = nil
+		}
+		if ap.PrivName != nil {
+			privs = append(privs, privilege{strings.ToUpper(*ap.PrivName), cols})
+			continue
+		}
+		all := tablePrivileges
+		if cols != nil {
+			all = columnPrivileges
+		}
+		for _, name := range all {
+			privs = append(privs, privilege{name, cols})
+		}
+	}
+	return privs
+}
+
+func grantees(list nodes.List) []string {
+	var names []string
+	for _, item := range list.Items {
+		rs, ok := item.(nodes.RoleSpec)
+		if !ok {
+			continue
+		}
+		switch rs.Roletype {
+		case nodes.ROLESPEC_CSTRING:
+			if rs.Rolename != nil {
+				names = append(names, *rs.Rolename)
+			}
+		case nodes.ROLESPEC_CURRENT_USER:
+			names = append(names, "current_user")
+		case nodes.ROLESPEC_SESSION_USER:
+			names = append(names, "session_user")
+		case nodes.ROLESPEC_PUBLIC:
+			names = append(names, "public")
+		}
+	}
+	return names
+}
+
+func grant(t *pg.Table, grantee, priv string, cols []string) {
+	for i, g := range t.Grants {
+		if g.Grantee != grantee || g.Privilege != priv {
+			continue
+		}
+		if len(g.Columns) == 0 {
+			return
+		}
+		if cols == nil {
+			t.Grants[i].Columns = nil
+			return
+		}
+		for _, col := range cols {
+			if !contains(g.Columns, col) {
+				t.Grants[i].Columns = append(t.Grants[i].Columns, col)
+			}
+		}
+		return
+	}
+	t.Grants = append(t.Grants, pg.Grant{
+		Grantee:   grantee,
+		Privilege: priv,
+		Columns:   append([]string(nil), cols...),
+	})
+}
+
+// Revoking a privilege on the table also revokes it on each column. Revoking
+// it on some columns leaves privileges on the whole table in place.
+func revoke(t *pg.Table, grantee, priv string, cols []string) {
+	var kept []pg.Grant
+	for _, g := range t.Grants {
+		if g.Grantee != grantee || g.Privilege != priv {
+			kept = append(kept, g)
+			continue
+		}
+		if cols == nil {
+			continue
+		}
+		if len(g.Columns) == 0 {
+			kept = append(kept, g)
+			continue
+		}
+		var remaining []string
+		for _, col := range g.Columns {
+			if !contains(cols, col) {
+				remaining = append(remaining, col)
+			}
+		}
+		if len(remaining) > 0 {
+			g.Columns = remaining
+			kept = append(kept, g)
+		}
+	}
+	t.Grants = kept
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package catalog
+
+import (
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/pg"
+
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+func policyTable(c *pg.Catalog, rv *nodes.RangeVar, location int) (pg.FQN, pg.Table, error) {
+	fqn, err := ParseRange(rv)
+	if err != nil {
+		return fqn, pg.Table{}, err
+	}
+	schema, exists := c.Schemas[fqn.Schema]
+	if !exists {
+		return fqn, pg.Table{}, wrap(pg.ErrorSchemaDoesNotExist(fqn.Schema), location)
+	}
+	table, exists := schema.Tables[fqn.Rel]
+	if !exists {
+		return fqn, pg.Table{}, wrap(pg.ErrorRelationDoesNotExist(fqn.Rel), location)
+	}
+	return fqn, table, nil
+}
+
+func policyIndex(t pg.Table, name string) int {
+	for i, p := range t.Policies {
+		if p.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func createPolicy(c *pg.Catalog, n nodes.CreatePolicyStmt, location int) error {
+	fqn, table, err := policyTable(c, n.Table, location)
+	if err != nil {
+		return err
+	}
+	name := *n.PolicyName
+	if policyIndex(table, name) >= 0 {
+		return wrap(pg.ErrorPolicyAlreadyExists(table.Name, name), location)
+	}
+	cmd := "all"
+	if n.CmdName != nil {
+		cmd = *n.CmdName
+	}
+	roles := grantees(n.Roles)
+	if len(roles) == 0 {
+		roles = []string{"public"}
+	}
+	table.Policies = append(table.Policies, pg.Policy{
+		Name:       name,
+		Command:    strings.ToUpper(cmd),
+		Permissive: n.Permissive,
+		Roles:      roles,
+	})
+	c.Schemas[fqn.Schema].Tables[fqn.Rel] = table
+	return nil
+}
+
+func alterPolicy(c *pg.Catalog, n nodes.AlterPolicyStmt, location int) error {
+	_, table, err := policyTable(c, n.Table, location)
+	if err != nil {
+		return err
+	}
+	idx := policyIndex(table, *n.PolicyName)
+	if idx < 0 {
+		return wrap(pg.ErrorPolicyDoesNotExist(table.Name, *n.PolicyName), location)
+	}
+	if roles := grantees(n.Roles); len(roles) > 0 {
+		table.Policies[idx].Roles = roles
+	}
+	return nil
+}
+
+func renamePolicy(c *pg.Catalog, n nodes.RenameStmt, location int) error {
+	_, table, err := policyTable(c, n.Relation, location)
+	if err != nil {
+		return err
+	}
+	idx := policyIndex(table, *n.Subname)
+	if idx < 0 {
+		return wrap(pg.ErrorPolicyDoesNotExist(table.Name, *n.Subname), location)
+	}
+	if policyIndex(table, *n.Newname) >= 0 {
+		return wrap(pg.ErrorPolicyAlreadyExists(table.Name, *n.Newname), location)
+	}
+	table.Policies[idx].Name = *n.Newname
+	return nil
+}
+
+// The objects of DROP POLICY name the table, qualified by its schema, and
+// then the policy
+func dropPolicy(c *pg.Catalog, n nodes.DropStmt, location int) error {
+	for _, obj := range n.Objects.Items {
+		list, ok := obj.(nodes.List)
+		if !ok || len(list.Items) < 2 {
+			continue
+		}
+		parts := stringSlice(list)
+		name := parts[len(parts)-1]
+		fqn, err := ParseList(nodes.List{Items: list.Items[:len(list.Items)-1]})
+		if err != nil {
+			return err
+		}
+		schema, exists := c.Schemas[fqn.Schema]
+		if !exists {
+			return wrap(pg.ErrorSchemaDoesNotExist(fqn.Schema), location)
+		}
+		table, exists := schema.Tables[fqn.Rel]
+		if !exists {
+			return wrap(pg.ErrorRelationDoesNotExist(fqn.Rel), location)
+		}
+		idx := policyIndex(table, name)
+		if idx < 0 {
+			if n.MissingOk {
+				continue
+			}
+			return wrap(pg.ErrorPolicyDoesNotExist(table.Name, name), location)
+		}
+		var kept []pg.Policy
+		for i, p := range table.Policies {
+			if i != idx {
+				kept = append(kept, p)
+			}
+		}
+		table.Policies = kept
+		schema.Tables[fqn.Rel] = table
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -9,17 +10,44 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v3"
 
 	"github.com/kyleconroy/sqlc/internal/config"
 )
 
 // Do runs the command logic.
 func Do(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
-	rootCmd := &cobra.Command{Use: "sqlc", SilenceUsage: true}
+	rootCmd := &cobra.Command{
+		Use:   "sqlc",
+		Short: "Generate type-safe Go code from SQL",
+		Long: `sqlc generates type-safe Go code from SQL. It reads the packages from a
+sqlc.yaml or sqlc.json file in the current directory, or the file given by
+--file. Paths in the configuration file are relative to the directory it's in.`,
+		SilenceUsage:           true,
+		BashCompletionFunction: bashCompletionFunction,
+	}
+	rootCmd.PersistentFlags().StringP("file", "f", "", "path to the configuration file, defaults to sqlc.yaml or sqlc.json in the current directory")
+	rootCmd.MarkPersistentFlagFilename("file", "yaml", "yml", "json")
+	for _, c := range []*cobra.Command{checkCmd, genCmd} {
+		c.Flags().Bool("cache", false, "reuse the analysis of query files that haven't changed since the last run, stored in "+cacheDir)
+		c.Flags().Bool("skip-version-check", false, "run even if the sqlc_version of the configuration file is a different version")
+		c.Flags().Bool("debug", false, "print the parse tree, relations, inferred types and Go types of each query to stderr, one JSON object per line")
+	}
+	checkCmd.Flags().String("migration", "", "check the queries against the schema as of this migration, a schema file name or its version such as 20200102150405")
+	genCmd.Flags().String("manifest", "", "write a JSON list of the generated files and their inputs, with their SHA-256 hashes, to this path")
+	genCmd.MarkFlagFilename("manifest", "json")
+	explainCmd.Flags().String("database-url", "", "PostgreSQL connection string, defaults to $DATABASE_URL")
+	explainCmd.Flags().Float64("min-rows", 10000, "only report sequential scans of tables with at least this many rows")
+	initCmd.Flags().String("engine", "postgresql", "database engine of the example schema and queries, postgresql or mysql")
+	flagValues(initCmd.Flags(), "engine", string(config.EnginePostgreSQL), string(config.EngineMySQL))
+	initCmd.Flags().String("package", "", "name of the generated package, defaults to the last element of --path")
+	initCmd.Flags().String("path", "db", "directory of the generated package")
+	initCmd.Flags().String("module", "", "Go module path, to write a go.mod file for a new module")
 	rootCmd.AddCommand(checkCmd)
 	rootCmd.AddCommand(genCmd)
+	rootCmd.AddCommand(explainCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(packagesCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	rootCmd.SetArgs(args)
@@ -39,74 +67,203 @@ func Do(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int
 
 var version string
 
+func buildVersion() string {
+	if version == "" {
+		// When no version is set, return the next bug fix version
+		// after the most recent tag
+		return "v1.0.1"
+	}
+	return version
+}
+
+// With --cache, the analysis of unchanged query files is reused between runs
+const cacheDir = ".sqlc-cache"
+
+// The directory of the configuration file and the settings of c, given its
+// flags and arguments
+func parseEnv(c *cobra.Command, args []string) (string, Env, error) {
+	var e Env
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", e, err
+	}
+	if file, _ := c.Flags().GetString("file"); file != "" {
+		e.File, err = filepath.Abs(file)
+		if err != nil {
+			return "", e, err
+		}
+		dir = filepath.Dir(e.File)
+	}
+	if useCache, _ := c.Flags().GetBool("cache"); useCache {
+		e.CacheDir = filepath.Join(dir, cacheDir)
+	}
+	e.SkipVersionCheck, _ = c.Flags().GetBool("skip-version-check")
+	e.Migration, _ = c.Flags().GetString("migration")
+	e.Debug, _ = c.Flags().GetBool("debug")
+	e.Packages = args
+	return dir, e, nil
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the sqlc version number",
 	Run: func(cmd *cobra.Command, args []string) {
-		if version == "" {
-			// When no version is set, return the next bug fix version
-			// after the most recent tag
-			fmt.Printf("%s\n", "v1.0.1")
-		} else {
-			fmt.Printf("%s\n", version)
-		}
+		fmt.Printf("%s\n", buildVersion())
 	},
 }
 
 var initCmd = &cobra.Command{
 	Use:   "init",
-	Short: "Create an empty sqlc.yaml settings file",
+	Short: "Create a sqlc.yaml settings file with an example schema and queries",
+	Long: `Create a sqlc.yaml settings file, an example schema.sql and an example
+query.sql in the current directory, so that sqlc generate works right away.
+With --file, the settings file is written to the given path instead. Files
+that already exist are left alone.`,
+	Example: `  sqlc init
+  sqlc init --engine mysql --path internal/store --module example.com/app`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if _, err := os.Stat("sqlc.yaml"); !os.IsNotExist(err) {
-			return nil
-		}
-		blob, err := yaml.Marshal(config.V1GenerateSettings{Version: "1"})
+		dir, e, err := parseEnv(cmd, nil)
 		if err != nil {
 			return err
 		}
-		return ioutil.WriteFile("sqlc.yaml", blob, 0644)
+		var opts InitOptions
+		if e.File != "" {
+			opts.Config = filepath.Base(e.File)
+		}
+		engine, _ := cmd.Flags().GetString("engine")
+		opts.Engine = config.Engine(engine)
+		opts.Package, _ = cmd.Flags().GetString("package")
+		opts.Path, _ = cmd.Flags().GetString("path")
+		opts.Module, _ = cmd.Flags().GetString("module")
+		return Init(dir, opts, cmd.OutOrStdout())
 	},
 }
 
 var genCmd = &cobra.Command{
-	Use:   "generate",
+	Use:   "generate [package...]",
 	Short: "Generate Go code from SQL",
+	Long: `Generate the code of every package in the configuration file, or of the
+named packages only. The files of a package are only written when all of its
+queries are valid.`,
+	Example: `  sqlc generate
+  sqlc generate db --cache
+  sqlc --file config/sqlc.yaml generate
+  sqlc generate --manifest sqlc-manifest.json`,
+	Annotations: map[string]string{argsAnnotation: "packages"},
 	Run: func(cmd *cobra.Command, args []string) {
 		stderr := cmd.ErrOrStderr()
-		dir, err := os.Getwd()
+		dir, e, err := parseEnv(cmd, args)
 		if err != nil {
 			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
 			os.Exit(1)
 		}
 
-		output, err := Generate(dir, stderr)
+		manifest, _ := cmd.Flags().GetString("manifest")
+		var output map[string]string
+		var m *Manifest
+		if manifest != "" {
+			output, m, err = GenerateManifest(e, dir, stderr)
+		} else {
+			output, err = Generate(e, dir, stderr)
+		}
 		if err != nil {
 			os.Exit(1)
 		}
 
-		for filename, source := range output {
-			os.MkdirAll(filepath.Dir(filename), 0755)
-			if err := ioutil.WriteFile(filename, []byte(source), 0644); err != nil {
-				fmt.Fprintf(stderr, "%s: %s\n", filename, err)
+		written, unchanged, err := writeFiles(output)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			os.Exit(1)
+		}
+		if m != nil {
+			if err := writeManifest(manifest, m); err != nil {
+				fmt.Fprintf(stderr, "%s: %s\n", manifest, err)
 				os.Exit(1)
 			}
 		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%d files written, %d unchanged\n", written, unchanged)
+	},
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Report sequential scans in the query plans of a live database",
+	Long: `Prepare every named query of the PostgreSQL packages against a live
+database and read its plan using EXPLAIN (FORMAT JSON). Parameters are passed
+as NULLs and the queries are never run. Each sequential scan of a table with at
+least --min-rows rows is reported, along with the filter that no index was
+found for.`,
+	Example: `  sqlc explain --database-url postgres://localhost/app
+  DATABASE_URL=postgres://localhost/app sqlc explain --min-rows 1000`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stderr := cmd.ErrOrStderr()
+		dir, e, err := parseEnv(cmd, nil)
+		if err != nil {
+			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
+			os.Exit(1)
+		}
+		url, _ := cmd.Flags().GetString("database-url")
+		if url == "" {
+			url = os.Getenv("DATABASE_URL")
+		}
+		if url == "" {
+			return fmt.Errorf("explain requires --database-url or DATABASE_URL")
+		}
+		minRows, _ := cmd.Flags().GetFloat64("min-rows")
+		return Explain(context.Background(), e, dir, url, minRows, cmd.OutOrStdout(), stderr)
 	},
 }
 
 var checkCmd = &cobra.Command{
-	Use:   "compile",
+	Use:   "compile [package...]",
 	Short: "Statically check SQL for syntax and type errors",
+	Long: `Check every package in the configuration file, or the named packages only,
+for syntax and type errors without writing any files. With --migration, the
+schema is built from the migration files up to and including the given one,
+to check that the queries of an older release still work against a database
+that has only been partly migrated.`,
+	Example: `  sqlc compile
+  sqlc compile db
+  sqlc compile --migration 20200102150405_add_users.sql`,
+	Annotations: map[string]string{argsAnnotation: "packages"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		stderr := cmd.ErrOrStderr()
-		dir, err := os.Getwd()
+		dir, e, err := parseEnv(cmd, args)
 		if err != nil {
 			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
 			os.Exit(1)
 		}
-		if _, err := Generate(dir, stderr); err != nil {
+		if _, err := Generate(e, dir, stderr); err != nil {
 			os.Exit(1)
 		}
 		return nil
 	},
 }
+
+var packagesCmd = &cobra.Command{
+	Use:    "__packages",
+	Short:  "Print the names of the configured packages, for shell completion",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, e, err := parseEnv(cmd, nil)
+		if err != nil {
+			return err
+		}
+		conf, err := readConfig(dir, e, ioutil.Discard)
+		if err != nil {
+			return err
+		}
+		for _, sql := range conf.SQL {
+			if sql.Gen.Go != nil {
+				fmt.Fprintln(cmd.OutOrStdout(), sql.Gen.Go.Package)
+			}
+			if sql.Gen.Kotlin != nil {
+				fmt.Fprintln(cmd.OutOrStdout(), sql.Gen.Kotlin.Package)
+			}
+		}
+		return nil
+	},
+}
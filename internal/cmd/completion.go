@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Commands annotated with argsAnnotation set to "packages" take the names of
+// configured packages as arguments
+const argsAnnotation = "sqlc_args"
+
+// The fixed values of a flag, for the zsh and fish scripts
+const valuesAnnotation = "sqlc_values"
+
+// Restrict the completion of the named flag to values
+func flagValues(flags *pflag.FlagSet, name string, values ...string) {
+	flags.SetAnnotation(name, valuesAnnotation, values)
+	flags.SetAnnotation(name, cobra.BashCompCustom, []string{"__sqlc_values " + strings.Join(values, " ")})
+}
+
+// Functions for the generated bash script. __sqlc_custom_func is called when
+// cobra has nothing to complete. Package names are read from the
+// configuration file given by --file, if it was typed before the word being
+// completed.
+const bashCompletionFunction = `__sqlc_values() {
+    COMPREPLY=($(compgen -W "$*" -- "${cur}"))
+}
+
+__sqlc_custom_func() {
+    case ${last_command} in
+        sqlc_generate | sqlc_compile)
+            local file=() i
+            for ((i = 1; i < ${#words[@]} - 1; i++)); do
+                case ${words[i]} in
+                    -f | --file) file=(--file "${words[i+1]}") ;;
+                    --file=*) file=("${words[i]}") ;;
+                esac
+            done
+            COMPREPLY=($(compgen -W "$(sqlc "${file[@]}" __packages 2>/dev/null)" -- "${cur}"))
+            ;;
+    esac
+}
+`
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish]",
+	Short: "Print a shell completion script",
+	Long: `Print a script that completes the commands, flags and configured package
+names of sqlc in the given shell. Package names are read from the
+configuration file in the current directory, or the one given by --file.`,
+	Example: `  source <(sqlc completion bash)
+  sqlc completion zsh > "${fpath[1]}/_sqlc"
+  sqlc completion fish > ~/.config/fish/completions/sqlc.fish`,
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Args:      cobra.ExactValidArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeCompletion(cmd.Root(), args[0], cmd.OutOrStdout())
+	},
+}
+
+func writeCompletion(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletion(w)
+	case "zsh":
+		_, err := io.WriteString(w, zshCompletion(root))
+		return err
+	case "fish":
+		_, err := io.WriteString(w, fishCompletion(root))
+		return err
+	default:
+		return fmt.Errorf("unknown shell %q, expected bash, zsh or fish", shell)
+	}
+}
+
+// The visible subcommands of root, in name order
+func completionCommands(root *cobra.Command) []*cobra.Command {
+	var cmds []*cobra.Command
+	for _, c := range root.Commands() {
+		if c.IsAvailableCommand() || c.Name() == "help" {
+			cmds = append(cmds, c)
+		}
+	}
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i].Name() < cmds[j].Name() })
+	return cmds
+}
+
+func completionFlags(flags *pflag.FlagSet) []*pflag.Flag {
+	var fs []*pflag.Flag
+	flags.VisitAll(func(f *pflag.Flag) {
+		// cobra only adds the help flag to the command being run
+		if !f.Hidden && f.Name != "help" {
+			fs = append(fs, f)
+		}
+	})
+	return fs
+}
+
+func isBoolFlag(f *pflag.Flag) bool {
+	return f.Value.Type() == "bool"
+}
+
+// Escape s for a single-quoted zsh _arguments spec or _describe entry
+func zshQuote(s string) string {
+	return strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`, `:`, `\:`).Replace(s)
+}
+
+func zshFlagSpec(f *pflag.Flag) string {
+	spec := "--" + f.Name
+	if f.Shorthand != "" {
+		spec = fmt.Sprintf("'(-%s --%s)'{-%s,--%s}'", f.Shorthand, f.Name, f.Shorthand, f.Name)
+	} else {
+		spec = "'" + spec
+	}
+	spec += "[" + zshQuote(f.Usage) + "]"
+	if !isBoolFlag(f) {
+		switch {
+		case len(f.Annotations[valuesAnnotation]) > 0:
+			spec += fmt.Sprintf(":%s:(%s)", f.Name, strings.Join(f.Annotations[valuesAnnotation], " "))
+		case len(f.Annotations[cobra.BashCompFilenameExt]) > 0:
+			spec += fmt.Sprintf(`:%s:_files -g "*.(%s)"`, f.Name, strings.Join(f.Annotations[cobra.BashCompFilenameExt], "|"))
+		default:
+			spec += fmt.Sprintf(":%s: ", f.Name)
+		}
+	}
+	return spec + "'"
+}
+
+func zshCompletion(root *cobra.Command) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n\n", root.Name())
+	b.WriteString(`_sqlc_packages() {
+  local -a packages
+  packages=(${(f)"$(sqlc $file __packages 2>/dev/null)"})
+  _describe 'package' packages
+}
+
+_sqlc() {
+  local -a file
+  local i
+  for ((i = 2; i < CURRENT; i++)); do
+    case $words[i] in
+      -f|--file) file=(--file "${words[i+1]}") ;;
+      --file=*) file=("$words[i]") ;;
+    esac
+  done
+
+  local curcontext="$curcontext" state line
+  _arguments -C \
+`)
+	for _, f := range completionFlags(root.PersistentFlags()) {
+		fmt.Fprintf(&b, "    %s \\\n", zshFlagSpec(f))
+	}
+	b.WriteString(`    '1: :->command' \
+    '*:: :->args'
+
+  case $state in
+    command)
+      local -a commands
+      commands=(
+`)
+	cmds := completionCommands(root)
+	var names []string
+	for _, c := range cmds {
+		names = append(names, c.Name())
+		fmt.Fprintf(&b, "        '%s:%s'\n", c.Name(), zshQuote(c.Short))
+	}
+	b.WriteString(`      )
+      _describe 'command' commands
+      ;;
+    args)
+      case $line[1] in
+`)
+	for _, c := range cmds {
+		var specs []string
+		for _, f := range completionFlags(c.NonInheritedFlags()) {
+			specs = append(specs, zshFlagSpec(f))
+		}
+		switch {
+		case c.Annotations[argsAnnotation] == "packages":
+			specs = append(specs, "'*:package:_sqlc_packages'")
+		case len(c.ValidArgs) > 0:
+			specs = append(specs, fmt.Sprintf("'1:%s:(%s)'", c.Name(), strings.Join(c.ValidArgs, " ")))
+		case c.Name() == "help":
+			specs = append(specs, fmt.Sprintf("'1:command:(%s)'", strings.Join(names, " ")))
+		}
+		if len(specs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "        %s)\n          _arguments \\\n            %s\n          ;;\n", c.Name(), strings.Join(specs, " \\\n            "))
+	}
+	b.WriteString(`      esac
+      ;;
+  esac
+}
+
+if [ "$funcstack[1]" = "_sqlc" ]; then
+  _sqlc "$@"
+else
+  compdef _sqlc sqlc
+fi
+`)
+	return b.String()
+}
+
+// Escape s for a single-quoted fish string
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func fishFlag(b *strings.Builder, condition string, f *pflag.Flag) {
+	fmt.Fprintf(b, "complete -c sqlc")
+	if condition != "" {
+		fmt.Fprintf(b, " -n %s", fishQuote(condition))
+	}
+	fmt.Fprintf(b, " -l %s", f.Name)
+	if f.Shorthand != "" {
+		fmt.Fprintf(b, " -s %s", f.Shorthand)
+	}
+	if !isBoolFlag(f) {
+		switch {
+		case len(f.Annotations[valuesAnnotation]) > 0:
+			fmt.Fprintf(b, " -x -a %s", fishQuote(strings.Join(f.Annotations[valuesAnnotation], " ")))
+		case len(f.Annotations[cobra.BashCompFilenameExt]) > 0:
+			fmt.Fprintf(b, " -r -F")
+		default:
+			fmt.Fprintf(b, " -x")
+		}
+	}
+	fmt.Fprintf(b, " -d %s\n", fishQuote(f.Usage))
+}
+
+func fishCompletion(root *cobra.Command) string {
+	var b strings.Builder
+	b.WriteString(`function __sqlc_packages
+    set -l args (commandline -opc)
+    set -l file
+    for i in (seq (count $args))
+        switch $args[$i]
+            case -f --file
+                set file --file $args[(math $i + 1)]
+            case '--file=*'
+                set file $args[$i]
+        end
+    end
+    sqlc $file __packages 2>/dev/null
+end
+
+complete -c sqlc -f
+`)
+	for _, f := range completionFlags(root.PersistentFlags()) {
+		fishFlag(&b, "", f)
+	}
+	cmds := completionCommands(root)
+	var names []string
+	for _, c := range cmds {
+		names = append(names, c.Name())
+		fmt.Fprintf(&b, "complete -c sqlc -n __fish_use_subcommand -a %s -d %s\n", c.Name(), fishQuote(c.Short))
+	}
+	for _, c := range cmds {
+		condition := "__fish_seen_subcommand_from " + c.Name()
+		for _, f := range completionFlags(c.NonInheritedFlags()) {
+			fishFlag(&b, condition, f)
+		}
+		switch {
+		case c.Annotations[argsAnnotation] == "packages":
+			fmt.Fprintf(&b, "complete -c sqlc -n %s -a '(__sqlc_packages)'\n", fishQuote(condition))
+		case len(c.ValidArgs) > 0:
+			fmt.Fprintf(&b, "complete -c sqlc -n %s -a %s\n", fishQuote(condition), fishQuote(strings.Join(c.ValidArgs, " ")))
+		case c.Name() == "help":
+			fmt.Fprintf(&b, "complete -c sqlc -n %s -a %s\n", fishQuote(condition), fishQuote(strings.Join(names, " ")))
+		}
+	}
+	return b.String()
+}
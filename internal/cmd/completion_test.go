@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestGeneratePackages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-packages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Both packages share the schema and queries written by init
+	conf := filepath.Join(dir, "config", "other.yaml")
+	if err := os.MkdirAll(filepath.Dir(conf), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Init(filepath.Dir(conf), InitOptions{Config: "other.yaml"}, ioutil.Discard); err != nil {
+		t.Fatal(err)
+	}
+	blob := `version: "1"
+packages:
+  - name: alpha
+    path: alpha
+    schema: schema.sql
+    queries: query.sql
+  - name: beta
+    path: beta
+    schema: schema.sql
+    queries: query.sql
+`
+	if err := ioutil.WriteFile(conf, []byte(blob), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr bytes.Buffer
+	output, err := Generate(Env{File: conf, Packages: []string{"beta"}}, filepath.Dir(conf), &stderr)
+	if err != nil {
+		t.Fatalf("generate: %s\n%s", err, stderr.String())
+	}
+	if _, ok := output[filepath.Join(dir, "config", "beta", "query.sql.go")]; !ok {
+		t.Errorf("beta wasn't generated: %v", output)
+	}
+	for filename := range output {
+		if strings.Contains(filename, "alpha") {
+			t.Errorf("alpha was generated: %s", filename)
+		}
+	}
+
+	stderr.Reset()
+	if _, err := Generate(Env{File: conf, Packages: []string{"gamma"}}, filepath.Dir(conf), &stderr); err == nil {
+		t.Fatal("expected an error for an unknown package")
+	}
+	if !strings.Contains(stderr.String(), `no package named "gamma"`) {
+		t.Errorf("unexpected error: %s", stderr.String())
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	root := &cobra.Command{Use: "sqlc"}
+	root.PersistentFlags().StringP("file", "f", "", "path to the configuration file")
+	root.MarkPersistentFlagFilename("file", "yaml", "json")
+	gen := &cobra.Command{
+		Use:         "generate",
+		Short:       "Generate Go code from SQL",
+		Annotations: map[string]string{argsAnnotation: "packages"},
+		Run:         func(*cobra.Command, []string) {},
+	}
+	gen.Flags().Bool("cache", false, "reuse the analysis of query files")
+	in := &cobra.Command{Use: "init", Short: "Create files", Run: func(*cobra.Command, []string) {}}
+	in.Flags().String("engine", "postgresql", "database engine")
+	flagValues(in.Flags(), "engine", "postgresql", "mysql")
+	root.AddCommand(gen, in)
+
+	for shell, want := range map[string][]string{
+		"zsh": {
+			`'(-f --file)'{-f,--file}'[path to the configuration file]:file:_files -g "*.(yaml|json)"'`,
+			`'generate:Generate Go code from SQL'`,
+			`'--cache[reuse the analysis of query files]'`,
+			`'*:package:_sqlc_packages'`,
+			`'--engine[database engine]:engine:(postgresql mysql)'`,
+		},
+		"fish": {
+			`complete -c sqlc -l file -s f -r -F -d 'path to the configuration file'`,
+			`complete -c sqlc -n __fish_use_subcommand -a generate -d 'Generate Go code from SQL'`,
+			`complete -c sqlc -n '__fish_seen_subcommand_from generate' -a '(__sqlc_packages)'`,
+			`complete -c sqlc -n '__fish_seen_subcommand_from init' -l engine -x -a 'postgresql mysql' -d 'database engine'`,
+		},
+		"bash": {
+			`flags_completion+=("__sqlc_values postgresql mysql")`,
+		},
+	} {
+		var b bytes.Buffer
+		if err := writeCompletion(root, shell, &b); err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range want {
+			if !strings.Contains(b.String(), line) {
+				t.Errorf("%s script is missing %s:\n%s", shell, line, b.String())
+			}
+		}
+	}
+	if err := writeCompletion(root, "tcsh", ioutil.Discard); err == nil {
+		t.Error("expected an error for an unknown shell")
+	}
+}
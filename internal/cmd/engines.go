@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+// Engines that analyze queries with the PostgreSQL parser and catalog
+func postgresEngine(engine config.Engine) bool {
+	switch engine {
+	case config.EnginePostgreSQL, config.EngineCockroachDB, config.EngineRedshift:
+		return true
+	}
+	return false
+}
+
+func catalogOptions(engine config.Engine) dinosql.CatalogOpts {
+	return dinosql.CatalogOpts{
+		CockroachDB: engine == config.EngineCockroachDB,
+		Redshift:    engine == config.EngineRedshift,
+	}
+}
+
+// Analyze the schema and queries of a package again with each of its
+// check_engines, and report every query whose Go API would be different. The
+// code is generated for the package's own engine, so the API and the SQL
+// strings are shared by all of them, except for SQLite, whose queries are
+// translated from the PostgreSQL ones on a best-effort basis and get their own
+// SQL strings.
+func checkEngines(name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts dinosql.ParserOpts, catalogs *catalogCache, result dinosql.Generateable, stderr io.Writer) bool {
+	errored := false
+	fail := func() {
+		if !errored {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+		}
+		errored = true
+	}
+
+	queries := result.GoQueries(combo)
+	want := querySignatures(queries)
+	for _, engine := range sql.CheckEngines {
+		if engine == sql.Engine {
+			continue
+		}
+		if engine == config.EngineSQLite {
+			if err := lintSQLite(sql, combo, queries, fail, stderr); err != nil {
+				fail()
+				fmt.Fprintf(stderr, "error: check_engines: %s\n", err)
+			}
+			continue
+		}
+		if !postgresEngine(sql.Engine) || !postgresEngine(engine) {
+			fail()
+			fmt.Fprintf(stderr, "error: check_engines: %s can't be checked with the %s engine\n", engine, sql.Engine)
+			continue
+		}
+
+		c, err := catalogs.parse(sql.Schema, catalogOptions(engine))
+		if err != nil {
+			fail()
+			printEngineErr(stderr, dir, engine, "schema", err)
+			continue
+		}
+		q, err := dinosql.ParseQueries(c, sql.Queries, parserOpts)
+		if err != nil {
+			fail()
+			printEngineErr(stderr, dir, engine, "queries", err)
+			continue
+		}
+
+		engineCombo := combo
+		engineCombo.Package.Engine = engine
+		got := querySignatures(q.GoQueries(engineCombo))
+		for _, query := range queries {
+			w, g := want[query.MethodName], got[query.MethodName]
+			for i, value := range w {
+				if i < len(g) && g[i] == value {
+					continue
+				}
+				fail()
+				other := "missing"
+				if i < len(g) {
+					other = g[i].typ
+				}
+				fmt.Fprintf(stderr, "%s: %s: %s is %s with %s and %s with %s\n",
+					query.SourceName, query.MethodName, value.name, value.typ,
+					sql.Engine, other, engine)
+			}
+		}
+	}
+	return errored
+}
+
+// Translate each query to SQLite, and report the ones that can't be, along
+// with the parameters and columns SQLite has no type for. This is a lint, not
+// a check by SQLite itself: see dinosql.TranslateSQLite. Settings that SQLite
+// can't be used with are returned as an error.
+func lintSQLite(sql config.SQL, combo config.CombinedSettings, queries []dinosql.GoQuery, fail func(), stderr io.Writer) error {
+	switch {
+	case !postgresEngine(sql.Engine):
+		return fmt.Errorf("sqlite can't be checked with the %s engine", sql.Engine)
+	case sql.Gen.Go == nil:
+		return errors.New("sqlite is only supported when generating Go")
+	case combo.Go.EmitPreparedQueries:
+		return errors.New("sqlite can't be used with emit_prepared_queries")
+	}
+	report := func(q dinosql.GoQuery, format string, args ...interface{}) {
+		fail()
+		fmt.Fprintf(stderr, "%s: %s: sqlite: %s\n", q.SourceName, q.MethodName, fmt.Sprintf(format, args...))
+	}
+	for _, q := range queries {
+		stmts := []string{q.SQL}
+		for _, s := range q.Script {
+			stmts = append(stmts, s.SQL)
+		}
+		if q.Page != nil {
+			stmts = append(stmts, q.Page.NextSQL)
+		}
+		for _, stmt := range stmts {
+			if _, err := dinosql.TranslateSQLite(stmt); err != nil {
+				report(q, "%s", err)
+				break
+			}
+		}
+		for _, v := range querySignatures([]dinosql.GoQuery{q})[q.MethodName] {
+			if strings.HasPrefix(v.typ, "[]") && v.typ != "[]byte" {
+				report(q, "%s is %s, but SQLite doesn't have arrays", v.name, v.typ)
+			}
+		}
+	}
+	return nil
+}
+
+func printEngineErr(stderr io.Writer, dir string, engine config.Engine, what string, err error) {
+	parserErr, ok := err.(*dinosql.ParserErr)
+	if !ok {
+		fmt.Fprintf(stderr, "error parsing %s with %s: %s\n", what, engine, err)
+		return
+	}
+	for _, fileErr := range parserErr.Errs {
+		fileErr.Err = fmt.Errorf("%s: %w", engine, fileErr.Err)
+		printFileErr(stderr, dir, fileErr)
+	}
+}
+
+type typedValue struct {
+	name string
+	typ  string
+}
+
+// The parameters and results of each query, by method name
+func querySignatures(queries []dinosql.GoQuery) map[string][]typedValue {
+	sigs := map[string][]typedValue{}
+	for _, q := range queries {
+		var sig []typedValue
+		for _, v := range []struct {
+			kind  string
+			value dinosql.GoQueryValue
+		}{{"parameter", q.Arg}, {"column", q.Ret}} {
+			if v.value.Struct != nil {
+				for _, f := range v.value.Struct.Fields {
+					sig = append(sig, typedValue{v.kind + " " + f.Name, f.Type})
+				}
+			} else if v.value.Typ != "" {
+				sig = append(sig, typedValue{v.kind + " " + v.value.Name, v.value.Typ})
+			}
+		}
+		sigs[q.MethodName] = sig
+	}
+	return sigs
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+// Explain plans every named query of the PostgreSQL packages in dir against
+// the database at url, and reports the sequential scans of tables with at
+// least minRows rows. The queries are prepared with their parameters as
+// typed NULLs and never executed.
+func Explain(ctx context.Context, e Env, dir, url string, minRows float64, stdout, stderr io.Writer) error {
+	conf, err := readConfig(dir, e, stderr)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// A generic plan doesn't depend on the NULL parameter values, which a
+	// custom plan would fold into constant false conditions. The setting needs
+	// PostgreSQL 12; older servers plan the queries as written.
+	conn.ExecContext(ctx, "SET plan_cache_mode = force_generic_plan")
+
+	catalogs := newCatalogCache()
+	for _, pkg := range conf.SQL {
+		if pkg.Engine != config.EnginePostgreSQL {
+			continue
+		}
+		schema := filepath.Join(dir, pkg.Schema)
+		c, err := catalogs.parse(schema, dinosql.CatalogOpts{})
+		if err != nil {
+			printParseErr(stderr, dir, "schema", err)
+			return err
+		}
+		opts := dinosql.ParserOpts{
+			Constants:  pkg.Constants,
+			SearchPath: pkg.SearchPath,
+		}
+		result, err := dinosql.ParseQueries(c, filepath.Join(dir, pkg.Queries), opts)
+		if err != nil {
+			printParseErr(stderr, dir, "queries", err)
+			return err
+		}
+		for _, q := range result.Queries {
+			if q.Name == "" {
+				continue
+			}
+			plan, err := explainQuery(ctx, conn, q)
+			if err != nil {
+				return fmt.Errorf("%s: %s: %w", relPath(dir, q.Filename), q.Name, err)
+			}
+			scans, err := sequentialScans(plan)
+			if err != nil {
+				return fmt.Errorf("%s: %s: %w", relPath(dir, q.Filename), q.Name, err)
+			}
+			for _, scan := range scans {
+				var rows float64
+				err := conn.QueryRowContext(ctx, tableRowsQuery, scan.Schema, scan.Relation).Scan(&rows)
+				if err != nil && err != sql.ErrNoRows {
+					return err
+				}
+				if rows < minRows {
+					continue
+				}
+				fmt.Fprintf(stdout, "%s: %s: %s\n", relPath(dir, q.Filename), q.Name, scan.warning(rows))
+			}
+		}
+	}
+	return nil
+}
+
+func relPath(dir, filename string) string {
+	return strings.TrimPrefix(filename, dir+"/")
+}
+
+func printParseErr(stderr io.Writer, dir, what string, err error) {
+	if parserErr, ok := err.(*dinosql.ParserErr); ok {
+		for _, fileErr := range parserErr.Errs {
+			printFileErr(stderr, dir, fileErr)
+		}
+	} else {
+		fmt.Fprintf(stderr, "error parsing %s: %s\n", what, err)
+	}
+}
+
+const explainStmt = "sqlc_explain"
+
+// The estimated number of rows in a table. Without a schema, the table is
+// found using the search path.
+const tableRowsQuery = `
+SELECT reltuples FROM pg_class
+WHERE oid = to_regclass(CASE WHEN $1 = '' THEN '' ELSE quote_ident($1) || '.' END || quote_ident($2))
+`
+
+func explainQuery(ctx context.Context, conn *sql.Conn, q *dinosql.Query) ([]byte, error) {
+	prepare := "PREPARE " + explainStmt
+	types := parameterTypes(q)
+	if len(types) > 0 {
+		prepare += " (" + strings.Join(types, ", ") + ")"
+	}
+	if _, err := conn.ExecContext(ctx, prepare+" AS "+q.DefaultSQL()); err != nil {
+		return nil, err
+	}
+	defer conn.ExecContext(ctx, "DEALLOCATE "+explainStmt)
+
+	explain := "EXPLAIN (FORMAT JSON, VERBOSE) EXECUTE " + explainStmt
+	if len(q.Params) > 0 {
+		nulls := make([]string, len(q.Params))
+		for i := range nulls {
+			nulls[i] = "NULL"
+		}
+		explain += " (" + strings.Join(nulls, ", ") + ")"
+	}
+	var plan []byte
+	if err := conn.QueryRowContext(ctx, explain).Scan(&plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// The types of the query parameters, in order. If any type is unknown the
+// server infers all of them.
+func parameterTypes(q *dinosql.Query) []string {
+	types := make([]string, len(q.Params))
+	for _, p := range q.Params {
+		if p.Number < 1 || p.Number > len(types) || p.Column.DataType == "" || p.Column.DataType == "any" {
+			return nil
+		}
+		typ := p.Column.DataType
+		if p.Column.IsArray {
+			typ += "[]"
+		}
+		types[p.Number-1] = typ
+	}
+	return types
+}
+
+type planNode struct {
+	NodeType string     `json:"Node Type"`
+	Relation string     `json:"Relation Name"`
+	Schema   string     `json:"Schema"`
+	Filter   string     `json:"Filter"`
+	Plans    []planNode `json:"Plans"`
+}
+
+type seqScan struct {
+	Schema   string
+	Relation string
+	Filter   string
+}
+
+func (s seqScan) table() string {
+	if s.Schema == "" {
+		return s.Relation
+	}
+	return s.Schema + "." + s.Relation
+}
+
+// A filtered sequential scan reads the whole table to find a few rows, which
+// an index on the filtered columns would avoid
+func (s seqScan) warning(rows float64) string {
+	msg := fmt.Sprintf("sequential scan on %s (about %.0f rows)", s.table(), rows)
+	if s.Filter != "" {
+		msg += fmt.Sprintf("; no index is used for the filter %s", s.Filter)
+	}
+	return msg
+}
+
+// Find the sequential scans in the output of EXPLAIN (FORMAT JSON)
+func sequentialScans(plan []byte) ([]seqScan, error) {
+	var out []struct {
+		Plan planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &out); err != nil {
+		return nil, err
+	}
+	var scans []seqScan
+	var walk func(n planNode)
+	walk = func(n planNode) {
+		if n.NodeType == "Seq Scan" {
+			scans = append(scans, seqScan{Schema: n.Schema, Relation: n.Relation, Filter: n.Filter})
+		}
+		for _, child := range n.Plans {
+			walk(child)
+		}
+	}
+	for _, o := range out {
+		walk(o.Plan)
+	}
+	return scans, nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+const nestedLoopPlan = `[
+  {
+    "Plan": {
+      "Node Type": "Nested Loop",
+      "Plans": [
+        {
+          "Node Type": "Seq Scan",
+          "Relation Name": "books",
+          "Schema": "public",
+          "Filter": "(books.title = $1)"
+        },
+        {
+          "Node Type": "Index Scan",
+          "Relation Name": "authors",
+          "Schema": "public",
+          "Index Name": "authors_pkey"
+        }
+      ]
+    }
+  }
+]`
+
+func TestSequentialScans(t *testing.T) {
+	scans, err := sequentialScans([]byte(nestedLoopPlan))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []seqScan{{Schema: "public", Relation: "books", Filter: "(books.title = $1)"}}
+	if diff := cmp.Diff(expected, scans); diff != "" {
+		t.Errorf("scans differed (-want +got):\n%s", diff)
+	}
+	warning := "sequential scan on public.books (about 50000 rows); no index is used for the filter (books.title = $1)"
+	if got := scans[0].warning(50000); got != warning {
+		t.Errorf("warning = %q; want %q", got, warning)
+	}
+}
+
+func TestParameterTypes(t *testing.T) {
+	q := &dinosql.Query{
+		Params: []dinosql.Parameter{
+			{Number: 2, Column: core.Column{DataType: "text", IsArray: true}},
+			{Number: 1, Column: core.Column{DataType: "pg_catalog.int4"}},
+		},
+	}
+	if diff := cmp.Diff([]string{"pg_catalog.int4", "text[]"}, parameterTypes(q)); diff != "" {
+		t.Errorf("types differed (-want +got):\n%s", diff)
+	}
+	q.Params[0].Column.DataType = "any"
+	if types := parameterTypes(q); types != nil {
+		t.Errorf("expected no types, got %v", types)
+	}
+}
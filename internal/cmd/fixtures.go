@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+// Add the fixtures package to the files generated for the package in out
+func addFixtures(files map[string]string, result dinosql.Generateable, combo config.CombinedSettings, out string) error {
+	r, ok := result.(dinosql.FixtureGenerateable)
+	if !ok {
+		return fmt.Errorf("emit_fixtures is not supported for engine %s", combo.Package.Engine)
+	}
+	importPath, err := goImportPath(out)
+	if err != nil {
+		return err
+	}
+	fixtures, err := dinosql.GenerateFixtures(r, combo, importPath)
+	if err != nil {
+		return err
+	}
+	for name, source := range fixtures {
+		files[name] = source
+	}
+	return nil
+}
+
+var modulePattern = regexp.MustCompile(`(?m)^module\s+"?([^\s"]+)"?`)
+
+// Find the import path of the package in dir using the go.mod file of its
+// module
+func goImportPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := dir; ; root = filepath.Dir(root) {
+		blob, err := ioutil.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			m := modulePattern.FindSubmatch(blob)
+			if m == nil {
+				return "", fmt.Errorf("%s: missing module path", filepath.Join(root, "go.mod"))
+			}
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(string(m[1]), filepath.ToSlash(rel)), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if filepath.Dir(root) == root {
+			return "", errors.New("emit_fixtures requires a go.mod file to find the import path of the generated package")
+		}
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -9,12 +10,16 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/kyleconroy/sqlc/internal/cache"
 	"github.com/kyleconroy/sqlc/internal/compiler"
 	"github.com/kyleconroy/sqlc/internal/config"
 	"github.com/kyleconroy/sqlc/internal/dinosql"
 	"github.com/kyleconroy/sqlc/internal/dinosql/kotlin"
 	"github.com/kyleconroy/sqlc/internal/mysql"
+	"github.com/kyleconroy/sqlc/internal/parallel"
+	core "github.com/kyleconroy/sqlc/internal/pg"
 )
 
 const errMessageNoVersion = `The configuration file must have a version number.
@@ -34,6 +39,11 @@ const errMessageNoPackages = `No packages are configured`
 
 func printFileErr(stderr io.Writer, dir string, fileErr dinosql.FileErr) {
 	filename := strings.TrimPrefix(fileErr.Filename, dir+"/")
+	if dup, ok := fileErr.Err.(*dinosql.DuplicateErr); ok {
+		first := *dup
+		first.First.Filename = strings.TrimPrefix(first.First.Filename, dir+"/")
+		fileErr.Err = &first
+	}
 	fmt.Fprintf(stderr, "%s:%d:%d: %s\n", filename, fileErr.Line, fileErr.Column, fileErr.Err)
 }
 
@@ -42,39 +52,78 @@ type outPair struct {
 	config.SQL
 }
 
-func Generate(dir string, stderr io.Writer) (map[string]string, error) {
-	var yamlMissing, jsonMissing bool
-	yamlPath := filepath.Join(dir, "sqlc.yaml")
-	jsonPath := filepath.Join(dir, "sqlc.json")
+// Settings that come from the command line instead of the configuration file
+type Env struct {
+	// Directory for the incremental generation cache. Caching is disabled if
+	// empty.
+	CacheDir string
 
-	if _, err := os.Stat(yamlPath); os.IsNotExist(err) {
-		yamlMissing = true
-	}
-	if _, err := os.Stat(jsonPath); os.IsNotExist(err) {
-		jsonMissing = true
-	}
+	// Path of the configuration file. If empty, sqlc.yaml or sqlc.json is
+	// read from the directory.
+	File string
 
-	if yamlMissing && jsonMissing {
-		fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
-		return nil, errors.New("config file missing")
-	}
+	// Contents of the configuration file, used instead of reading File or the
+	// directory if not nil
+	Config []byte
+
+	// Names of the packages to generate. All packages are generated if empty.
+	Packages []string
+
+	// Generate even if the configuration file pins a different sqlc version
+	SkipVersionCheck bool
+
+	// Build each schema only up to and including the file of this migration,
+	// a file name or a version. Every schema file is read if empty.
+	Migration string
+
+	// Print how each query was analyzed, one JSON object per line
+	Debug bool
+}
 
-	if !yamlMissing && !jsonMissing {
-		fmt.Fprintln(stderr, "error parsing sqlc.json: both files present")
-		return nil, errors.New("sqlc.json and sqlc.yaml present")
+// Read the configuration file, sqlc.yaml or sqlc.json in dir unless
+// e.File is set, reporting any problems to stderr
+func readConfig(dir string, e Env, stderr io.Writer) (config.Config, error) {
+	configPath := e.File
+	if e.Config != nil {
+		return parseConfig(e.Config, stderr)
 	}
+	if configPath == "" {
+		var yamlMissing, jsonMissing bool
+		yamlPath := filepath.Join(dir, "sqlc.yaml")
+		jsonPath := filepath.Join(dir, "sqlc.json")
 
-	configPath := yamlPath
-	if yamlMissing {
-		configPath = jsonPath
+		if _, err := os.Stat(yamlPath); os.IsNotExist(err) {
+			yamlMissing = true
+		}
+		if _, err := os.Stat(jsonPath); os.IsNotExist(err) {
+			jsonMissing = true
+		}
+
+		if yamlMissing && jsonMissing {
+			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
+			return config.Config{}, errors.New("config file missing")
+		}
+
+		if !yamlMissing && !jsonMissing {
+			fmt.Fprintln(stderr, "error parsing sqlc.json: both files present")
+			return config.Config{}, errors.New("sqlc.json and sqlc.yaml present")
+		}
+
+		configPath = yamlPath
+		if yamlMissing {
+			configPath = jsonPath
+		}
 	}
 
 	blob, err := ioutil.ReadFile(configPath)
 	if err != nil {
-		fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
-		return nil, err
+		fmt.Fprintf(stderr, "error parsing %s: file does not exist\n", filepath.Base(configPath))
+		return config.Config{}, err
 	}
+	return parseConfig(blob, stderr)
+}
 
+func parseConfig(blob []byte, stderr io.Writer) (config.Config, error) {
 	conf, err := config.ParseConfig(bytes.NewReader(blob))
 	if err != nil {
 		switch err {
@@ -86,7 +135,40 @@ func Generate(dir string, stderr io.Writer) (map[string]string, error) {
 			fmt.Fprintf(stderr, errMessageNoPackages)
 		}
 		fmt.Fprintf(stderr, "error parsing sqlc.json: %s\n", err)
-		return nil, err
+		return config.Config{}, err
+	}
+	return conf, nil
+}
+
+func Generate(e Env, dir string, stderr io.Writer) (map[string]string, error) {
+	output, _, err := generate(e, dir, stderr)
+	return output, err
+}
+
+// Generate, also returning the manifest of the generated files
+func GenerateManifest(e Env, dir string, stderr io.Writer) (map[string]string, *Manifest, error) {
+	output, results, err := generate(e, dir, stderr)
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := buildManifest(dir, results)
+	if err != nil {
+		fmt.Fprintf(stderr, "error writing manifest: %s\n", err)
+		return nil, nil, err
+	}
+	return output, m, nil
+}
+
+func generate(e Env, dir string, stderr io.Writer) (map[string]string, []pkgResult, error) {
+	conf, err := readConfig(dir, e, stderr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if conf.SQLCVersion != "" && !e.SkipVersionCheck {
+		if err := checkVersion(conf.SQLCVersion); err != nil {
+			fmt.Fprintln(stderr, err)
+			return nil, nil, err
+		}
 	}
 
 	output := map[string]string{}
@@ -107,109 +189,317 @@ func Generate(dir string, stderr io.Writer) (map[string]string, error) {
 			})
 		}
 	}
+	if len(e.Packages) > 0 {
+		pairs, err = selectPackages(pairs, e.Packages)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return nil, nil, err
+		}
+	}
 
-	for _, sql := range pairs {
-		combo := config.Combine(conf, sql.SQL)
-		var result dinosql.Generateable
+	// Packages are generated concurrently. Each package writes its errors to
+	// its own buffer, which are printed in configuration order. Packages that
+	// share a schema share the parsed catalog.
+	catalogs := newCatalogCache()
+	catalogs.migration = e.Migration
+	// The cache doesn't keep the traces printed by --debug
+	var qcache *cache.Cache
+	if e.CacheDir != "" && !e.Debug {
+		qcache, err = cache.Open(e.CacheDir)
+		if err != nil {
+			// Generation still works without the cache, just more slowly
+			fmt.Fprintf(stderr, "warning: cache disabled: %s\n", err)
+		}
+	}
+	results := make([]pkgResult, len(pairs))
+	parallel.Do(len(pairs), func(i int) {
+		results[i] = generatePkg(dir, conf, pairs[i], catalogs, qcache, e.Debug)
+	})
 
-		// TODO: This feels like a hack that will bite us later
-		sql.Schema = filepath.Join(dir, sql.Schema)
-		sql.Queries = filepath.Join(dir, sql.Queries)
+	for _, res := range results {
+		stderr.Write(res.stderr.Bytes())
+		if res.parseFailed {
+			errored = true
+			break
+		}
+		if res.errored {
+			errored = true
+			continue
+		}
+		for filename, source := range res.files {
+			output[filename] = source
+		}
+	}
 
-		var name string
-		parseOpts := dinosql.ParserOpts{}
-		if sql.Gen.Go != nil {
-			name = combo.Go.Package
-		} else if sql.Gen.Kotlin != nil {
-			parseOpts.UsePositionalParameters = true
-			name = combo.Kotlin.Package
+	if errored {
+		return nil, nil, fmt.Errorf("errored")
+	}
+	return output, results, nil
+}
+
+// Write each generated file, skipping those whose contents on disk are
+// already the same. Unchanged files keep their modification times, so build
+// tools that watch them don't rebuild or rerun tests.
+func writeFiles(output map[string]string) (written, unchanged int, err error) {
+	for filename, source := range output {
+		if existing, err := ioutil.ReadFile(filename); err == nil && string(existing) == source {
+			unchanged++
+			continue
+		}
+		os.MkdirAll(filepath.Dir(filename), 0755)
+		if err := ioutil.WriteFile(filename, []byte(source), 0644); err != nil {
+			return written, unchanged, fmt.Errorf("%s: %s", filename, err)
 		}
+		written++
+	}
+	return written, unchanged, nil
+}
 
-		result, errored = parse(name, dir, sql.SQL, combo, parseOpts, stderr)
-		if errored {
-			break
+func (p outPair) name() string {
+	if p.Gen.Kotlin != nil {
+		return p.Gen.Kotlin.Package
+	}
+	return p.Gen.Go.Package
+}
+
+// Keep the packages with the given names, in configuration order
+func selectPackages(pairs []outPair, names []string) ([]outPair, error) {
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = false
+	}
+	var selected []outPair
+	for _, p := range pairs {
+		if _, ok := wanted[p.name()]; ok {
+			wanted[p.name()] = true
+			selected = append(selected, p)
 		}
+	}
+	for _, name := range names {
+		if !wanted[name] {
+			return nil, fmt.Errorf("no package named %q", name)
+		}
+	}
+	return selected, nil
+}
 
-		var files map[string]string
-		var out string
-		if sql.Gen.Go != nil {
-			out = combo.Go.Out
-			files, err = dinosql.Generate(result, combo)
-		} else if sql.Gen.Kotlin != nil {
-			out = combo.Kotlin.Out
-			ktRes, ok := result.(kotlin.KtGenerateable)
-			if !ok {
-				err = fmt.Errorf("kotlin not supported for engine %s", combo.Package.Engine)
-				break
+type pkgResult struct {
+	name   string
+	files  map[string]string
+	stderr bytes.Buffer
+
+	// The schema and queries paths of the package, relative to the directory
+	// of the configuration file
+	inputs []string
+
+	// Packages after one that failed to parse aren't reported
+	parseFailed bool
+	errored     bool
+}
+
+func generatePkg(dir string, conf config.Config, sql outPair, catalogs *catalogCache, qcache *cache.Cache, debug bool) pkgResult {
+	var res pkgResult
+	stderr := &res.stderr
+	combo := config.Combine(conf, sql.SQL)
+
+	res.inputs = []string{sql.Schema, sql.Queries}
+
+	// TODO: This feels like a hack that will bite us later
+	sql.Schema = filepath.Join(dir, sql.Schema)
+	sql.Queries = filepath.Join(dir, sql.Queries)
+
+	var name string
+	parseOpts := dinosql.ParserOpts{
+		Engine:           sql.Engine,
+		Constants:        sql.Constants,
+		SearchPath:       sql.SearchPath,
+		ReadOnly:         sql.ReadOnly,
+		WarningsAsErrors: sql.WarningsAsErrors,
+		Cache:            qcache,
+		Debug:            debug,
+	}
+	if sql.Gen.Go != nil {
+		name = combo.Go.Package
+	} else if sql.Gen.Kotlin != nil {
+		parseOpts.UsePositionalParameters = true
+		name = combo.Kotlin.Package
+	}
+
+	res.name = name
+
+	result, errored := parse(name, dir, sql.SQL, combo, parseOpts, catalogs, stderr)
+	if errored {
+		res.parseFailed = true
+		return res
+	}
+
+	var files map[string]string
+	var out string
+	var err error
+	if sql.Gen.Go != nil {
+		out = combo.Go.Out
+		files, err = dinosql.Generate(result, combo)
+		if err == nil && combo.Go.EmitProtobuf {
+			err = addProtobuf(files, result, combo, filepath.Join(dir, out))
+		}
+		if err == nil {
+			if errs := dinosql.VerifyGo(files); len(errs) > 0 {
+				fmt.Fprintf(stderr, "# package %s\n", name)
+				for _, err := range errs {
+					fmt.Fprintf(stderr, "error generating code: %s\n", err)
+				}
+				res.errored = true
+				return res
 			}
+		}
+		if err == nil && combo.Go.EmitFixtures {
+			err = addFixtures(files, result, combo, filepath.Join(dir, out))
+		}
+	} else if sql.Gen.Kotlin != nil {
+		out = combo.Kotlin.Out
+		ktRes, ok := result.(kotlin.KtGenerateable)
+		if !ok {
+			err = fmt.Errorf("kotlin not supported for engine %s", combo.Package.Engine)
+		} else {
 			files, err = kotlin.KtGenerate(ktRes, combo)
 		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "# package %s\n", name)
+		fmt.Fprintf(stderr, "error generating code: %s\n", err)
+		res.errored = true
+		return res
+	}
+
+	if conf.SQLCVersion != "" {
+		header, err := versionHeader(dir, res.inputs)
 		if err != nil {
 			fmt.Fprintf(stderr, "# package %s\n", name)
 			fmt.Fprintf(stderr, "error generating code: %s\n", err)
-			errored = true
-			continue
+			res.errored = true
+			return res
 		}
+		stampFiles(files, header)
+	}
 
-		for n, source := range files {
-			filename := filepath.Join(dir, out, n)
-			output[filename] = source
-		}
+	res.files = map[string]string{}
+	for n, source := range files {
+		filename := filepath.Join(dir, out, n)
+		res.files[filename] = source
 	}
+	return res
+}
 
-	if errored {
-		return nil, fmt.Errorf("errored")
+// Parsing a schema is the most expensive step of generation, and many
+// packages read the same schema. Each schema path is parsed once per dialect.
+type catalogCache struct {
+	mu      sync.Mutex
+	entries map[catalogKey]*catalogEntry
+
+	// Schemas are only read up to this migration, if set
+	migration string
+}
+
+type catalogKey struct {
+	schema string
+	opts   dinosql.CatalogOpts
+}
+
+type catalogEntry struct {
+	once    sync.Once
+	catalog core.Catalog
+	err     error
+}
+
+func newCatalogCache() *catalogCache {
+	return &catalogCache{entries: map[catalogKey]*catalogEntry{}}
+}
+
+func (c *catalogCache) parse(schema string, opts dinosql.CatalogOpts) (core.Catalog, error) {
+	opts.Migration = c.migration
+	key := catalogKey{schema, opts}
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &catalogEntry{}
+		c.entries[key] = entry
 	}
-	return output, nil
+	c.mu.Unlock()
+	entry.once.Do(func() {
+		entry.catalog, entry.err = dinosql.ParseCatalogOpts(schema, opts)
+	})
+	return entry.catalog, entry.err
 }
 
-func parse(name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts dinosql.ParserOpts, stderr io.Writer) (dinosql.Generateable, bool) {
+func parse(name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts dinosql.ParserOpts, catalogs *catalogCache, stderr io.Writer) (dinosql.Generateable, bool) {
 	switch sql.Engine {
-	case config.EngineMySQL:
+	case config.EngineMySQL, config.EngineMariaDB, config.EngineTiDB:
+		if parserOpts.Debug {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+			fmt.Fprintf(stderr, "error: --debug isn't supported by the %s engine\n", sql.Engine)
+			return nil, true
+		}
+		if catalogs.migration != "" {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+			fmt.Fprintf(stderr, "error: --migration isn't supported by the %s engine\n", sql.Engine)
+			return nil, true
+		}
 		// Experimental MySQL support
 		q, err := mysql.GeneratePkg(name, sql.Schema, sql.Queries, combo)
 		if err != nil {
 			fmt.Fprintf(stderr, "# package %s\n", name)
-			if parserErr, ok := err.(*dinosql.ParserErr); ok {
-				for _, fileErr := range parserErr.Errs {
-					printFileErr(stderr, dir, fileErr)
-				}
-			} else {
-				fmt.Fprintf(stderr, "error parsing schema: %s\n", err)
-			}
+			printParseErr(stderr, dir, "schema", err)
 			return nil, true
 		}
 		return q, false
 
-	case config.EnginePostgreSQL:
-		c, err := dinosql.ParseCatalog(sql.Schema)
+	case config.EnginePostgreSQL, config.EngineCockroachDB, config.EngineRedshift:
+		c, err := catalogs.parse(sql.Schema, catalogOptions(sql.Engine))
 		if err != nil {
 			fmt.Fprintf(stderr, "# package %s\n", name)
-			if parserErr, ok := err.(*dinosql.ParserErr); ok {
-				for _, fileErr := range parserErr.Errs {
-					printFileErr(stderr, dir, fileErr)
-				}
-			} else {
-				fmt.Fprintf(stderr, "error parsing schema: %s\n", err)
-			}
+			printParseErr(stderr, dir, "schema", err)
+			return nil, true
+		}
+		c, err = dinosql.MarkSensitive(c, combo.Go.SensitiveColumns)
+		if err != nil {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+			fmt.Fprintf(stderr, "error: %s\n", err)
 			return nil, true
 		}
 
 		q, err := dinosql.ParseQueries(c, sql.Queries, parserOpts)
 		if err != nil {
 			fmt.Fprintf(stderr, "# package %s\n", name)
-			if parserErr, ok := err.(*dinosql.ParserErr); ok {
-				for _, fileErr := range parserErr.Errs {
-					printFileErr(stderr, dir, fileErr)
-				}
-			} else {
-				fmt.Fprintf(stderr, "error parsing queries: %s\n", err)
+			printParseErr(stderr, dir, "queries", err)
+			return nil, true
+		}
+		if len(q.Warnings) > 0 {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+			for _, w := range q.Warnings {
+				w.Err = fmt.Errorf("warning: %s", w.Err)
+				printFileErr(stderr, dir, w)
 			}
+		}
+		if parserOpts.Debug {
+			printDebug(stderr, name, q.Debug(combo))
+		}
+		result := &kotlin.Result{Result: q}
+		if checkEngines(name, dir, sql, combo, parserOpts, catalogs, result, stderr) {
 			return nil, true
 		}
-		return &kotlin.Result{Result: q}, false
+		return result, false
 
 	case config.EngineXLemon, config.EngineXDolphin, config.EngineXElephant:
+		if parserOpts.Debug {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+			fmt.Fprintf(stderr, "error: --debug isn't supported by the %s engine\n", sql.Engine)
+			return nil, true
+		}
+		if catalogs.migration != "" {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+			fmt.Fprintf(stderr, "error: --migration isn't supported by the %s engine\n", sql.Engine)
+			return nil, true
+		}
 		r, err := compiler.Run(sql, combo)
 		if err != nil {
 			fmt.Fprintf(stderr, "# package %s\n", name)
@@ -218,7 +508,26 @@ func parse(name, dir string, sql config.SQL, combo config.CombinedSettings, pars
 		}
 		return r, false
 
+	case config.EngineSQLite:
+		fmt.Fprintf(stderr, "# package %s\n", name)
+		fmt.Fprintf(stderr, "error: the %s engine can only be used in check_engines\n", sql.Engine)
+		return nil, true
+
 	default:
 		panic("invalid engine")
 	}
 }
+
+type debugLine struct {
+	Package string `json:"package"`
+	dinosql.QueryDebug
+}
+
+// Print the analysis of each query of a package as a line of JSON
+func printDebug(w io.Writer, name string, queries []dinosql.QueryDebug) {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, q := range queries {
+		enc.Encode(debugLine{Package: name, QueryDebug: q})
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteFilesUnchanged(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-write")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	same := filepath.Join(dir, "db", "models.go")
+	changed := filepath.Join(dir, "db", "query.sql.go")
+	output := map[string]string{same: "package db\n", changed: "package db\n"}
+	if written, unchanged, err := writeFiles(output); err != nil || written != 2 || unchanged != 0 {
+		t.Fatalf("first write: %d written, %d unchanged, %v", written, unchanged, err)
+	}
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for filename := range output {
+		if err := os.Chtimes(filename, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output[changed] = "package db\n\nconst x = 1\n"
+	if written, unchanged, err := writeFiles(output); err != nil || written != 1 || unchanged != 1 {
+		t.Fatalf("second write: %d written, %d unchanged, %v", written, unchanged, err)
+	}
+	info, err := os.Stat(same)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("unchanged file was rewritten at %s", info.ModTime())
+	}
+	blob, err := ioutil.ReadFile(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(blob) != output[changed] {
+		t.Errorf("changed file wasn't written: %q", blob)
+	}
+}
+
+func TestGenerateManifest(t *testing.T) {
+	dir, err := filepath.Abs(filepath.Join("..", "endtoend", "testdata", "sqlc_version"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stderr bytes.Buffer
+	output, m, err := GenerateManifest(Env{}, dir, &stderr)
+	if err != nil {
+		t.Fatalf("%s\n%s", err, stderr.String())
+	}
+	if len(m.Packages) != 1 {
+		t.Fatalf("expected one package: %+v", m)
+	}
+	pkg := m.Packages[0]
+	var inputs []string
+	for _, f := range pkg.Inputs {
+		inputs = append(inputs, f.Path)
+	}
+	if fmt.Sprint(inputs) != "[schema.sql query.sql]" {
+		t.Errorf("unexpected inputs: %v", inputs)
+	}
+	if len(pkg.Files) != len(output) {
+		t.Fatalf("manifest lists %d files, %d were generated", len(pkg.Files), len(output))
+	}
+	for _, f := range pkg.Files {
+		source, ok := output[filepath.Join(dir, filepath.FromSlash(f.Path))]
+		if !ok {
+			t.Errorf("%s wasn't generated", f.Path)
+			continue
+		}
+		if sum := fmt.Sprintf("%x", sha256.Sum256([]byte(source))); sum != f.SHA256 {
+			t.Errorf("%s: hash %s, expected %s", f.Path, f.SHA256, sum)
+		}
+	}
+}
+
+func TestCompileMigration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-migration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"sqlc.json":                        `{"version": "1", "packages": [{"path": "db", "schema": "migrations", "queries": "query.sql"}]}`,
+		"migrations/0001_users.up.sql":     "CREATE TABLE users (id int NOT NULL, name text NOT NULL);",
+		"migrations/0002_email.up.sql":     "ALTER TABLE users ADD COLUMN email text;",
+		"migrations/0003_no_name.up.sql":   "ALTER TABLE users DROP COLUMN name;",
+		"migrations/0003_no_name.down.sql": "ALTER TABLE users ADD COLUMN name text;",
+		"query.sql":                        "-- name: GetUser :one\nSELECT name FROM users WHERE id = $1;",
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, migration := range []string{"0001_users.up.sql", "2"} {
+		var stderr bytes.Buffer
+		if _, err := Generate(Env{Migration: migration}, dir, &stderr); err != nil {
+			t.Errorf("%s: %s", migration, stderr.String())
+		}
+	}
+
+	var stderr bytes.Buffer
+	if _, err := Generate(Env{}, dir, &stderr); err == nil {
+		t.Errorf("expected the query to fail against the fully migrated schema")
+	}
+	stderr.Reset()
+	if _, err := Generate(Env{Migration: "4"}, dir, &stderr); err == nil {
+		t.Errorf("expected an error for a missing migration")
+	} else if !strings.Contains(stderr.String(), `no schema file matches migration "4"`) {
+		t.Errorf("unexpected error: %s", stderr.String())
+	}
+}
+
+func TestGenerateDebug(t *testing.T) {
+	dir, err := filepath.Abs(filepath.Join("..", "endtoend", "testdata", "sqlc_version"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stderr bytes.Buffer
+	if _, err := Generate(Env{Debug: true}, dir, &stderr); err != nil {
+		t.Fatalf("%s\n%s", err, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	for _, line := range lines {
+		var q struct {
+			Package string          `json:"package"`
+			Name    string          `json:"name"`
+			AST     json.RawMessage `json:"ast"`
+			Go      struct {
+				Method string `json:"method"`
+			} `json:"go"`
+		}
+		if err := json.Unmarshal([]byte(line), &q); err != nil {
+			t.Fatalf("%s: %s", err, line)
+		}
+		if q.Package == "" || q.Name == "" || len(q.AST) == 0 || q.Go.Method == "" {
+			t.Errorf("incomplete debug output: %s", line)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// The starting point of a new project, written by sqlc init
+type InitOptions struct {
+	Config  string // The name of the configuration file, sqlc.yaml by default
+	Engine  config.Engine
+	Package string // The name of the generated package
+	Path    string // The directory of the generated package
+	Module  string // The Go module path, for a go.mod file
+}
+
+const initConfig = `version: "1"
+packages:
+  - name: "{{.Package}}"
+    path: "{{.Path}}"
+    engine: "{{.Engine}}"
+    schema: "schema.sql"
+    queries: "query.sql"
+`
+
+var initSchemas = map[config.Engine]string{
+	config.EnginePostgreSQL: `CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name TEXT      NOT NULL,
+  bio  TEXT
+);
+`,
+	config.EngineMySQL: `CREATE TABLE authors (
+  id   BIGINT  NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name TEXT    NOT NULL,
+  bio  TEXT
+);
+`,
+}
+
+var initQueries = map[config.Engine]string{
+	config.EnginePostgreSQL: `-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAuthors :many
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: CreateAuthor :one
+INSERT INTO authors (name, bio)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: DeleteAuthor :exec
+DELETE FROM authors
+WHERE id = $1;
+`,
+	config.EngineMySQL: `/* name: GetAuthor :one */
+SELECT * FROM authors
+WHERE id = ? LIMIT 1;
+
+/* name: ListAuthors :many */
+SELECT * FROM authors
+ORDER BY name;
+
+/* name: CreateAuthor :exec */
+INSERT INTO authors (name, bio)
+VALUES (?, ?);
+
+/* name: DeleteAuthor :exec */
+DELETE FROM authors
+WHERE id = ?;
+`,
+}
+
+const initModule = `module {{.Module}}
+
+go 1.13
+`
+
+// Write a configuration file, an example schema and example queries to dir,
+// along with a go.mod file if a module path is given. Existing files are
+// left alone.
+func Init(dir string, opts InitOptions, stdout io.Writer) error {
+	if opts.Engine == "" {
+		opts.Engine = config.EnginePostgreSQL
+	}
+	schema, ok := initSchemas[opts.Engine]
+	if !ok {
+		return fmt.Errorf("unknown engine %q, expected %s or %s", opts.Engine, config.EnginePostgreSQL, config.EngineMySQL)
+	}
+	if opts.Path == "" {
+		opts.Path = "db"
+	}
+	if opts.Package == "" {
+		opts.Package = filepath.Base(opts.Path)
+	}
+	configExists := exists(filepath.Join(dir, "sqlc.json"))
+	if opts.Config == "" {
+		opts.Config = "sqlc.yaml"
+	} else {
+		configExists = false
+	}
+
+	files := []struct {
+		name     string
+		contents string
+		skip     bool
+	}{
+		{opts.Config, initConfig, configExists},
+		{"schema.sql", schema, false},
+		{"query.sql", initQueries[opts.Engine], false},
+		{"go.mod", initModule, opts.Module == ""},
+	}
+	for _, f := range files {
+		if f.skip {
+			continue
+		}
+		path := filepath.Join(dir, f.name)
+		if exists(path) {
+			fmt.Fprintf(stdout, "%s already exists, skipping\n", f.name)
+			continue
+		}
+		var b strings.Builder
+		if err := template.Must(template.New(f.name).Parse(f.contents)).Execute(&b, opts); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, []byte(b.String()), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "created %s\n", f.name)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, opts.Path), 0755); err != nil {
+		return err
+	}
+	if opts.Module != "" {
+		fmt.Fprintf(stdout, "run sqlc generate to write package %s/%s\n", opts.Module, filepath.ToSlash(opts.Path))
+	} else {
+		fmt.Fprintf(stdout, "run sqlc generate to write package %s in %s\n", opts.Package, opts.Path)
+	}
+	return nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !os.IsNotExist(err)
+}
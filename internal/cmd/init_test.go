@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+func TestInit(t *testing.T) {
+	for _, engine := range []config.Engine{config.EnginePostgreSQL, config.EngineMySQL} {
+		engine := engine
+		t.Run(string(engine), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "sqlc-init")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			var stdout bytes.Buffer
+			opts := InitOptions{Engine: engine, Path: "internal/store", Module: "example.com/app"}
+			if err := Init(dir, opts, &stdout); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range []string{"sqlc.yaml", "schema.sql", "query.sql", "go.mod"} {
+				if !strings.Contains(stdout.String(), "created "+name) {
+					t.Errorf("%s wasn't created:\n%s", name, stdout.String())
+				}
+			}
+
+			var stderr bytes.Buffer
+			output, err := Generate(Env{}, dir, &stderr)
+			if err != nil {
+				t.Fatalf("generate: %s\n%s", err, stderr.String())
+			}
+			source, ok := output[filepath.Join(dir, "internal", "store", "query.sql.go")]
+			if !ok {
+				t.Fatalf("query.sql.go wasn't generated: %v", output)
+			}
+			if !strings.HasPrefix(strings.SplitN(source, "package ", 2)[1], "store\n") {
+				t.Errorf("expected package store:\n%s", source)
+			}
+
+			// Running init again leaves the files alone
+			if err := ioutil.WriteFile(filepath.Join(dir, "query.sql"), []byte("-- edited\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			stdout.Reset()
+			if err := Init(dir, opts, &stdout); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(stdout.String(), "created") {
+				t.Errorf("existing files were overwritten:\n%s", stdout.String())
+			}
+			blob, err := ioutil.ReadFile(filepath.Join(dir, "query.sql"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(blob) != "-- edited\n" {
+				t.Errorf("query.sql was overwritten: %q", blob)
+			}
+		})
+	}
+}
+
+func TestInitUnknownEngine(t *testing.T) {
+	if err := Init(os.TempDir(), InitOptions{Engine: "oracle"}, ioutil.Discard); err == nil {
+		t.Fatal("expected an error for an unknown engine")
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+// A list of the files generated for each package and the SQL files they were
+// generated from, for build systems that need to declare the inputs and
+// outputs of a rule. Paths are slash-separated and relative to the directory
+// of the configuration file.
+type Manifest struct {
+	SQLCVersion string            `json:"sqlc_version"`
+	Packages    []ManifestPackage `json:"packages"`
+}
+
+type ManifestPackage struct {
+	Name   string         `json:"name"`
+	Inputs []ManifestFile `json:"inputs"`
+	Files  []ManifestFile `json:"files"`
+}
+
+type ManifestFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+func buildManifest(dir string, results []pkgResult) (*Manifest, error) {
+	m := &Manifest{SQLCVersion: buildVersion(), Packages: []ManifestPackage{}}
+	for _, res := range results {
+		pkg := ManifestPackage{Name: res.name, Inputs: []ManifestFile{}, Files: []ManifestFile{}}
+		seen := map[string]bool{}
+		for _, input := range res.inputs {
+			files, err := dinosql.ReadSQLFiles(filepath.Join(dir, input))
+			if err != nil {
+				return nil, err
+			}
+			for _, filename := range files {
+				if seen[filename] {
+					continue
+				}
+				seen[filename] = true
+				blob, err := ioutil.ReadFile(filename)
+				if err != nil {
+					return nil, err
+				}
+				f, err := manifestFile(dir, filename, blob)
+				if err != nil {
+					return nil, err
+				}
+				pkg.Inputs = append(pkg.Inputs, f)
+			}
+		}
+		for filename, source := range res.files {
+			f, err := manifestFile(dir, filename, []byte(source))
+			if err != nil {
+				return nil, err
+			}
+			pkg.Files = append(pkg.Files, f)
+		}
+		sort.Slice(pkg.Files, func(i, j int) bool { return pkg.Files[i].Path < pkg.Files[j].Path })
+		m.Packages = append(m.Packages, pkg)
+	}
+	return m, nil
+}
+
+func manifestFile(dir, filename string, blob []byte) (ManifestFile, error) {
+	rel, err := filepath.Rel(dir, filename)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	sum := sha256.Sum256(blob)
+	return ManifestFile{Path: filepath.ToSlash(rel), SHA256: hex.EncodeToString(sum[:])}, nil
+}
+
+// Write m to path as indented JSON
+func writeManifest(path string, m *Manifest) error {
+	blob, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(blob, '\n'), 0644)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+// Add the .proto file for the models to the files generated for the package
+// in out. The field numbers written there by the previous run are kept.
+func addProtobuf(files map[string]string, result dinosql.Generateable, combo config.CombinedSettings, out string) error {
+	fields, err := ioutil.ReadFile(filepath.Join(out, dinosql.ProtobufFieldsFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	proto, err := dinosql.GenerateProtobuf(result, combo, fields)
+	if err != nil {
+		return err
+	}
+	for name, source := range proto {
+		if _, ok := files[name]; ok {
+			return fmt.Errorf("emit_protobuf: %s is already generated for the queries", name)
+		}
+		files[name] = source
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+const generatedMarker = "// Code generated by sqlc. DO NOT EDIT.\n"
+
+// The sqlc_version of a configuration file pins the version of sqlc that
+// generates its packages, so that everyone on a team generates the same code
+func checkVersion(pinned string) error {
+	current := buildVersion()
+	if strings.TrimPrefix(pinned, "v") == strings.TrimPrefix(current, "v") {
+		return nil
+	}
+	return fmt.Errorf("sqlc_version is %s, but this is sqlc %s; install sqlc %s or pass --skip-version-check", pinned, current, pinned)
+}
+
+// The comment added to generated files when the version is pinned. It names
+// the version of sqlc and the SHA-256 hash of each input, relative to dir, so
+// that a CI job can regenerate the code and fail on any difference.
+func versionHeader(dir string, inputs []string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// versions:\n//   sqlc %s\n// inputs:\n", buildVersion())
+	for _, input := range inputs {
+		sum, err := inputHash(filepath.Join(dir, input))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "//   %s sha256:%x\n", filepath.ToSlash(filepath.Clean(input)), sum)
+	}
+	return b.String(), nil
+}
+
+// The hash of the SQL files that sqlc reads from path, a file or a directory
+func inputHash(path string) ([]byte, error) {
+	files, err := dinosql.ReadSQLFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	for _, filename := range files {
+		blob, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.Base(filename), len(blob))
+		h.Write(blob)
+	}
+	return h.Sum(nil), nil
+}
+
+// Add header after the generated code comment of each file
+func stampFiles(files map[string]string, header string) {
+	for name, source := range files {
+		if strings.HasPrefix(source, generatedMarker) {
+			files[name] = generatedMarker + header + strings.TrimPrefix(source, generatedMarker)
+		}
+	}
+}
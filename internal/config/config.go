@@ -7,6 +7,7 @@ import (
 	"go/types"
 	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/kyleconroy/sqlc/internal/pg"
@@ -39,6 +40,20 @@ const (
 	EngineMySQL      Engine = "mysql"
 	EnginePostgreSQL Engine = "postgresql"
 
+	// CockroachDB uses the PostgreSQL engine, with its own DDL and types
+	EngineCockroachDB Engine = "cockroachdb"
+
+	// Redshift uses the PostgreSQL engine, ignoring its storage clauses
+	EngineRedshift Engine = "redshift"
+
+	// MariaDB and TiDB use the MySQL engine, with their own extensions
+	EngineMariaDB Engine = "mariadb"
+	EngineTiDB    Engine = "tidb"
+
+	// SQLite can only be listed in check_engines. Queries are translated from
+	// the PostgreSQL engine's analysis.
+	EngineSQLite Engine = "sqlite"
+
 	// Experimental engines
 	EngineXLemon    Engine = "_lemon"
 	EngineXDolphin  Engine = "_dolphin"
@@ -46,9 +61,10 @@ const (
 )
 
 type Config struct {
-	Version string `json:"version" yaml:"version"`
-	SQL     []SQL  `json:"sql" yaml:"sql"`
-	Gen     Gen    `json:"overrides,omitempty" yaml:"overrides"`
+	Version     string `json:"version" yaml:"version"`
+	SQLCVersion string `json:"sqlc_version,omitempty" yaml:"sqlc_version"`
+	SQL         []SQL  `json:"sql" yaml:"sql"`
+	Gen         Gen    `json:"overrides,omitempty" yaml:"overrides"`
 }
 
 type Gen struct {
@@ -66,10 +82,15 @@ type GenKotlin struct {
 }
 
 type SQL struct {
-	Engine  Engine `json:"engine,omitempty" yaml:"engine"`
-	Schema  string `json:"schema" yaml:"schema"`
-	Queries string `json:"queries" yaml:"queries"`
-	Gen     SQLGen `json:"gen" yaml:"gen"`
+	Engine           Engine            `json:"engine,omitempty" yaml:"engine"`
+	CheckEngines     []Engine          `json:"check_engines,omitempty" yaml:"check_engines"`
+	Schema           string            `json:"schema" yaml:"schema"`
+	Queries          string            `json:"queries" yaml:"queries"`
+	Constants        map[string]string `json:"constants,omitempty" yaml:"constants"`
+	SearchPath       []string          `json:"search_path,omitempty" yaml:"search_path"`
+	ReadOnly         bool              `json:"read_only,omitempty" yaml:"read_only"`
+	WarningsAsErrors bool              `json:"warnings_as_errors,omitempty" yaml:"warnings_as_errors"`
+	Gen              SQLGen            `json:"gen" yaml:"gen"`
 }
 
 type SQLGen struct {
@@ -78,13 +99,31 @@ type SQLGen struct {
 }
 
 type SQLGo struct {
-	EmitInterface       bool              `json:"emit_interface" yaml:"emit_interface"`
-	EmitJSONTags        bool              `json:"emit_json_tags" yaml:"emit_json_tags"`
-	EmitPreparedQueries bool              `json:"emit_prepared_queries" yaml:"emit_prepared_queries":`
-	Package             string            `json:"package" yaml:"package"`
-	Out                 string            `json:"out" yaml:"out"`
-	Overrides           []Override        `json:"overrides,omitempty" yaml:"overrides"`
-	Rename              map[string]string `json:"rename,omitempty" yaml:"rename"`
+	EmitInterface        bool                         `json:"emit_interface" yaml:"emit_interface"`
+	EmitJSONTags         bool                         `json:"emit_json_tags" yaml:"emit_json_tags"`
+	EmitPreparedQueries  bool                         `json:"emit_prepared_queries" yaml:"emit_prepared_queries":`
+	EmitExportedQueries  bool                         `json:"emit_exported_queries" yaml:"emit_exported_queries"`
+	EmitHooks            bool                         `json:"emit_hooks" yaml:"emit_hooks"`
+	EmitRetries          bool                         `json:"emit_retries" yaml:"emit_retries"`
+	EmitMock             bool                         `json:"emit_mock" yaml:"emit_mock"`
+	EmitFixtures         bool                         `json:"emit_fixtures" yaml:"emit_fixtures"`
+	EmitRowStructs       bool                         `json:"emit_row_structs" yaml:"emit_row_structs"`
+	EmitSharedRowStructs bool                         `json:"emit_shared_row_structs" yaml:"emit_shared_row_structs"`
+	EmitEnumHelpers      bool                         `json:"emit_enum_helpers" yaml:"emit_enum_helpers"`
+	EmitModelHelpers     bool                         `json:"emit_model_helpers" yaml:"emit_model_helpers"`
+	EmitProtobuf         bool                         `json:"emit_protobuf" yaml:"emit_protobuf"`
+	ProtobufPackage      string                       `json:"protobuf_package,omitempty" yaml:"protobuf_package"`
+	ProtobufGoPackage    string                       `json:"protobuf_go_package,omitempty" yaml:"protobuf_go_package"`
+	EnumCase             string                       `json:"enum_case,omitempty" yaml:"enum_case"`
+	OmitEnumPrefix       bool                         `json:"omit_enum_prefix" yaml:"omit_enum_prefix"`
+	EnumValues           map[string]map[string]string `json:"enum_values,omitempty" yaml:"enum_values"`
+	TypeDefaults         map[string]TypeDefault       `json:"type_defaults,omitempty" yaml:"type_defaults"`
+	SensitiveColumns     []string                     `json:"sensitive_columns,omitempty" yaml:"sensitive_columns"`
+	SensitiveTag         string                       `json:"sensitive_tag,omitempty" yaml:"sensitive_tag"`
+	Package              string                       `json:"package" yaml:"package"`
+	Out                  string                       `json:"out" yaml:"out"`
+	Overrides            []Override                   `json:"overrides,omitempty" yaml:"overrides"`
+	Rename               map[string]string            `json:"rename,omitempty" yaml:"rename"`
 }
 
 type SQLKotlin struct {
@@ -207,6 +246,55 @@ func (o *Override) Parse() error {
 	return nil
 }
 
+// The Go types of every column of a database type in a package, such as
+// `string` for `uuid`, so that the type doesn't need an override for each
+// nullability
+type TypeDefault struct {
+	// name of the golang type to use for NOT NULL columns
+	GoType string `json:"go_type" yaml:"go_type"`
+
+	// name of the golang type to use for nullable columns. Without one, they
+	// keep the type sqlc would use.
+	NullGoType string `json:"null_go_type,omitempty" yaml:"null_go_type"`
+}
+
+// Turn the type defaults of a package into db_type overrides, which are listed
+// after the package's own overrides so that those take precedence. Each type
+// is matched with and without the pg_catalog schema.
+func typeDefaultOverrides(defaults map[string]TypeDefault) ([]Override, error) {
+	dbTypes := make([]string, 0, len(defaults))
+	for dbType := range defaults {
+		dbTypes = append(dbTypes, dbType)
+	}
+	sort.Strings(dbTypes)
+
+	var overrides []Override
+	for _, dbType := range dbTypes {
+		def := defaults[dbType]
+		if def.GoType == "" {
+			return nil, fmt.Errorf("type default for %q must specify `go_type`", dbType)
+		}
+		names := []string{dbType}
+		if !strings.HasPrefix(dbType, "pg_catalog.") {
+			names = append(names, "pg_catalog."+dbType)
+		} else {
+			names = append(names, strings.TrimPrefix(dbType, "pg_catalog."))
+		}
+		for _, name := range names {
+			overrides = append(overrides, Override{GoType: def.GoType, DBType: name})
+			if def.NullGoType != "" {
+				overrides = append(overrides, Override{GoType: def.NullGoType, DBType: name, Null: true})
+			}
+		}
+	}
+	for i := range overrides {
+		if err := overrides[i].Parse(); err != nil {
+			return nil, err
+		}
+	}
+	return overrides, nil
+}
+
 var ErrMissingVersion = errors.New("no version number")
 var ErrUnknownVersion = errors.New("invalid version number")
 var ErrMissingEngine = errors.New("unknown engine")
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -148,3 +149,32 @@ func TestTypeOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeDefaultOverrides(t *testing.T) {
+	overrides, err := typeDefaultOverrides(map[string]TypeDefault{
+		"uuid":                   {GoType: "string", NullGoType: "database/sql.NullString"},
+		"pg_catalog.timestamptz": {GoType: "github.com/example/clock.Time"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, o := range overrides {
+		got = append(got, fmt.Sprintf("%s null=%t %s", o.DBType, o.Null, o.GoTypeName))
+	}
+	want := []string{
+		"pg_catalog.timestamptz null=false clock.Time",
+		"timestamptz null=false clock.Time",
+		"uuid null=false string",
+		"uuid null=true sql.NullString",
+		"pg_catalog.uuid null=false string",
+		"pg_catalog.uuid null=true sql.NullString",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("differed (-want +got):\n%s", diff)
+	}
+
+	if _, err := typeDefaultOverrides(map[string]TypeDefault{"uuid": {}}); err == nil {
+		t.Errorf("expected an error for a type default without go_type")
+	}
+}
@@ -9,22 +9,46 @@ import (
 )
 
 type V1GenerateSettings struct {
-	Version   string              `json:"version" yaml:"version"`
-	Packages  []v1PackageSettings `json:"packages" yaml:"packages"`
-	Overrides []Override          `json:"overrides,omitempty" yaml:"overrides,omitempty"`
-	Rename    map[string]string   `json:"rename,omitempty" yaml:"rename,omitempty"`
+	Version     string              `json:"version" yaml:"version"`
+	SQLCVersion string              `json:"sqlc_version,omitempty" yaml:"sqlc_version,omitempty"`
+	Packages    []v1PackageSettings `json:"packages" yaml:"packages"`
+	Overrides   []Override          `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+	Rename      map[string]string   `json:"rename,omitempty" yaml:"rename,omitempty"`
 }
 
 type v1PackageSettings struct {
-	Name                string     `json:"name" yaml:"name"`
-	Engine              Engine     `json:"engine,omitempty" yaml:"engine"`
-	Path                string     `json:"path" yaml:"path"`
-	Schema              string     `json:"schema" yaml:"schema"`
-	Queries             string     `json:"queries" yaml:"queries"`
-	EmitInterface       bool       `json:"emit_interface" yaml:"emit_interface"`
-	EmitJSONTags        bool       `json:"emit_json_tags" yaml:"emit_json_tags"`
-	EmitPreparedQueries bool       `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
-	Overrides           []Override `json:"overrides" yaml:"overrides"`
+	Name                 string                       `json:"name" yaml:"name"`
+	Engine               Engine                       `json:"engine,omitempty" yaml:"engine"`
+	CheckEngines         []Engine                     `json:"check_engines,omitempty" yaml:"check_engines"`
+	Path                 string                       `json:"path" yaml:"path"`
+	Schema               string                       `json:"schema" yaml:"schema"`
+	Queries              string                       `json:"queries" yaml:"queries"`
+	EmitInterface        bool                         `json:"emit_interface" yaml:"emit_interface"`
+	EmitJSONTags         bool                         `json:"emit_json_tags" yaml:"emit_json_tags"`
+	EmitPreparedQueries  bool                         `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
+	EmitExportedQueries  bool                         `json:"emit_exported_queries" yaml:"emit_exported_queries"`
+	EmitHooks            bool                         `json:"emit_hooks" yaml:"emit_hooks"`
+	EmitRetries          bool                         `json:"emit_retries" yaml:"emit_retries"`
+	EmitMock             bool                         `json:"emit_mock" yaml:"emit_mock"`
+	EmitFixtures         bool                         `json:"emit_fixtures" yaml:"emit_fixtures"`
+	EmitRowStructs       bool                         `json:"emit_row_structs" yaml:"emit_row_structs"`
+	EmitSharedRowStructs bool                         `json:"emit_shared_row_structs" yaml:"emit_shared_row_structs"`
+	EmitEnumHelpers      bool                         `json:"emit_enum_helpers" yaml:"emit_enum_helpers"`
+	EmitModelHelpers     bool                         `json:"emit_model_helpers" yaml:"emit_model_helpers"`
+	EmitProtobuf         bool                         `json:"emit_protobuf" yaml:"emit_protobuf"`
+	ProtobufPackage      string                       `json:"protobuf_package,omitempty" yaml:"protobuf_package"`
+	ProtobufGoPackage    string                       `json:"protobuf_go_package,omitempty" yaml:"protobuf_go_package"`
+	EnumCase             string                       `json:"enum_case,omitempty" yaml:"enum_case"`
+	OmitEnumPrefix       bool                         `json:"omit_enum_prefix" yaml:"omit_enum_prefix"`
+	EnumValues           map[string]map[string]string `json:"enum_values,omitempty" yaml:"enum_values"`
+	Constants            map[string]string            `json:"constants" yaml:"constants"`
+	SearchPath           []string                     `json:"search_path,omitempty" yaml:"search_path"`
+	ReadOnly             bool                         `json:"read_only,omitempty" yaml:"read_only"`
+	WarningsAsErrors     bool                         `json:"warnings_as_errors,omitempty" yaml:"warnings_as_errors"`
+	Overrides            []Override                   `json:"overrides" yaml:"overrides"`
+	TypeDefaults         map[string]TypeDefault       `json:"type_defaults,omitempty" yaml:"type_defaults"`
+	SensitiveColumns     []string                     `json:"sensitive_columns,omitempty" yaml:"sensitive_columns"`
+	SensitiveTag         string                       `json:"sensitive_tag,omitempty" yaml:"sensitive_tag"`
 }
 
 func v1ParseConfig(rd io.Reader) (Config, error) {
@@ -61,6 +85,11 @@ func v1ParseConfig(rd io.Reader) (Config, error) {
 				return config, err
 			}
 		}
+		defaults, err := typeDefaultOverrides(settings.Packages[j].TypeDefaults)
+		if err != nil {
+			return config, err
+		}
+		settings.Packages[j].Overrides = append(settings.Packages[j].Overrides, defaults...)
 		if settings.Packages[j].Name == "" {
 			settings.Packages[j].Name = filepath.Base(settings.Packages[j].Path)
 		}
@@ -90,22 +119,46 @@ func (c *V1GenerateSettings) ValidateGlobalOverrides() error {
 
 func (c *V1GenerateSettings) Translate() Config {
 	conf := Config{
-		Version: c.Version,
+		Version:     c.Version,
+		SQLCVersion: c.SQLCVersion,
 	}
 
 	for _, pkg := range c.Packages {
 		conf.SQL = append(conf.SQL, SQL{
-			Engine:  pkg.Engine,
-			Schema:  pkg.Schema,
-			Queries: pkg.Queries,
+			Engine:           pkg.Engine,
+			CheckEngines:     pkg.CheckEngines,
+			Schema:           pkg.Schema,
+			Queries:          pkg.Queries,
+			Constants:        pkg.Constants,
+			SearchPath:       pkg.SearchPath,
+			ReadOnly:         pkg.ReadOnly,
+			WarningsAsErrors: pkg.WarningsAsErrors,
 			Gen: SQLGen{
 				Go: &SQLGo{
-					EmitInterface:       pkg.EmitInterface,
-					EmitJSONTags:        pkg.EmitJSONTags,
-					EmitPreparedQueries: pkg.EmitPreparedQueries,
-					Package:             pkg.Name,
-					Out:                 pkg.Path,
-					Overrides:           pkg.Overrides,
+					EmitInterface:        pkg.EmitInterface,
+					EmitJSONTags:         pkg.EmitJSONTags,
+					EmitPreparedQueries:  pkg.EmitPreparedQueries,
+					EmitExportedQueries:  pkg.EmitExportedQueries,
+					EmitHooks:            pkg.EmitHooks,
+					EmitRetries:          pkg.EmitRetries,
+					EmitMock:             pkg.EmitMock,
+					EmitFixtures:         pkg.EmitFixtures,
+					EmitRowStructs:       pkg.EmitRowStructs,
+					EmitSharedRowStructs: pkg.EmitSharedRowStructs,
+					EmitEnumHelpers:      pkg.EmitEnumHelpers,
+					EmitModelHelpers:     pkg.EmitModelHelpers,
+					EmitProtobuf:         pkg.EmitProtobuf,
+					ProtobufPackage:      pkg.ProtobufPackage,
+					ProtobufGoPackage:    pkg.ProtobufGoPackage,
+					EnumCase:             pkg.EnumCase,
+					OmitEnumPrefix:       pkg.OmitEnumPrefix,
+					EnumValues:           pkg.EnumValues,
+					TypeDefaults:         pkg.TypeDefaults,
+					SensitiveColumns:     pkg.SensitiveColumns,
+					SensitiveTag:         pkg.SensitiveTag,
+					Package:              pkg.Name,
+					Out:                  pkg.Path,
+					Overrides:            pkg.Overrides,
 				},
 			},
 		})
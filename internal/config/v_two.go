@@ -50,6 +50,11 @@ func v2ParseConfig(rd io.Reader) (Config, error) {
 					return conf, err
 				}
 			}
+			defaults, err := typeDefaultOverrides(conf.SQL[j].Gen.Go.TypeDefaults)
+			if err != nil {
+				return conf, err
+			}
+			conf.SQL[j].Gen.Go.Overrides = append(conf.SQL[j].Gen.Go.Overrides, defaults...)
 		}
 		if conf.SQL[j].Gen.Kotlin != nil {
 			if conf.SQL[j].Gen.Kotlin.Out == "" {
@@ -0,0 +1,63 @@
+package dinosql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/internal/cache"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// A query file is only analyzed again if its expanded source, the catalog or
+// the parser options have changed. Files with errors are never cached.
+type cachedQuery struct {
+	Query    *Query
+	Location int
+}
+
+// The built-in pg_catalog schema only changes with sqlc itself and the engine,
+// which the cache key and optsCacheKey already cover.
+func catalogCacheKey(c core.Catalog) (string, error) {
+	schemas := map[string]core.Schema{}
+	for name, schema := range c.Schemas {
+		if name != "pg_catalog" {
+			schemas[name] = schema
+		}
+	}
+	blob, err := json.Marshal(schemas)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(blob)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Constants and fragments are already part of the expanded source, so only
+// the options that change the analysis are included.
+func optsCacheKey(opts ParserOpts) string {
+	return fmt.Sprintf("engine=%s,positional=%t,search_path=%q,read_only=%t", opts.Engine, opts.UsePositionalParameters, opts.SearchPath, opts.ReadOnly)
+}
+
+func cachedQueries(c *cache.Cache, key string) ([]fileQuery, bool) {
+	var entries []cachedQuery
+	if !c.Get(key, &entries) {
+		return nil, false
+	}
+	queries := make([]fileQuery, len(entries))
+	for i, e := range entries {
+		queries[i] = fileQuery{query: e.Query, location: e.Location}
+	}
+	return queries, true
+}
+
+func storeQueries(c *cache.Cache, key string, queries []fileQuery) {
+	entries := make([]cachedQuery, len(queries))
+	for i, q := range queries {
+		entries[i] = cachedQuery{Query: q.query, Location: q.location}
+	}
+	// The cache is an optimization. Failing to write an entry only means the
+	// file will be analyzed again next time.
+	c.Put(key, entries)
+}
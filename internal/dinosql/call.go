@@ -0,0 +1,247 @@
+package dinosql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/catalog"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+	"github.com/kyleconroy/sqlc/internal/sql/token"
+
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// The parser predates procedures, which were added in PostgreSQL 11. A
+// procedure is declared like a function without a result, so CREATE, ALTER
+// and DROP PROCEDURE are parsed as their FUNCTION counterparts, and the
+// functions they create are marked as procedures once they're in the catalog.
+// FUNCTION is padded to the length of PROCEDURE, so locations don't change.
+// Returns the locations of the rewritten keywords.
+func rewriteProcedures(contents string) (string, []int) {
+	var b []byte
+	var rewritten []int
+	tokens := token.Split(contents)
+	for i, t := range tokens {
+		if !t.Is("PROCEDURE") || i == 0 {
+			continue
+		}
+		switch prev := tokens[i-1]; {
+		case prev.Is("CREATE"), prev.Is("ALTER"), prev.Is("DROP"), prev.Is("ON"):
+		case prev.Is("REPLACE") && i > 2 && tokens.At(i-3, "CREATE", "OR"):
+		default:
+			continue
+		}
+		if b == nil {
+			b = []byte(contents)
+		}
+		copy(b[t.Start:t.End], "FUNCTION ")
+		rewritten = append(rewritten, t.Start)
+	}
+	if b == nil {
+		return contents, nil
+	}
+	return string(b), rewritten
+}
+
+// Mark the function created by a rewritten CREATE PROCEDURE statement
+func markProcedure(c *core.Catalog, stmt nodes.Node, rewritten []int) {
+	raw, ok := stmt.(nodes.RawStmt)
+	if !ok {
+		return
+	}
+	n, ok := raw.Stmt.(nodes.CreateFunctionStmt)
+	if !ok {
+		return
+	}
+	if _, ok := statementLocation(raw, rewritten); !ok {
+		return
+	}
+	fqn, err := catalog.ParseList(n.Funcname)
+	if err != nil {
+		return
+	}
+	var types []string
+	for _, item := range n.Parameters.Items {
+		if arg, ok := item.(nodes.FunctionParameter); ok {
+			types = append(types, join(arg.ArgType.Names, "."))
+		}
+	}
+	funcs := c.Schemas[fqn.Schema].Funcs[fqn.Rel]
+	for i, f := range funcs {
+		if len(f.Arguments) != len(types) {
+			continue
+		}
+		same := true
+		for j, arg := range f.Arguments {
+			same = same && arg.DataType == types[j]
+		}
+		if same {
+			funcs[i].Procedure = true
+		}
+	}
+}
+
+// Report whether the statement is a CALL parsed as a SELECT
+func isCall(raw nodes.RawStmt, calls []int) bool {
+	_, ok := statementLocation(raw, calls)
+	return ok
+}
+
+// The first of the locations that's in the statement
+func statementLocation(raw nodes.RawStmt, locations []int) (int, bool) {
+	for _, loc := range locations {
+		if loc < raw.StmtLocation {
+			continue
+		}
+		if raw.StmtLen == 0 || loc < raw.StmtLocation+raw.StmtLen {
+			return loc, true
+		}
+	}
+	return 0, false
+}
+
+// A CALL statement in a queries file is parsed as a SELECT of the procedure,
+// and turned back into a CALL once the query is analyzed. Returns the
+// locations of the SELECT keywords that stand in for CALL.
+func expandCalls(source string, parent sourceMap) (string, sourceMap, []int) {
+	m := sourceMap{Filename: parent.Filename, Source: parent.Source, Parent: &parent}
+	var calls []int
+	var b strings.Builder
+	prev := 0
+	tokens := token.Split(source)
+	for i, t := range tokens {
+		if !t.Is("CALL") || (i > 0 && tokens[i-1].Text != ";") {
+			continue
+		}
+		b.WriteString(source[prev:t.Start])
+		m.Splices = append(m.Splices, splice{
+			Start:   b.Len(),
+			End:     b.Len() + len("SELECT"),
+			OrigLen: t.End - t.Start,
+		})
+		calls = append(calls, b.Len())
+		b.WriteString("SELECT")
+		prev = t.End
+	}
+	if calls == nil {
+		return source, parent, nil
+	}
+	b.WriteString(source[prev:])
+	return b.String(), m, calls
+}
+
+// The procedure a CALL statement, parsed as a SELECT, runs
+func lookupProcedure(c core.Catalog, stmt nodes.Node) (core.Function, error) {
+	call, ok := callTarget(stmt)
+	if !ok {
+		return core.Function{}, errors.New("CALL must be followed by a single procedure call, such as CALL transfer($1, $2)")
+	}
+	fqn, err := catalog.ParseList(call.Funcname)
+	if err != nil {
+		return core.Function{}, err
+	}
+	funs, _ := c.LookupFunctions(fqn)
+	for _, fun := range funs {
+		if !fun.Accepts(len(call.Args.Items)) {
+			continue
+		}
+		if !fun.Procedure {
+			return core.Function{}, core.Error{
+				Code:     "42809",
+				Message:  fmt.Sprintf("%s is not a procedure", fqn.Rel),
+				Hint:     "To call a function, use SELECT.",
+				Location: call.Location,
+			}
+		}
+		return fun, nil
+	}
+	var sig []string
+	for range call.Args.Items {
+		sig = append(sig, "unknown")
+	}
+	return core.Function{}, core.Error{
+		Code:     "42883",
+		Message:  fmt.Sprintf("procedure %s(%s) does not exist", fqn.Rel, strings.Join(sig, ", ")),
+		Hint:     "No procedure matches the given name and argument types. You might need to add explicit type casts.",
+		Location: call.Location,
+	}
+}
+
+func callTarget(stmt nodes.Node) (nodes.FuncCall, bool) {
+	sel, ok := stmt.(nodes.SelectStmt)
+	if !ok || len(sel.TargetList.Items) != 1 || len(sel.FromClause.Items) > 0 || sel.WhereClause != nil ||
+		len(sel.GroupClause.Items) > 0 || sel.HavingClause != nil || len(sel.SortClause.Items) > 0 ||
+		sel.LimitCount != nil || sel.LimitOffset != nil || sel.WithClause != nil || len(sel.DistinctClause.Items) > 0 {
+		return nodes.FuncCall{}, false
+	}
+	res, ok := sel.TargetList.Items[0].(nodes.ResTarget)
+	if !ok || res.Name != nil {
+		return nodes.FuncCall{}, false
+	}
+	call, ok := res.Val.(nodes.FuncCall)
+	return call, ok
+}
+
+// A CALL statement runs a procedure with an argument for each of its
+// parameters. The INOUT parameters are returned as a single row, which
+// can be read with :one.
+func parseCall(c core.Catalog, stmt nodes.Node, source string, opts ParserOpts) (*Query, error) {
+	raw, ok := stmt.(nodes.RawStmt)
+	if !ok {
+		return nil, errors.New("node is not a statement")
+	}
+	proc, err := lookupProcedure(c, raw.Stmt)
+	if err != nil {
+		return nil, err
+	}
+	rawSQL, err := pluckQuery(source, raw)
+	if err != nil {
+		return nil, err
+	}
+	if rawSQL == "" {
+		return nil, errors.New("missing semicolon at end of file")
+	}
+	head := strings.TrimSpace(rawSQL)
+	name, cmd, err := ParseMetadata(head, CommentSyntaxDash)
+	if err != nil {
+		return nil, err
+	}
+	out := proc.OutArgs()
+	switch {
+	case cmd == ":many" || cmd == ":paginated":
+		return nil, fmt.Errorf("query %q specifies parameter %q, but CALL returns at most one row", name, cmd)
+	case cmd == ":one" && len(out) == 0:
+		return nil, fmt.Errorf("query %q specifies parameter %q, but procedure %s has no INOUT parameters to return", name, cmd, proc.Name)
+	}
+	if spec, _ := parseSort(head); spec != nil {
+		return nil, fmt.Errorf("query %q can't have a sort annotation, as CALL returns at most one row", name)
+	}
+	readOnly, ok, err := parseReadOnly(head)
+	if err != nil {
+		return nil, err
+	}
+	if (ok && readOnly) || (!ok && opts.ReadOnly) {
+		return nil, fmt.Errorf("read-only query %q performs CALL", name)
+	}
+
+	q, err := parseQuery(c, stmt, source, opts)
+	if err != nil {
+		return nil, err
+	}
+	q.Columns = nil
+	for _, arg := range out {
+		q.Columns = append(q.Columns, core.Column{Name: arg.Name, DataType: arg.DataType})
+	}
+	q.SQL = restoreCall(q.SQL)
+	return q, nil
+}
+
+// Replace the SELECT that stands in for CALL at the start of a statement
+func restoreCall(sql string) string {
+	tokens := token.Split(sql)
+	if len(tokens) == 0 || !tokens[0].Is("SELECT") {
+		return sql
+	}
+	return sql[:tokens[0].Start] + "CALL" + sql[tokens[0].End:]
+}
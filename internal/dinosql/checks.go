@@ -67,11 +67,7 @@ func (v *funcCallVisitor) Visit(node nodes.Node) ast.Visitor {
 
 	args := len(funcCall.Args.Items)
 	for _, fun := range funs {
-		arity := fun.ArgN
-		if fun.Arguments != nil {
-			arity = len(fun.Arguments)
-		}
-		if arity == args {
+		if fun.Accepts(args) {
 			return v
 		}
 	}
@@ -123,6 +119,177 @@ func validateInsertStmt(stmt nodes.InsertStmt) error {
 	return nil
 }
 
+// The first part of a statement that writes to the database, such as
+// "INSERT" or "SELECT FOR UPDATE", or "" if it only reads. Writes in
+// data-modifying WITH queries count, but functions with side effects, such as
+// nextval, aren't detected.
+func statementWrite(n nodes.Node) string {
+	var write string
+	ast.Walk(ast.VisitorFunc(func(node nodes.Node) {
+		if write != "" {
+			return
+		}
+		switch n := node.(type) {
+		case nodes.InsertStmt:
+			write = "INSERT"
+		case nodes.UpdateStmt:
+			write = "UPDATE"
+		case nodes.DeleteStmt:
+			write = "DELETE"
+		case nodes.NotifyStmt:
+			write = "NOTIFY"
+		case nodes.CreateStmt:
+			write = "CREATE TABLE"
+		case nodes.SelectStmt:
+			if n.IntoClause != nil {
+				write = "SELECT INTO"
+			}
+		case nodes.LockingClause:
+			switch n.Strength {
+			case nodes.LCS_FORKEYSHARE, nodes.LCS_FORSHARE:
+				write = "SELECT FOR SHARE"
+			default:
+				write = "SELECT FOR UPDATE"
+			}
+		}
+	}), n)
+	return write
+}
+
+func validateReadOnly(n nodes.Node, name string) error {
+	if write := statementWrite(n); write != "" {
+		return fmt.Errorf("read-only query %q performs %s", name, write)
+	}
+	return nil
+}
+
+// Problems with a query that don't stop generation: parameters in ORDER BY or
+// GROUP BY, which sort or group by a constant instead of a column, param
+// annotations of parameters the query doesn't use, columns returned to an
+// :exec query, which are discarded, and columns selected twice or aliased to
+// the name of another column, whose fields get numbered suffixes.
+func queryWarnings(stmt nodes.Node, name, cmd string, cols []pg.Column, names map[int]string, unused []string) []Warning {
+	warnings := unusedParamWarnings(name, unused)
+	paramName := func(ref nodes.ParamRef) string {
+		if name, ok := names[ref.Number]; ok {
+			return fmt.Sprintf("%q", name)
+		}
+		return fmt.Sprintf("$%d", ref.Number)
+	}
+	constant := func(clause string, n nodes.Node) {
+		if cast, ok := n.(nodes.TypeCast); ok {
+			n = cast.Arg
+		}
+		if ref, ok := n.(nodes.ParamRef); ok {
+			warnings = append(warnings, Warning{
+				Message:  fmt.Sprintf("parameter %s in %s is a constant, not a column name", paramName(ref), clause),
+				Location: ref.Location,
+			})
+		}
+	}
+	ast.Walk(ast.VisitorFunc(func(node nodes.Node) {
+		sel, ok := node.(nodes.SelectStmt)
+		if !ok {
+			return
+		}
+		for _, item := range sel.SortClause.Items {
+			if sortBy, ok := item.(nodes.SortBy); ok {
+				constant("ORDER BY", sortBy.Node)
+			}
+		}
+		for _, item := range sel.GroupClause.Items {
+			constant("GROUP BY", item)
+		}
+	}), stmt)
+
+	if cmd == ":exec" || cmd == ":execrows" {
+		var returning nodes.List
+		switch n := stmt.(type) {
+		case nodes.DeleteStmt:
+			returning = n.ReturningList
+		case nodes.InsertStmt:
+			returning = n.ReturningList
+		case nodes.UpdateStmt:
+			returning = n.ReturningList
+		}
+		if len(returning.Items) > 0 {
+			w := Warning{Message: fmt.Sprintf("query %q discards the columns of its RETURNING clause; use :one or :many to read them", name)}
+			if res, ok := returning.Items[0].(nodes.ResTarget); ok {
+				w.Location = res.Location
+			}
+			warnings = append(warnings, w)
+		}
+		return warnings
+	}
+
+	// Output columns with the same name are only reported if they're the
+	// same column selected twice, or if an alias renames a column to the name
+	// of another. Joined tables often share column names, such as id.
+	var targets nodes.List
+	switch n := stmt.(type) {
+	case nodes.SelectStmt:
+		targets = n.TargetList
+	case nodes.DeleteStmt:
+		targets = n.ReturningList
+	case nodes.InsertStmt:
+		targets = n.ReturningList
+	case nodes.UpdateStmt:
+		targets = n.ReturningList
+	}
+	aliases := map[string]int{}
+	for _, item := range targets.Items {
+		if res, ok := item.(nodes.ResTarget); ok && res.Name != nil {
+			aliases[*res.Name] = res.Location
+		}
+	}
+	var order []string
+	byName := map[string][]pg.Column{}
+	for i, c := range cols {
+		col := columnName(c, i)
+		if byName[col] == nil {
+			order = append(order, col)
+		}
+		byName[col] = append(byName[col], c)
+	}
+	for _, col := range order {
+		same := byName[col]
+		if len(same) < 2 {
+			continue
+		}
+		if loc, ok := aliases[col]; ok {
+			warnings = append(warnings, Warning{
+				Message:  fmt.Sprintf("query %q aliases a column to %q, the name of another column", name, col),
+				Location: loc,
+			})
+			continue
+		}
+		tables := map[pg.FQN]bool{}
+		for _, c := range same {
+			if c.Table.Rel != "" && tables[c.Table] {
+				warnings = append(warnings, Warning{
+					Message: fmt.Sprintf("query %q selects column %q more than once", name, col),
+				})
+				break
+			}
+			tables[c.Table] = true
+		}
+	}
+	return warnings
+}
+
+// A param annotation names a parameter that the query doesn't use, such as
+// one that's misspelled, or only used by a statement after the query, which
+// isn't part of it unless the query is an :execscript.
+func unusedParamWarnings(name string, unused []string) []Warning {
+	var warnings []Warning
+	for _, param := range unused {
+		warnings = append(warnings, Warning{
+			Message: fmt.Sprintf("query %q annotates parameter %q, which it doesn't use", name, param),
+		})
+	}
+	return warnings
+}
+
 // A query can use one (and only one) of the following formats:
 // - positional parameters           $1
 // - named parameter operator        @param
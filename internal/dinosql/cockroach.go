@@ -0,0 +1,155 @@
+package dinosql
+
+import (
+	"strings"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+	"github.com/kyleconroy/sqlc/internal/sql/token"
+)
+
+// CockroachDB speaks the PostgreSQL wire protocol and mostly its SQL, so
+// CockroachDB schemas are parsed with the PostgreSQL parser once the clauses
+// that only CockroachDB understands have been removed. None of them change
+// the columns of a table.
+
+// Types that take a length or precision, which tell a column such as
+// "family varchar(10)" apart from a column family
+var parenthesizedTypes = map[string]struct{}{
+	"bit": {}, "char": {}, "character": {}, "decimal": {}, "float": {},
+	"interval": {}, "numeric": {}, "string": {}, "time": {}, "timestamp": {},
+	"timestamptz": {}, "varbit": {}, "varchar": {},
+}
+
+// Blank out the CockroachDB-only clauses of a schema:
+//
+//   - STORING (...) and COVERING (...) on indexes
+//   - INTERLEAVE IN PARENT ... (...) on tables and indexes
+//   - USING HASH WITH BUCKET_COUNT = n on primary keys and indexes
+//   - INDEX, UNIQUE INDEX, INVERTED INDEX and FAMILY definitions inside
+//     CREATE TABLE
+//
+// The clauses are replaced with spaces, so the positions in error messages
+// still point at the original schema.
+func RemoveCockroachClauses(contents string) string {
+	b := []byte(contents)
+	tokens := token.Split(contents)
+	createTable := false
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.Text == ";":
+			createTable = false
+			depth = 0
+
+		case t.Text == "(":
+			depth++
+
+		case t.Text == ")":
+			depth--
+
+		case tokens.At(i, "CREATE", "TABLE"):
+			createTable = true
+
+		case tokens.At(i, "STORING", "(") || tokens.At(i, "COVERING", "("):
+			end := tokens.Closing(i + 1)
+			token.Blank(b, t.Start, tokens[end].End)
+			i = end
+
+		case tokens.At(i, "INTERLEAVE", "IN", "PARENT"):
+			j := i + 3
+			for j < len(tokens) && tokens[j].Text != "(" {
+				j++
+			}
+			end := tokens.Closing(j)
+			token.Blank(b, t.Start, tokens[end].End)
+			i = end
+
+		case tokens.At(i, "USING", "HASH", "WITH", "BUCKET_COUNT", "="):
+			end := i + 5
+			if end >= len(tokens) {
+				end = len(tokens) - 1
+			}
+			token.Blank(b, t.Start, tokens[end].End)
+			i = end
+
+		case createTable && depth == 1 && i > 0 && (tokens[i-1].Text == "," || tokens[i-1].Text == "("):
+			if !tableIndexDefinition(tokens[i:]) {
+				continue
+			}
+			// The definition runs until the next comma or the end of the
+			// column list
+			end := i
+			for end+1 < len(tokens) {
+				next := tokens[end+1].Text
+				if next == "," || next == ")" {
+					break
+				}
+				if next == "(" {
+					end = tokens.Closing(end + 1)
+				} else {
+					end++
+				}
+			}
+			start := t.Start
+			if tokens[i-1].Text == "," {
+				start = tokens[i-1].Start
+			} else if end+1 < len(tokens) && tokens[end+1].Text == "," {
+				end++
+			}
+			token.Blank(b, start, tokens[end].End)
+			i = end
+		}
+	}
+	return string(b)
+}
+
+// Report whether a CREATE TABLE element defines an index or a column family
+func tableIndexDefinition(tokens token.List) bool {
+	if len(tokens) > 1 && (tokens[0].Is("UNIQUE") || tokens[0].Is("INVERTED")) && tokens[1].Is("INDEX") {
+		return true
+	}
+	if !tokens[0].Is("INDEX") && !tokens[0].Is("FAMILY") {
+		return false
+	}
+	if len(tokens) > 1 && tokens[1].Text == "(" {
+		return true
+	}
+	if len(tokens) > 2 && tokens[2].Text == "(" {
+		_, isType := parenthesizedTypes[strings.ToLower(tokens[1].Text)]
+		return !isType
+	}
+	return false
+}
+
+// The functions that CockroachDB provides on top of PostgreSQL's
+func addCockroachFunctions(c *core.Catalog) {
+	schema := c.Schemas["pg_catalog"]
+	for _, f := range []core.Function{
+		{Name: "unique_rowid", ReturnType: "pg_catalog.int8"},
+		{Name: "gen_random_uuid", ReturnType: "uuid"},
+		{Name: "uuid_v4", ReturnType: "pg_catalog.bytea"},
+		{Name: "experimental_strftime", ArgN: 2, ReturnType: "text"},
+		{Name: "cluster_logical_timestamp", ReturnType: "pg_catalog.numeric"},
+	} {
+		schema.Funcs[f.Name] = append(schema.Funcs[f.Name], f)
+	}
+}
+
+// CockroachDB integers are 8 bytes unless the width is given: INT and
+// INTEGER are INT8, and SERIAL columns default to unique_rowid(), an INT8.
+// Its BYTES, INT64 and JSON types are aliases for BYTEA, INT8 and JSONB.
+func cockroachType(dataType string) string {
+	switch dataType {
+	case "pg_catalog.int4", "int64", "int8":
+		return "pg_catalog.int8"
+	case "serial", "smallserial", "bigserial", "serial2", "serial4", "serial8",
+		"pg_catalog.serial2", "pg_catalog.serial4":
+		return "pg_catalog.serial8"
+	case "bytes":
+		return "pg_catalog.bytea"
+	case "json":
+		return "jsonb"
+	}
+	return dataType
+}
@@ -0,0 +1,64 @@
+package dinosql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoveCockroachClauses(t *testing.T) {
+	for _, tc := range []struct {
+		input  string
+		output string
+	}{
+		{
+			"CREATE INDEX foo_idx ON foo (a) STORING (b, c);",
+			"CREATE INDEX foo_idx ON foo (a) ;",
+		},
+		{
+			"CREATE TABLE foo (a INT, b INT, PRIMARY KEY (a, b)) INTERLEAVE IN PARENT public.bar (a);",
+			"CREATE TABLE foo (a INT, b INT, PRIMARY KEY (a, b)) ;",
+		},
+		{
+			"CREATE TABLE foo (a INT PRIMARY KEY USING HASH WITH BUCKET_COUNT = 8);",
+			"CREATE TABLE foo (a INT PRIMARY KEY );",
+		},
+		{
+			"CREATE TABLE foo (a STRING, INDEX (a), UNIQUE INDEX foo_a_key (a ASC) STORING (b), FAMILY f1 (a));",
+			"CREATE TABLE foo (a STRING );",
+		},
+		{
+			"CREATE TABLE foo (INVERTED INDEX foo_j_idx (j), j JSONB);",
+			"CREATE TABLE foo ( j JSONB);",
+		},
+		{
+			"CREATE TABLE foo (family VARCHAR(10), index INT, storing TEXT);",
+			"CREATE TABLE foo (family VARCHAR(10), index INT, storing TEXT);",
+		},
+		{
+			"CREATE TABLE foo (a TEXT DEFAULT 'STORING (a)'); -- INDEX (a)",
+			"CREATE TABLE foo (a TEXT DEFAULT 'STORING (a)'); -- INDEX (a)",
+		},
+	} {
+		actual := RemoveCockroachClauses(tc.input)
+		if len(actual) != len(tc.input) {
+			t.Errorf("%s: length changed from %d to %d", tc.input, len(tc.input), len(actual))
+		}
+		if got, want := strings.Join(strings.Fields(actual), " "), strings.Join(strings.Fields(tc.output), " "); got != want {
+			t.Errorf("%s:\nwant: %s\n got: %s", tc.input, want, got)
+		}
+	}
+}
+
+func TestCockroachType(t *testing.T) {
+	for dataType, want := range map[string]string{
+		"pg_catalog.int4": "pg_catalog.int8",
+		"int4":            "int4",
+		"serial":          "pg_catalog.serial8",
+		"bytes":           "pg_catalog.bytea",
+		"text":            "text",
+	} {
+		if got := cockroachType(dataType); got != want {
+			t.Errorf("cockroachType(%q) = %q, want %q", dataType, got, want)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package dinosql
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+	"github.com/kyleconroy/sqlc/internal/postgresql/ast"
+
+	pg "github.com/lfittl/pg_query_go"
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// What the analysis of a query saw, recorded with ParserOpts.Debug
+type QueryTrace struct {
+	// The parse tree of the query as it's sent to the database, after
+	// parameters are renumbered and stars are expanded, in the JSON format of
+	// libpg_query
+	AST json.RawMessage
+
+	// The tables and common table expressions that the query reads or writes,
+	// with the schemas that unqualified names resolved to
+	Relations []TraceRelation
+}
+
+type TraceRelation struct {
+	Schema string `json:"schema,omitempty"`
+	Name   string `json:"name"`
+	Alias  string `json:"alias,omitempty"`
+	CTE    bool   `json:"cte,omitempty"`
+}
+
+func traceQuery(c core.Catalog, q *Query) *QueryTrace {
+	sql := q.SQL
+	if len(q.Script) > 0 {
+		var stmts []string
+		for _, s := range q.Script {
+			stmts = append(stmts, s.SQL)
+		}
+		sql = strings.Join(stmts, ";\n")
+	}
+	trace := &QueryTrace{Relations: []TraceRelation{}}
+	tree, err := pg.Parse(sql)
+	if err != nil {
+		return trace
+	}
+	if blob, err := pg.ParseToJSON(sql); err == nil {
+		trace.AST = json.RawMessage(blob)
+	}
+
+	ctes := map[string]bool{}
+	for _, stmt := range tree.Statements {
+		ast.Walk(ast.VisitorFunc(func(node nodes.Node) {
+			if cte, ok := node.(nodes.CommonTableExpr); ok && cte.Ctename != nil {
+				ctes[*cte.Ctename] = true
+			}
+		}), stmt)
+	}
+	seen := map[TraceRelation]bool{}
+	for _, stmt := range tree.Statements {
+		for _, rv := range rangeVars(stmt) {
+			if rv.Relname == nil {
+				continue
+			}
+			rel := TraceRelation{Name: *rv.Relname}
+			if rv.Alias != nil {
+				rel.Alias = *rv.Alias.Aliasname
+			}
+			if rv.Schemaname == nil && ctes[rel.Name] {
+				rel.CTE = true
+			} else if fqn, err := resolveRange(c, &rv); err == nil {
+				rel.Schema = fqn.Schema
+			}
+			if !seen[rel] {
+				seen[rel] = true
+				trace.Relations = append(trace.Relations, rel)
+			}
+		}
+	}
+	return trace
+}
+
+// The analysis of a query, from its parse tree to the Go types of its
+// parameters and columns, as sqlc compile --debug prints it
+type QueryDebug struct {
+	File      string          `json:"file"`
+	Name      string          `json:"name"`
+	Cmd       string          `json:"cmd"`
+	SQL       string          `json:"sql"`
+	AST       json.RawMessage `json:"ast,omitempty"`
+	Relations []TraceRelation `json:"relations"`
+	Params    []DebugColumn   `json:"params"`
+	Columns   []DebugColumn   `json:"columns"`
+	Go        *DebugGo        `json:"go,omitempty"`
+}
+
+// An inferred parameter or output column
+type DebugColumn struct {
+	Number   int    `json:"number,omitempty"`
+	Name     string `json:"name"`
+	Table    string `json:"table,omitempty"`
+	DataType string `json:"data_type"`
+	NotNull  bool   `json:"not_null"`
+	IsArray  bool   `json:"is_array"`
+}
+
+// The generated method of a query
+type DebugGo struct {
+	Method string      `json:"method"`
+	Arg    *DebugValue `json:"arg,omitempty"`
+	Ret    *DebugValue `json:"ret,omitempty"`
+}
+
+// The Go parameter or result of a method. A struct has fields instead of a
+// type.
+type DebugValue struct {
+	Name   string       `json:"name,omitempty"`
+	Type   string       `json:"type,omitempty"`
+	Struct string       `json:"struct,omitempty"`
+	Fields []DebugField `json:"fields,omitempty"`
+}
+
+type DebugField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func debugColumn(col core.Column) DebugColumn {
+	d := DebugColumn{
+		Name:     col.Name,
+		DataType: col.DataType,
+		NotNull:  col.NotNull,
+		IsArray:  col.IsArray,
+	}
+	if col.Table.Rel != "" {
+		d.Table = col.Table.Rel
+		if col.Table.Schema != "" {
+			d.Table = col.Table.Schema + "." + col.Table.Rel
+		}
+	}
+	return d
+}
+
+func debugValue(v GoQueryValue) *DebugValue {
+	if v.isEmpty() {
+		return nil
+	}
+	d := &DebugValue{Name: v.Name, Type: v.Typ}
+	if v.Struct != nil {
+		d.Type = ""
+		d.Struct = v.Struct.Name
+		for _, f := range v.Struct.Fields {
+			d.Fields = append(d.Fields, DebugField{Name: f.Name, Type: f.Type})
+		}
+	}
+	return d
+}
+
+// Debug describes the analysis of each query. The parse tree and relations
+// are only known for queries parsed with ParserOpts.Debug.
+func (r Result) Debug(settings config.CombinedSettings) []QueryDebug {
+	methods := map[string]GoQuery{}
+	for _, gq := range r.GoQueries(settings) {
+		methods[gq.MethodName] = gq
+	}
+	var out []QueryDebug
+	for _, q := range r.Queries {
+		if q.Name == "" {
+			continue
+		}
+		d := QueryDebug{
+			File:      q.Filename,
+			Name:      q.Name,
+			Cmd:       q.Cmd,
+			SQL:       q.SQL,
+			Relations: []TraceRelation{},
+			Params:    []DebugColumn{},
+			Columns:   []DebugColumn{},
+		}
+		if q.Trace != nil {
+			d.AST = q.Trace.AST
+			d.Relations = q.Trace.Relations
+		}
+		for _, p := range q.Params {
+			col := debugColumn(p.Column)
+			col.Number = p.Number
+			d.Params = append(d.Params, col)
+		}
+		for _, c := range q.Columns {
+			d.Columns = append(d.Columns, debugColumn(c))
+		}
+		if gq, ok := methods[q.MethodName()]; ok {
+			d.Go = &DebugGo{
+				Method: gq.MethodName,
+				Arg:    debugValue(gq.Arg),
+				Ret:    debugValue(gq.Ret),
+			}
+		}
+		out = append(out, d)
+	}
+	return out
+}
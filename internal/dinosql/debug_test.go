@@ -0,0 +1,29 @@
+package dinosql
+
+import (
+	"testing"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTraceQuery(t *testing.T) {
+	c := sessionCatalog(core.NewCatalog(), []string{"app", "public"})
+	c.Schemas["app"] = core.Schema{Tables: map[string]core.Table{"users": {Name: "users"}}}
+	q := &Query{SQL: "WITH recent AS (SELECT id FROM users) SELECT u.id FROM users u JOIN recent ON recent.id = u.id JOIN audit.log l ON l.id = u.id"}
+	trace := traceQuery(c, q)
+	want := []TraceRelation{
+		{Schema: "app", Name: "users", Alias: "u"},
+		{Name: "recent", CTE: true},
+		{Schema: "audit", Name: "log", Alias: "l"},
+		{Schema: "app", Name: "users"},
+	}
+	if diff := cmp.Diff(want, trace.Relations); diff != "" {
+		t.Errorf("relations differed (-want +got):\n%s", diff)
+	}
+	if len(trace.AST) == 0 {
+		t.Errorf("expected the parse tree")
+	}
+
+}
@@ -0,0 +1,111 @@
+package dinosql
+
+import (
+	"strings"
+
+	pg "github.com/lfittl/pg_query_go"
+)
+
+// Blank out the parts of a pg_dump or psql script that aren't SQL: psql
+// meta-commands such as \connect, and the rows of COPY ... FROM stdin
+// statements, which run until a \. line. Blank lines are left in their place
+// so that the line numbers in error messages don't change.
+func RemovePsqlMetaCommands(contents string) string {
+	lines := strings.Split(contents, "\n")
+	var copying bool
+	for i, line := range lines {
+		switch {
+		case copying:
+			if strings.TrimRight(line, "\r") == `\.` {
+				copying = false
+			}
+			lines[i] = ""
+		case strings.HasPrefix(line, `\`):
+			lines[i] = ""
+		case strings.HasPrefix(line, "COPY ") && strings.HasSuffix(strings.TrimRight(line, "\r"), "FROM stdin;"):
+			copying = true
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Statements that pg_dump writes for newer servers, but that the bundled
+// PostgreSQL 9.5 parser doesn't understand. None of them change the tables,
+// columns or types that sqlc tracks, so they can be skipped.
+var skippableDumpStatements = []string{
+	"ALTER SEQUENCE ",
+	"CREATE CONSTRAINT TRIGGER ",
+	"CREATE EVENT TRIGGER ",
+	"CREATE INDEX ",
+	"CREATE PUBLICATION ",
+	"CREATE SEQUENCE ",
+	"CREATE STATISTICS ",
+	"CREATE SUBSCRIPTION ",
+	"CREATE TRIGGER ",
+	"CREATE UNIQUE INDEX ",
+}
+
+// Skipped ALTER TABLE commands, which are only written as separate statements
+var skippableDumpCommands = []string{
+	" ADD GENERATED ",
+	" ATTACH PARTITION ",
+}
+
+func skippableDumpStatement(sql string) bool {
+	stmt := strings.ToUpper(strings.Join(strings.Fields(stripLeadingComments(sql)), " ")) + " "
+	for _, prefix := range skippableDumpStatements {
+		if strings.HasPrefix(stmt, prefix) {
+			return true
+		}
+	}
+	if strings.HasPrefix(stmt, "ALTER TABLE ") {
+		for _, cmd := range skippableDumpCommands {
+			if strings.Contains(stmt, cmd) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func stripLeadingComments(sql string) string {
+	for {
+		sql = strings.TrimSpace(sql)
+		if !strings.HasPrefix(sql, "--") {
+			return sql
+		}
+		end := strings.Index(sql, "\n")
+		if end < 0 {
+			return ""
+		}
+		sql = sql[end:]
+	}
+}
+
+// Parse a schema one statement at a time, skipping the statements that the
+// parser doesn't support and that don't change the catalog. Used when a
+// schema, usually the output of pg_dump, fails to parse as a whole.
+func parseDump(contents string) (pg.ParsetreeList, error) {
+	var tree pg.ParsetreeList
+	start := 0
+	ends := statementEnds(contents)
+	if strings.TrimSpace(stripLeadingComments(contents[lastEnd(ends):])) != "" {
+		ends = append(ends, len(contents))
+	}
+	for _, end := range ends {
+		stmts, err := parseBatch(contents, batch{start, end})
+		if err != nil && !skippableDumpStatement(contents[start:end]) {
+			return tree, err
+		}
+		tree.Statements = append(tree.Statements, stmts.Statements...)
+		start = end
+	}
+	return tree, nil
+}
+
+func lastEnd(ends []int) int {
+	if len(ends) == 0 {
+		return 0
+	}
+	return ends[len(ends)-1]
+}
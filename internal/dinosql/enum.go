@@ -0,0 +1,70 @@
+package dinosql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// Styles of enum constant names, for the enum_case setting
+const (
+	EnumCasePascal         = "pascal"          // UserStatusInProgress
+	EnumCaseScreamingSnake = "screaming_snake" // USER_STATUS_IN_PROGRESS
+)
+
+func validateEnumSettings(settings config.CombinedSettings) error {
+	switch settings.Go.EnumCase {
+	case "", EnumCasePascal, EnumCaseScreamingSnake:
+		return nil
+	}
+	return fmt.Errorf("invalid enum_case: %q", settings.Go.EnumCase)
+}
+
+// Split an enum value into words at dashes, colons, slashes, underscores and
+// spaces, so that "in-progress" and "in progress" are both the words "in" and
+// "progress". Other characters that can't be part of a Go identifier are
+// dropped.
+func enumValueWords(value string) []string {
+	var words []string
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("-:/_", r)
+	}) {
+		word := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, field)
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// The name of the constant for an enum value. The enum is identified by its
+// name in SQL, qualified with its schema outside of public, for the names
+// configured in enum_values.
+func enumConstantName(goName, sqlName, value string, settings config.CombinedSettings) string {
+	if name := settings.Go.EnumValues[sqlName][value]; name != "" {
+		return name
+	}
+	words := enumValueWords(value)
+	if settings.Go.EnumCase == EnumCaseScreamingSnake {
+		if !settings.Go.OmitEnumPrefix || len(words) == 0 || unicode.IsDigit(rune(words[0][0])) {
+			words = append(enumValueWords(strings.Replace(sqlName, ".", "_", -1)), words...)
+		}
+		return strings.ToUpper(strings.Join(words, "_"))
+	}
+	var name string
+	for _, w := range words {
+		name += strings.Title(w)
+	}
+	// Without the prefix, a value such as "2fa" wouldn't be an identifier
+	if !settings.Go.OmitEnumPrefix || name == "" || unicode.IsDigit(rune(name[0])) {
+		name = goName + name
+	}
+	return name
+}
@@ -0,0 +1,39 @@
+package dinosql
+
+import (
+	"testing"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+func TestEnumConstantName(t *testing.T) {
+	for _, tc := range []struct {
+		value  string
+		golang config.SQLGo
+		name   string
+	}{
+		{"in-progress", config.SQLGo{}, "JobStatusInProgress"},
+		{"on hold", config.SQLGo{}, "JobStatusOnHold"},
+		{"foo@e", config.SQLGo{}, "JobStatusFooe"},
+		{"on hold", config.SQLGo{OmitEnumPrefix: true}, "OnHold"},
+		{"2fa", config.SQLGo{OmitEnumPrefix: true}, "JobStatus2fa"},
+		{"!", config.SQLGo{OmitEnumPrefix: true}, "JobStatus"},
+		{"on hold", config.SQLGo{EnumCase: EnumCaseScreamingSnake}, "JOB_STATUS_ON_HOLD"},
+		{"on hold", config.SQLGo{EnumCase: EnumCaseScreamingSnake, OmitEnumPrefix: true}, "ON_HOLD"},
+		{"n/a", config.SQLGo{EnumValues: map[string]map[string]string{
+			"job_status": {"n/a": "NotApplicable"},
+		}}, "NotApplicable"},
+	} {
+		settings := config.CombinedSettings{Go: tc.golang}
+		if name := enumConstantName("JobStatus", "job_status", tc.value, settings); name != tc.name {
+			t.Errorf("%q: expected %s, got %s", tc.value, tc.name, name)
+		}
+	}
+}
+
+func TestValidateEnumSettings(t *testing.T) {
+	settings := config.CombinedSettings{Go: config.SQLGo{EnumCase: "camel"}}
+	if err := validateEnumSettings(settings); err == nil {
+		t.Errorf("expected an error for enum_case camel")
+	}
+}
@@ -0,0 +1,180 @@
+package dinosql
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// A builder for inserting rows of a table in tests. Fixtures live in their
+// own package, next to the generated code, so that they're only linked into
+// the test binaries that import them.
+type GoFixture struct {
+	Name    string
+	Struct  GoStruct
+	Table   string // The quoted, schema-qualified table name
+	Columns []GoFixtureColumn
+}
+
+type GoFixtureColumn struct {
+	Field  GoField
+	Column string // The unquoted column name
+	Type   string // The field type, qualified with the models package
+
+	// NOT NULL columns without a default are always inserted, using the zero
+	// value of the field unless a value was set
+	Required bool
+}
+
+func (c GoFixtureColumn) IsArray() bool {
+	return strings.HasPrefix(c.Field.Type, "[]") && c.Field.Type != "[]byte"
+}
+
+func (f GoFixture) Returning() string {
+	cols := make([]string, len(f.Columns))
+	for i, c := range f.Columns {
+		cols[i] = quoteIdent(c.Column)
+	}
+	return strings.Join(cols, ", ")
+}
+
+func (f GoFixture) Scan() string {
+	var out []string
+	for _, c := range f.Columns {
+		out = append(out, scanTarget("i."+c.Field.Name, c.Field.Type, false, c.Field.Interval))
+	}
+	return strings.Join(out, ", ")
+}
+
+// A result whose models have fixtures, which need the tables behind them
+type FixtureGenerateable interface {
+	Generateable
+	Fixtures(settings config.CombinedSettings) []GoFixture
+}
+
+func (r Result) Fixtures(settings config.CombinedSettings) []GoFixture {
+	pkg := settings.Go.Package
+	var fixtures []GoFixture
+	for _, s := range r.Structs(settings) {
+		table, ok := r.Catalog.Schemas[s.Table.Schema].Tables[s.Table.Rel]
+		if !ok || len(table.Columns) != len(s.Fields) {
+			continue
+		}
+		f := GoFixture{
+			Name:   s.Name + "Fixture",
+			Struct: s,
+			Table:  quoteIdent(s.Table.Schema) + "." + quoteIdent(s.Table.Rel),
+		}
+		for i, col := range table.Columns {
+			f.Columns = append(f.Columns, GoFixtureColumn{
+				Field:    s.Fields[i],
+				Column:   col.Name,
+				Type:     qualifyType(s.Fields[i].Type, pkg),
+				Required: col.NotNull && !col.HasDefault,
+			})
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+var builtinTypes = map[string]struct{}{
+	"bool": {}, "byte": {}, "complex64": {}, "complex128": {}, "float32": {},
+	"float64": {}, "int": {}, "int8": {}, "int16": {}, "int32": {}, "int64": {},
+	"interface{}": {}, "rune": {}, "string": {}, "uint": {}, "uint8": {},
+	"uint16": {}, "uint32": {}, "uint64": {},
+}
+
+// Types declared in the models package, such as enums, are referred to by
+// their package name from the fixtures package
+func qualifyType(typ, pkg string) string {
+	elem := strings.TrimPrefix(typ, "[]")
+	if _, ok := builtinTypes[elem]; ok || strings.Contains(elem, ".") {
+		return typ
+	}
+	return strings.TrimSuffix(typ, elem) + pkg + "." + elem
+}
+
+func fixtureImports(r FixtureGenerateable, settings config.CombinedSettings, modelsPath string) [][]string {
+	imports := withStdImports(modelImports(r, settings), "context", "fmt", "strings")
+	pkg := map[string]struct{}{modelsPath: {}}
+	for _, p := range imports.Dep {
+		pkg[p] = struct{}{}
+	}
+	for _, f := range r.Fixtures(settings) {
+		for _, c := range f.Columns {
+			if c.IsArray() {
+				pkg["github.com/lib/pq"] = struct{}{}
+			}
+		}
+	}
+	imports.Dep = imports.Dep[:0]
+	for p := range pkg {
+		imports.Dep = append(imports.Dep, p)
+	}
+	sort.Strings(imports.Dep)
+	return mergeImports(imports)
+}
+
+type fixtureCtx struct {
+	Q          string
+	Package    string
+	Models     string
+	SourceName string
+	Fixtures   []GoFixture
+}
+
+func (c *fixtureCtx) UsesIntervalScan() bool {
+	for _, f := range c.Fixtures {
+		for _, col := range f.Columns {
+			if col.Field.Interval {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Generate the fixtures package for the models package at modelsPath. The
+// returned file names are relative to the output directory of the models.
+func GenerateFixtures(r FixtureGenerateable, settings config.CombinedSettings, modelsPath string) (map[string]string, error) {
+	imports := fixtureImports(r, settings, modelsPath)
+	funcMap := template.FuncMap{
+		"lowerTitle": LowerTitle,
+		"comment":    DoubleSlashComment,
+		"imports":    func(string) [][]string { return imports },
+		"quoteIdent": quoteIdent,
+	}
+	tmpl := template.Must(template.New("table").Funcs(funcMap).Parse(templateSet))
+
+	pkg := settings.Go.Package + "test"
+	ctx := fixtureCtx{
+		Q:          "`",
+		Package:    pkg,
+		Models:     settings.Go.Package,
+		SourceName: "fixtures.go",
+		Fixtures:   r.Fixtures(settings),
+	}
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+	err := tmpl.ExecuteTemplate(w, "fixturesFile", &ctx)
+	w.Flush()
+	if err != nil {
+		return nil, err
+	}
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("source error: %w", err)
+	}
+	return map[string]string{pkg + "/fixtures.go": string(code)}, nil
+}
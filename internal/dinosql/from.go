@@ -0,0 +1,371 @@
+package dinosql
+
+import (
+	"fmt"
+
+	nodes "github.com/lfittl/pg_query_go/nodes"
+
+	"github.com/kyleconroy/sqlc/internal/catalog"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// A table, derived table or join output visible to a query.
+//
+// Columns named in a JOIN ... USING clause are merged into a single output
+// column. The merged column lives in an unnamed table placed in front of the
+// joined tables, while the original columns are hidden from unqualified
+// references. They can still be referenced using the table name, e.g.
+// books.author_id.
+type sourceTable struct {
+	core.Table
+	hidden map[string]struct{}
+}
+
+func (t sourceTable) visible(scope string, c core.Column) bool {
+	if scope != "" {
+		return true
+	}
+	_, hidden := t.hidden[c.Name]
+	return !hidden
+}
+
+func newSourceTable(t core.Table) sourceTable {
+	return sourceTable{Table: t, hidden: map[string]struct{}{}}
+}
+
+func fromItemTables(qc *QueryCatalog, node nodes.Node) ([]sourceTable, error) {
+	switch n := node.(type) {
+
+	case nodes.RangeVar:
+		fqn, err := resolveRange(qc.catalog, &n)
+		if err != nil {
+			return nil, err
+		}
+		table, cerr := qc.GetTable(fqn)
+		if cerr != nil {
+			cerr.Location = n.Location
+			return nil, *cerr
+		}
+		if n.Alias != nil {
+			table.Name = *n.Alias.Aliasname
+		}
+		return []sourceTable{newSourceTable(table)}, nil
+
+	case nodes.RangeSubselect:
+		// LATERAL subqueries may reference columns from preceding FROM items.
+		// Those references only appear in expressions, so the output columns
+		// can be computed the same way as for any other subquery.
+		cols, err := outputColumns(qc, n.Subquery)
+		if err != nil {
+			return nil, err
+		}
+		table := core.Table{Columns: cols}
+		if n.Alias != nil {
+			table.Name = *n.Alias.Aliasname
+			for i, name := range stringSlice(n.Alias.Colnames) {
+				if i < len(table.Columns) {
+					table.Columns[i].Name = name
+				}
+			}
+		}
+		return []sourceTable{newSourceTable(table)}, nil
+
+	case nodes.RangeFunction:
+		table, err := functionTable(qc, n)
+		if err != nil {
+			return nil, err
+		}
+		return []sourceTable{newSourceTable(table)}, nil
+
+	case nodes.JoinExpr:
+		left, err := fromItemTables(qc, n.Larg)
+		if err != nil {
+			return nil, err
+		}
+		right, err := fromItemTables(qc, n.Rarg)
+		if err != nil {
+			return nil, err
+		}
+		using := stringSlice(n.UsingClause)
+		if n.IsNatural {
+			using = commonColumns(left, right)
+		}
+		if len(using) == 0 {
+			return append(left, right...), nil
+		}
+		merged, err := mergeUsingColumns(n.Jointype, using, left, right)
+		if err != nil {
+			return nil, err
+		}
+		tables := []sourceTable{newSourceTable(core.Table{Columns: merged})}
+		return append(tables, append(left, right...)...), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// The output of functions in a FROM clause, such as unnest or generate_series.
+// A function returning a scalar produces one column named after the function,
+// or after the alias of the FROM item. Functions returning records list their
+// columns in a column definition list:
+//
+//	SELECT * FROM json_to_record($1) AS r(id int, name text)
+func functionTable(qc *QueryCatalog, n nodes.RangeFunction) (core.Table, error) {
+	var table core.Table
+	scalar := len(n.Functions.Items) == 1
+	for _, item := range n.Functions.Items {
+		parts, ok := item.(nodes.List)
+		if !ok || len(parts.Items) == 0 {
+			continue
+		}
+		call, ok := parts.Items[0].(nodes.FuncCall)
+		if !ok {
+			table.Columns = append(table.Columns, core.Column{DataType: "any"})
+			continue
+		}
+		fqn, err := catalog.ParseList(call.Funcname)
+		if err != nil {
+			return table, err
+		}
+		if table.Name == "" {
+			table.Name = fqn.Rel
+		}
+
+		// ROWS FROM lists a column definition list for each function
+		defs := n.Coldeflist
+		if len(parts.Items) > 1 {
+			if list, ok := parts.Items[1].(nodes.List); ok && len(list.Items) > 0 {
+				defs = list
+			}
+		}
+		if len(defs.Items) > 0 {
+			scalar = false
+			for _, d := range defs.Items {
+				def, ok := d.(nodes.ColumnDef)
+				if !ok || def.TypeName == nil {
+					continue
+				}
+				col := catalog.ToColumn(def.TypeName)
+				col.Name = *def.Colname
+				col.NotNull = false
+				table.Columns = append(table.Columns, col)
+			}
+			continue
+		}
+
+		cols, ok := setFunctionColumns(fqn, call)
+		if !ok {
+			col := core.Column{Name: fqn.Rel, DataType: "any"}
+			if fun, err := qc.catalog.LookupFunctionN(fqn, len(call.Args.Items)); err == nil {
+				col.DataType = fun.ReturnType
+			}
+			cols = []core.Column{col}
+		} else if len(cols) != 1 || cols[0].Name != fqn.Rel {
+			scalar = false
+		}
+		table.Columns = append(table.Columns, cols...)
+	}
+	if n.Ordinality {
+		table.Columns = append(table.Columns, core.Column{
+			Name:     "ordinality",
+			DataType: "bigint",
+			NotNull:  true,
+		})
+	}
+	if n.Alias != nil {
+		table.Name = *n.Alias.Aliasname
+		names := stringSlice(n.Alias.Colnames)
+		if len(names) == 0 && scalar {
+			table.Columns[0].Name = table.Name
+		}
+		for i, name := range names {
+			if i < len(table.Columns) {
+				table.Columns[i].Name = name
+			}
+		}
+	}
+	return table, nil
+}
+
+// The columns of the set-returning functions built into PostgreSQL
+//
+// https://www.postgresql.org/docs/current/functions-srf.html
+// https://www.postgresql.org/docs/current/functions-json.html
+func setFunctionColumns(fqn core.FQN, call nodes.FuncCall) ([]core.Column, bool) {
+	if len(call.Funcname.Items) > 1 && fqn.Schema != "pg_catalog" {
+		return nil, false
+	}
+	switch fqn.Rel {
+	case "unnest":
+		// Each array is expanded into a column of its element type
+		var cols []core.Column
+		for _, arg := range call.Args.Items {
+			col := exprColumn(arg)
+			col.Name = "unnest"
+			col.IsArray = false
+			cols = append(cols, col)
+		}
+		return cols, true
+
+	case "generate_series":
+		col := core.Column{Name: "generate_series", DataType: "pg_catalog.int4", NotNull: true}
+		for _, arg := range call.Args.Items {
+			if c := exprColumn(arg); c.DataType != "any" {
+				col.DataType = c.DataType
+				if _, ok := arg.(nodes.TypeCast); ok {
+					break
+				}
+			}
+		}
+		return []core.Column{col}, true
+
+	case "json_array_elements", "jsonb_array_elements":
+		typ := "json"
+		if fqn.Rel == "jsonb_array_elements" {
+			typ = "jsonb"
+		}
+		return []core.Column{{Name: "value", DataType: typ, NotNull: true}}, true
+
+	case "json_array_elements_text", "jsonb_array_elements_text":
+		return []core.Column{{Name: "value", DataType: "text"}}, true
+
+	case "json_each", "jsonb_each":
+		typ := "json"
+		if fqn.Rel == "jsonb_each" {
+			typ = "jsonb"
+		}
+		return []core.Column{
+			{Name: "key", DataType: "text", NotNull: true},
+			{Name: "value", DataType: typ, NotNull: true},
+		}, true
+
+	case "json_each_text", "jsonb_each_text":
+		return []core.Column{
+			{Name: "key", DataType: "text", NotNull: true},
+			{Name: "value", DataType: "text"},
+		}, true
+
+	case "json_object_keys", "jsonb_object_keys":
+		return []core.Column{{Name: fqn.Rel, DataType: "text", NotNull: true}}, true
+
+	default:
+		return nil, false
+	}
+}
+
+// The type of an argument that's a cast or a constant
+func exprColumn(node nodes.Node) core.Column {
+	switch n := node.(type) {
+	case nodes.TypeCast:
+		if n.TypeName != nil {
+			return catalog.ToColumn(n.TypeName)
+		}
+	case nodes.A_Const:
+		switch n.Val.(type) {
+		case nodes.Integer:
+			return core.Column{DataType: "pg_catalog.int4", NotNull: true}
+		case nodes.Float:
+			return core.Column{DataType: "pg_catalog.numeric", NotNull: true}
+		case nodes.String:
+			return core.Column{DataType: "text", NotNull: true}
+		}
+	}
+	return core.Column{DataType: "any"}
+}
+
+func lookupUsingColumn(side, name string, tables []sourceTable) (core.Column, error) {
+	var col core.Column
+	var found int
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if c.Name == name && t.visible("", c) {
+				col = c
+				found += 1
+			}
+		}
+	}
+	if found == 0 {
+		return col, core.Error{
+			Code:    "42703",
+			Message: fmt.Sprintf("column \"%s\" specified in USING clause does not exist in %s table", name, side),
+		}
+	}
+	if found > 1 {
+		return col, core.Error{
+			Code:    "42702",
+			Message: fmt.Sprintf("common column name \"%s\" appears more than once in %s table", name, side),
+		}
+	}
+	return col, nil
+}
+
+func mergeUsingColumns(jt nodes.JoinType, using []string, left, right []sourceTable) ([]core.Column, error) {
+	var merged []core.Column
+	for _, name := range using {
+		l, err := lookupUsingColumn("left", name, left)
+		if err != nil {
+			return nil, err
+		}
+		r, err := lookupUsingColumn("right", name, right)
+		if err != nil {
+			return nil, err
+		}
+		// The merged column takes its value from the side of the join that
+		// always produces a row. A FULL join merges both sides using COALESCE,
+		// which is only NOT NULL if both sides are.
+		col := l
+		switch jt {
+		case nodes.JOIN_RIGHT:
+			col = r
+		case nodes.JOIN_INNER:
+			col.NotNull = l.NotNull || r.NotNull
+		case nodes.JOIN_FULL:
+			col.NotNull = l.NotNull && r.NotNull
+		}
+		col.Name = name
+		merged = append(merged, col)
+
+		for _, t := range append(left, right...) {
+			t.hidden[name] = struct{}{}
+		}
+	}
+	return merged, nil
+}
+
+// NATURAL joins are shorthand for a USING list naming all columns that appear
+// in both tables.
+func commonColumns(left, right []sourceTable) []string {
+	seen := map[string]struct{}{}
+	for _, t := range right {
+		for _, c := range t.Columns {
+			if t.visible("", c) {
+				seen[c.Name] = struct{}{}
+			}
+		}
+	}
+	var names []string
+	for _, t := range left {
+		for _, c := range t.Columns {
+			if _, ok := seen[c.Name]; ok && t.visible("", c) {
+				names = append(names, c.Name)
+			}
+		}
+	}
+	return names
+}
+
+// Return the names of the columns merged by JOIN ... USING clauses
+func usingColumns(root nodes.Node) map[string]struct{} {
+	names := map[string]struct{}{}
+	joins := search(root, func(node nodes.Node) bool {
+		_, ok := node.(nodes.JoinExpr)
+		return ok
+	})
+	for _, item := range joins.Items {
+		for _, name := range stringSlice(item.(nodes.JoinExpr).UsingClause) {
+			names[name] = struct{}{}
+		}
+	}
+	return names
+}
@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"go/format"
 	"log"
-	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"unicode"
@@ -19,8 +19,6 @@ import (
 	"github.com/jinzhu/inflection"
 )
 
-var identPattern = regexp.MustCompile("[^a-zA-Z0-9_]+")
-
 type GoConstant struct {
 	Name  string
 	Type  string
@@ -38,6 +36,18 @@ type GoField struct {
 	Type    string
 	Tags    map[string]string
 	Comment string
+
+	// Scanned by unmarshaling a json or jsonb column
+	JSON bool
+
+	// Holds data from a sensitive column, which is left out of String
+	Sensitive bool
+
+	// A time.Duration read from and written as a PostgreSQL interval
+	Interval bool
+
+	// The column of a model's table
+	Column string
 }
 
 func (gf GoField) Tag() string {
@@ -49,7 +59,23 @@ func (gf GoField) Tag() string {
 		return ""
 	}
 	sort.Strings(tags)
-	return strings.Join(tags, ",")
+	return strings.Join(tags, " ")
+}
+
+// The tag of the field in a struct declaration. The JSON tag is only included
+// with emit_json_tags, and a sensitive field sets sensitiveTag to "-".
+func (gf GoField) StructTag(emitJSON bool, sensitiveTag string) string {
+	f := gf
+	f.Tags = map[string]string{}
+	if emitJSON {
+		for key, val := range gf.Tags {
+			f.Tags[key] = val
+		}
+	}
+	if gf.Sensitive {
+		f.Tags[sensitiveTag+":"] = "-"
+	}
+	return f.Tag()
 }
 
 type GoStruct struct {
@@ -57,6 +83,46 @@ type GoStruct struct {
 	Name    string
 	Fields  []GoField
 	Comment string
+
+	// The row-level security of a model's table, documented after Comment
+	Security string
+}
+
+// The fields of the struct, for comparing structs regardless of their names
+func (s GoStruct) shape() string {
+	var b strings.Builder
+	for _, f := range s.Fields {
+		fmt.Fprintf(&b, "%s %s `%s` %t\n", f.Name, f.Type, f.Tag(), f.Sensitive)
+	}
+	return b.String()
+}
+
+func (s GoStruct) HasSensitive() bool {
+	for _, f := range s.Fields {
+		if f.Sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// The format string of the String method of a struct with sensitive fields,
+// which prints like %+v with the sensitive values replaced by [redacted]
+func (s GoStruct) StringFormat() string {
+	var b strings.Builder
+	b.WriteString(s.Name + "{")
+	for i, f := range s.Fields {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		if f.Sensitive {
+			b.WriteString(f.Name + ":[redacted]")
+		} else {
+			b.WriteString(f.Name + ":%v")
+		}
+	}
+	b.WriteString("}")
+	return strconv.Quote(b.String())
 }
 
 type GoQueryValue struct {
@@ -64,6 +130,10 @@ type GoQueryValue struct {
 	Name   string
 	Struct *GoStruct
 	Typ    string
+	JSON   bool
+
+	// A time.Duration read from and written as a PostgreSQL interval
+	Interval bool
 }
 
 func (v GoQueryValue) EmitStruct() bool {
@@ -96,25 +166,36 @@ func (v GoQueryValue) Type() string {
 }
 
 func (v GoQueryValue) Params() string {
+	return formatParams(v.paramList())
+}
+
+func (v GoQueryValue) paramList() []string {
 	if v.isEmpty() {
-		return ""
+		return nil
 	}
 	var out []string
 	if v.Struct == nil {
-		if strings.HasPrefix(v.Typ, "[]") && v.Typ != "[]byte" {
-			out = append(out, "pq.Array("+v.Name+")")
-		} else {
-			out = append(out, v.Name)
-		}
+		out = append(out, paramValue(v.Name, v.Typ, v.Interval))
 	} else {
 		for _, f := range v.Struct.Fields {
-			if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
-				out = append(out, "pq.Array("+v.Name+"."+f.Name+")")
-			} else {
-				out = append(out, v.Name+"."+f.Name)
-			}
+			out = append(out, paramValue(v.Name+"."+f.Name, f.Type, f.Interval))
 		}
 	}
+	return out
+}
+
+func paramValue(name, typ string, isInterval bool) string {
+	switch {
+	case isInterval:
+		return "NullDuration{Duration: " + name + ", Valid: true}"
+	case strings.HasPrefix(typ, "[]") && typ != "[]byte":
+		return "pq.Array(" + name + ")"
+	default:
+		return name
+	}
+}
+
+func formatParams(out []string) string {
 	if len(out) <= 3 {
 		return strings.Join(out, ",")
 	}
@@ -125,18 +206,10 @@ func (v GoQueryValue) Params() string {
 func (v GoQueryValue) Scan() string {
 	var out []string
 	if v.Struct == nil {
-		if strings.HasPrefix(v.Typ, "[]") && v.Typ != "[]byte" {
-			out = append(out, "pq.Array(&"+v.Name+")")
-		} else {
-			out = append(out, "&"+v.Name)
-		}
+		out = append(out, scanTarget(v.Name, v.Typ, v.JSON, v.Interval))
 	} else {
 		for _, f := range v.Struct.Fields {
-			if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
-				out = append(out, "pq.Array(&"+v.Name+"."+f.Name+")")
-			} else {
-				out = append(out, "&"+v.Name+"."+f.Name)
-			}
+			out = append(out, scanTarget(v.Name+"."+f.Name, f.Type, f.JSON, f.Interval))
 		}
 	}
 	if len(out) <= 3 {
@@ -146,17 +219,209 @@ func (v GoQueryValue) Scan() string {
 	return "\n" + strings.Join(out, ",\n")
 }
 
+func scanTarget(name, typ string, isJSON, isInterval bool) string {
+	switch {
+	case isJSON:
+		return "jsonScan{&" + name + "}"
+	case isInterval:
+		return "intervalScan{&" + name + "}"
+	case strings.HasPrefix(typ, "[]") && typ != "[]byte":
+		return "pq.Array(&" + name + ")"
+	default:
+		return "&" + name
+	}
+}
+
 // A struct used to generate methods and fields on the Queries struct
 type GoQuery struct {
 	Cmd          string
 	Comments     []string
+	Name         string // The name in the query's -- name: header
 	MethodName   string
 	FieldName    string
 	ConstantName string
 	SQL          string
 	SourceName   string
 	Ret          GoQueryValue
+
+	// The SQL of the query for SQLite, if the package checks its queries with
+	// SQLite and the SQL is different
+	SQLiteSQL string
+
 	Arg          GoQueryValue
+	Sort         *GoSort
+
+	// A Go expression for the duration of the query's timeout
+	Timeout string
+
+	// Packages of the parameter and column types set by annotations, by
+	// type name
+	ParamPackages map[string]string
+
+	Page *GoPage
+
+	// Structs declared by json annotations for the query's columns
+	JSONStructs []GoStruct
+
+	// The statements of an :execscript query, in order
+	Script []GoScriptStatement
+
+	// The session variables the query reads with current_setting
+	SessionVars []GoSessionVar
+}
+
+// A statement of an :execscript query. The first one is the query's constant.
+type GoScriptStatement struct {
+	ConstantName string
+	SQL          string
+	SQLiteSQL    string
+	Params       string
+	Last         bool
+}
+
+// The cursor of a :paginated query
+type GoPage struct {
+	Cursor       string
+	Fields       []GoCursorField
+	NextConstant  string
+	NextSQL       string
+	NextSQLiteSQL string
+}
+
+type GoCursorField struct {
+	GoField
+	Value string // The field's value in the last row of a page, e.g. last.ID
+}
+
+// The whitelisted sort orders of a query with a sort annotation
+type GoSort struct {
+	Name      string
+	Marker    string
+	Constants []GoConstant
+}
+
+// Unexported methods are left out of the Querier interface, so that other
+// packages can still implement it.
+func (q GoQuery) Exported() bool {
+	return isExported(q.MethodName)
+}
+
+// The parameters of the generated method, following the context. Paginated
+// methods take the cursor to start after, nil for the first page, and the
+// size of the page.
+func (q GoQuery) ArgPair() string {
+	var pairs []string
+	if pair := q.Arg.Pair(); pair != "" {
+		pairs = append(pairs, pair)
+	}
+	if q.Sort != nil {
+		pairs = append(pairs, "orderBy "+q.Sort.Name)
+	}
+	if q.Page != nil {
+		pairs = append(pairs, "after *"+q.Page.Cursor, "limit int32")
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// The arguments that pass the parameters of the method on to another call
+func (q GoQuery) CallArgs() string {
+	var args []string
+	if !q.Arg.isEmpty() {
+		args = append(args, q.Arg.Name)
+	}
+	if q.Sort != nil {
+		args = append(args, "orderBy")
+	}
+	if q.Page != nil {
+		args = append(args, "after", "limit")
+	}
+	return strings.Join(args, ", ")
+}
+
+// The arguments of the first or the next pages of a paginated query
+func (q GoQuery) PageParams(next bool) string {
+	params := q.Arg.paramList()
+	if next {
+		for _, f := range q.Page.Fields {
+			params = append(params, paramValue("after."+f.Name, f.Type, f.Interval))
+		}
+	}
+	return formatParams(append(params, "limit"))
+}
+
+// The methods of :exec and :execrows queries don't scan any rows, so the types
+// of their columns aren't used
+func (q GoQuery) hasRetType() bool {
+	return q.Cmd != ":exec" && q.Cmd != ":execrows" && q.Cmd != ":execscript" && !q.Ret.isEmpty()
+}
+
+// Sorted and paginated queries pick their SQL when they're called, so they
+// aren't prepared ahead of time. Neither are the statements of scripts.
+func (q GoQuery) Prepared() bool {
+	return q.Sort == nil && q.Page == nil && q.Script == nil
+}
+
+// The query string passed to the database. Sorted queries replace the marker
+// with the requested sort order, which has been checked against the whitelist.
+func (q GoQuery) Query() string {
+	if q.Sort == nil {
+		return q.Ref()
+	}
+	return fmt.Sprintf("strings.Replace(%s, %q, string(orderBy), 1)", q.Ref(), q.Sort.Marker)
+}
+
+// The constant holding the query's SQL. Queries made with NewSQLite pick the
+// SQLite version, if there is one.
+func (q GoQuery) Ref() string {
+	return dialectRef(q.ConstantName, q.SQLiteSQL)
+}
+
+func (s GoScriptStatement) Ref() string {
+	return dialectRef(s.ConstantName, s.SQLiteSQL)
+}
+
+func (p GoPage) NextRef() string {
+	return dialectRef(p.NextConstant, p.NextSQLiteSQL)
+}
+
+func dialectRef(constant, sqlite string) string {
+	if sqlite == "" {
+		return constant
+	}
+	return fmt.Sprintf("q.dialect(%s, %sSQLite)", constant, constant)
+}
+
+// Report whether the package's queries are also checked with SQLite, which
+// gets its own versions of their SQL
+func checksSQLite(settings config.CombinedSettings) bool {
+	for _, engine := range settings.Package.CheckEngines {
+		if engine == config.EngineSQLite {
+			return true
+		}
+	}
+	return false
+}
+
+// The SQLite version of a query, or nothing if it's the same. Queries that
+// can't be translated have already been reported by check_engines.
+func sqliteSQL(sql string, settings config.CombinedSettings) string {
+	if !checksSQLite(settings) {
+		return ""
+	}
+	translated, err := TranslateSQLite(sql)
+	if err != nil || translated == sql {
+		return ""
+	}
+	return translated
+}
+
+// The name of the constant holding the SQL of a query. Exported constants let
+// applications log or explain the queries without copying them.
+func ConstantName(name string, settings config.CombinedSettings) string {
+	if settings.Go.EmitExportedQueries {
+		return strings.Title(name)
+	}
+	return LowerTitle(name)
 }
 
 type Generateable interface {
@@ -227,22 +492,51 @@ func Imports(r Generateable, settings config.CombinedSettings) func(string) [][]
 		}
 
 		if filename == "models.go" {
-			return mergeImports(modelImports(r, settings))
+			imports := modelImports(r, settings)
+			if usesNullDuration(r, settings) {
+				imports = withStdImports(imports, "database/sql/driver", "fmt", "math", "strconv", "strings", "time")
+			}
+			if modelHelpersScanArrays(r, settings) {
+				imports = withDepImports(imports, "github.com/lib/pq")
+			}
+			return mergeImports(imports)
 		}
 
 		if filename == "querier.go" {
 			return mergeImports(interfaceImports(r, settings))
 		}
 
+		if filename == "hooks.go" {
+			return mergeImports(hookImports(r, settings))
+		}
+
+		if filename == "retry.go" {
+			return mergeImports(retryImports(r, settings))
+		}
+
+		if filename == "querier_mock.go" {
+			return mergeImports(mockImports(r, settings))
+		}
+
 		return mergeImports(queryImports(r, settings, filename))
 	}
 }
 
 func dbImports(r Generateable, settings config.CombinedSettings) fileImports {
 	std := []string{"context", "database/sql"}
-	if settings.Go.EmitPreparedQueries {
+	if usesJSONScan(r.GoQueries(settings)) {
+		std = append(std, "encoding/json")
+	}
+	intervalScan := usesIntervalScan(r.GoQueries(settings)) || settings.Go.EmitModelHelpers && modelHelpersScanIntervals(r.Structs(settings))
+	if settings.Go.EmitPreparedQueries || usesJSONScan(r.GoQueries(settings)) || intervalScan {
 		std = append(std, "fmt")
 	}
+	if intervalScan {
+		std = append(std, "time")
+	}
+	if len(sessionSetters(r.GoQueries(settings), settings)) > 0 {
+		std = append(std, "errors")
+	}
 	return fileImports{Std: std}
 }
 
@@ -250,8 +544,8 @@ func interfaceImports(r Generateable, settings config.CombinedSettings) fileImpo
 	gq := r.GoQueries(settings)
 	uses := func(name string) bool {
 		for _, q := range gq {
-			if !q.Ret.isEmpty() {
-				if strings.HasPrefix(q.Ret.Type(), name) {
+			if q.hasRetType() {
+				if strings.HasPrefix(strings.TrimPrefix(q.Ret.Type(), "[]"), name) {
 					return true
 				}
 			}
@@ -273,7 +567,7 @@ func interfaceImports(r Generateable, settings config.CombinedSettings) fileImpo
 	if uses("json.RawMessage") {
 		std["encoding/json"] = struct{}{}
 	}
-	if uses("time.Time") {
+	if uses("time.Time") || uses("time.Duration") {
 		std["time"] = struct{}{}
 	}
 	if uses("net.IP") {
@@ -291,6 +585,11 @@ func interfaceImports(r Generateable, settings config.CombinedSettings) fileImpo
 		}
 		overrideTypes[o.GoTypeName] = o.GoPackage
 	}
+	for _, q := range gq {
+		for goType, pkg := range q.ParamPackages {
+			overrideTypes[goType] = pkg
+		}
+	}
 
 	_, overrideNullTime := overrideTypes["pq.NullTime"]
 	if uses("pq.NullTime") && !overrideNullTime {
@@ -323,6 +622,48 @@ func interfaceImports(r Generateable, settings config.CombinedSettings) fileImpo
 	return fileImports{stds, pkgs}
 }
 
+func hookImports(r Generateable, settings config.CombinedSettings) fileImports {
+	return withStdImports(interfaceImports(r, settings), "database/sql", "time")
+}
+
+func retryImports(r Generateable, settings config.CombinedSettings) fileImports {
+	return withStdImports(interfaceImports(r, settings), "context", "database/sql", "errors", "time")
+}
+
+func mockImports(r Generateable, settings config.CombinedSettings) fileImports {
+	return withStdImports(interfaceImports(r, settings), "fmt", "sync")
+}
+
+func withStdImports(imports fileImports, pkgs ...string) fileImports {
+	std := map[string]struct{}{}
+	for _, s := range pkgs {
+		std[s] = struct{}{}
+	}
+	for _, s := range imports.Std {
+		std[s] = struct{}{}
+	}
+	imports.Std = imports.Std[:0]
+	for s := range std {
+		imports.Std = append(imports.Std, s)
+	}
+	sort.Strings(imports.Std)
+	return imports
+}
+
+func withDepImports(imports fileImports, pkgs ...string) fileImports {
+	for _, p := range pkgs {
+		found := false
+		for _, dep := range imports.Dep {
+			found = found || dep == p
+		}
+		if !found {
+			imports.Dep = append(imports.Dep, p)
+		}
+	}
+	sort.Strings(imports.Dep)
+	return imports
+}
+
 func modelImports(r Generateable, settings config.CombinedSettings) fileImports {
 	std := make(map[string]struct{})
 	if UsesType(r, "sql.Null", settings) {
@@ -331,7 +672,7 @@ func modelImports(r Generateable, settings config.CombinedSettings) fileImports
 	if UsesType(r, "json.RawMessage", settings) {
 		std["encoding/json"] = struct{}{}
 	}
-	if UsesType(r, "time.Time", settings) {
+	if UsesType(r, "time.Time", settings) || UsesType(r, "time.Duration", settings) {
 		std["time"] = struct{}{}
 	}
 	if UsesType(r, "net.IP", settings) {
@@ -340,6 +681,14 @@ func modelImports(r Generateable, settings config.CombinedSettings) fileImports
 	if UsesType(r, "net.HardwareAddr", settings) {
 		std["net"] = struct{}{}
 	}
+	if settings.Go.EmitEnumHelpers && len(r.Enums(settings)) > 0 {
+		std["fmt"] = struct{}{}
+	}
+	for _, s := range r.Structs(settings) {
+		if s.HasSensitive() {
+			std["fmt"] = struct{}{}
+		}
+	}
 
 	// Custom imports
 	pkg := make(map[string]struct{})
@@ -399,7 +748,7 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 
 	uses := func(name string) bool {
 		for _, q := range gq {
-			if !q.Ret.isEmpty() {
+			if q.hasRetType() {
 				if q.Ret.EmitStruct() {
 					for _, f := range q.Ret.Struct.Fields {
 						fType := strings.TrimPrefix(f.Type, "[]")
@@ -408,10 +757,17 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 						}
 					}
 				}
-				if strings.HasPrefix(q.Ret.Type(), name) {
+				if strings.HasPrefix(strings.TrimPrefix(q.Ret.Type(), "[]"), name) {
 					return true
 				}
 			}
+			for _, s := range q.JSONStructs {
+				for _, f := range s.Fields {
+					if strings.HasPrefix(strings.TrimPrefix(f.Type, "[]"), name) {
+						return true
+					}
+				}
+			}
 			if !q.Arg.isEmpty() {
 				if q.Arg.EmitStruct() {
 					for _, f := range q.Arg.Struct.Fields {
@@ -425,21 +781,28 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 					return true
 				}
 			}
+			if q.Page != nil {
+				for _, f := range q.Page.Fields {
+					if strings.HasPrefix(f.Type, name) {
+						return true
+					}
+				}
+			}
 		}
 		return false
 	}
 
 	sliceScan := func() bool {
 		for _, q := range gq {
-			if !q.Ret.isEmpty() {
+			if q.hasRetType() {
 				if q.Ret.IsStruct() {
 					for _, f := range q.Ret.Struct.Fields {
-						if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
+						if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" && !f.JSON {
 							return true
 						}
 					}
 				} else {
-					if strings.HasPrefix(q.Ret.Type(), "[]") && q.Ret.Type() != "[]byte" {
+					if strings.HasPrefix(q.Ret.Type(), "[]") && q.Ret.Type() != "[]byte" && !q.Ret.JSON {
 						return true
 					}
 				}
@@ -470,12 +833,33 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 	if uses("json.RawMessage") {
 		std["encoding/json"] = struct{}{}
 	}
-	if uses("time.Time") {
+	if uses("time.Time") || uses("time.Duration") {
 		std["time"] = struct{}{}
 	}
 	if uses("net.IP") {
 		std["net"] = struct{}{}
 	}
+	for _, q := range gq {
+		if q.Sort != nil {
+			std["fmt"] = struct{}{}
+			std["strings"] = struct{}{}
+		}
+		if q.Timeout != "" {
+			std["time"] = struct{}{}
+		}
+		if q.Arg.EmitStruct() && q.Arg.Struct.HasSensitive() {
+			std["fmt"] = struct{}{}
+		}
+		if q.Ret.EmitStruct() && q.Ret.Struct.HasSensitive() {
+			std["fmt"] = struct{}{}
+		}
+		if q.Page != nil {
+			std["database/sql"] = struct{}{}
+			std["encoding/base64"] = struct{}{}
+			std["encoding/json"] = struct{}{}
+			std["fmt"] = struct{}{}
+		}
+	}
 
 	pkg := make(map[string]struct{})
 	overrideTypes := map[string]string{}
@@ -485,6 +869,11 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 		}
 		overrideTypes[o.GoTypeName] = o.GoPackage
 	}
+	for _, q := range gq {
+		for goType, pkg := range q.ParamPackages {
+			overrideTypes[goType] = pkg
+		}
+	}
 
 	if sliceScan() {
 		pkg["github.com/lib/pq"] = struct{}{}
@@ -520,18 +909,6 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 	return fileImports{stds, pkgs}
 }
 
-func enumValueName(value string) string {
-	name := ""
-	id := strings.Replace(value, "-", "_", -1)
-	id = strings.Replace(id, ":", "_", -1)
-	id = strings.Replace(id, "/", "_", -1)
-	id = identPattern.ReplaceAllString(id, "")
-	for _, part := range strings.Split(id, "_") {
-		name += strings.Title(part)
-	}
-	return name
-}
-
 func (r Result) Enums(settings config.CombinedSettings) []GoEnum {
 	var enums []GoEnum
 	for name, schema := range r.Catalog.Schemas {
@@ -539,11 +916,11 @@ func (r Result) Enums(settings config.CombinedSettings) []GoEnum {
 			continue
 		}
 		for _, enum := range schema.Enums() {
-			var enumName string
+			var enumName, sqlName string
 			if name == "public" {
-				enumName = enum.Name
+				enumName, sqlName = enum.Name, enum.Name
 			} else {
-				enumName = name + "_" + enum.Name
+				enumName, sqlName = name+"_"+enum.Name, name+"."+enum.Name
 			}
 			e := GoEnum{
 				Name:    StructName(enumName, settings),
@@ -551,7 +928,7 @@ func (r Result) Enums(settings config.CombinedSettings) []GoEnum {
 			}
 			for _, v := range enum.Vals {
 				e.Constants = append(e.Constants, GoConstant{
-					Name:  e.Name + enumValueName(v),
+					Name:  enumConstantName(e.Name, sqlName, v, settings),
 					Value: v,
 					Type:  e.Name,
 				})
@@ -594,16 +971,20 @@ func (r Result) Structs(settings config.CombinedSettings) []GoStruct {
 				tableName = name + "_" + table.Name
 			}
 			s := GoStruct{
-				Table:   core.FQN{Schema: name, Rel: table.Name},
-				Name:    inflection.Singular(StructName(tableName, settings)),
-				Comment: table.Comment,
+				Table:    core.FQN{Schema: name, Rel: table.Name},
+				Name:     inflection.Singular(StructName(tableName, settings)),
+				Comment:  table.Comment,
+				Security: securityComment(table),
 			}
 			for _, column := range table.Columns {
 				s.Fields = append(s.Fields, GoField{
 					Name:    StructName(column.Name, settings),
-					Type:    r.goType(column, settings),
-					Tags:    map[string]string{"json:": column.Name},
-					Comment: column.Comment,
+					Type:      r.goType(column, settings),
+					Tags:      map[string]string{"json:": column.Name},
+					Comment:   column.Comment,
+					Sensitive: column.Sensitive,
+					Interval:  r.isInterval(column, settings),
+					Column:    column.Name,
 				})
 			}
 			structs = append(structs, s)
@@ -615,6 +996,13 @@ func (r Result) Structs(settings config.CombinedSettings) []GoStruct {
 	return structs
 }
 
+// A NOT NULL interval column mapped to time.Duration is scanned with
+// intervalScan and passed as a NullDuration, as database/sql doesn't convert
+// between the two
+func (r Result) isInterval(col core.Column, settings config.CombinedSettings) bool {
+	return !col.IsArray && r.goType(col, settings) == "time.Duration" && (col.DataType == "interval" || col.DataType == "pg_catalog.interval")
+}
+
 func (r Result) goType(col core.Column, settings config.CombinedSettings) string {
 	// package overrides have a higher precedence
 	for _, oride := range settings.Overrides {
@@ -631,6 +1019,12 @@ func (r Result) goType(col core.Column, settings config.CombinedSettings) string
 
 func (r Result) goInnerType(col core.Column, settings config.CombinedSettings) string {
 	columnType := col.DataType
+	if settings.Package.Engine == config.EngineCockroachDB {
+		columnType = cockroachType(columnType)
+	}
+	if settings.Package.Engine == config.EngineRedshift {
+		columnType = redshiftType(columnType)
+	}
 	notNull := col.NotNull || col.IsArray
 
 	// package overrides have a higher precedence
@@ -693,18 +1087,34 @@ func (r Result) goInnerType(col core.Column, settings config.CombinedSettings) s
 		}
 		return "sql.NullString"
 
+	case "money", "pg_catalog.money":
+		// lib/pq returns money as text formatted with the currency symbol of
+		// the database's lc_monetary setting, such as "$1.00"
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
 	case "bool", "pg_catalog.bool":
 		if notNull {
 			return "bool"
 		}
 		return "sql.NullBool"
 
-	case "jsonb":
+	case "json", "jsonb":
 		return "json.RawMessage"
 
 	case "bytea", "blob", "pg_catalog.bytea":
 		return "[]byte"
 
+	case "interval", "pg_catalog.interval":
+		// A nullable interval is a NullDuration, declared in models.go. So are
+		// the elements of arrays, which pq.Array scans with their Scan method.
+		if notNull && !col.IsArray {
+			return "time.Duration"
+		}
+		return "NullDuration"
+
 	case "date":
 		if notNull {
 			return "time.Time"
@@ -794,6 +1204,10 @@ func (r Result) goInnerType(col core.Column, settings config.CombinedSettings) s
 type goColumn struct {
 	id int
 	core.Column
+
+	// The Go type set by a param or json annotation
+	typ  string
+	json bool
 }
 
 // It's possible that this method will generate duplicate JSON tag values
@@ -825,21 +1239,62 @@ func (r Result) columnsToStruct(name string, columns []goColumn, settings config
 			tagName = fmt.Sprintf("%s_%d", tagName, suffix)
 			fieldName = fmt.Sprintf("%s_%d", fieldName, suffix)
 		}
+		typ := c.typ
+		if typ == "" {
+			typ = r.goType(c.Column, settings)
+		}
 		gs.Fields = append(gs.Fields, GoField{
 			Name: fieldName,
-			Type: r.goType(c.Column, settings),
-			Tags: map[string]string{"json:": tagName},
+			Type: typ,
+			Tags:      map[string]string{"json:": tagName},
+			JSON:      c.json,
+			Sensitive: c.Sensitive,
+			Interval:  c.typ == "" && r.isInterval(c.Column, settings),
 		})
 		seen[c.Name]++
 	}
 	return &gs
 }
 
+// The struct declared by a json annotation. Its fields always have JSON tags,
+// as the keys of the JSON built by the query are column names.
+func (r Result) jsonStruct(jt JSONType, settings config.CombinedSettings) GoStruct {
+	gs := GoStruct{Name: jt.Struct}
+	for _, c := range jt.Fields {
+		// A JSON null leaves the zero value
+		c.NotNull = true
+		gs.Fields = append(gs.Fields, GoField{
+			Name: StructName(c.Name, settings),
+			Type: r.goType(c, settings),
+			Tags: map[string]string{"json:": c.Name},
+		})
+	}
+	return gs
+}
+
+// Lower case the first word of a mixed-case name, so that the columns
+// "firstName", "UserID" and "URLPath" are the arguments firstName, userID and
+// urlPath
+func lowerFirstWord(name string) string {
+	r := []rune(name)
+	upper := 0
+	for upper < len(r) && unicode.IsUpper(r[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(r) {
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
 func argName(name string) string {
 	out := ""
 	for i, p := range strings.Split(name, "_") {
 		if i == 0 {
-			out += strings.ToLower(p)
+			out += lowerFirstWord(p)
 		} else if p == "id" {
 			out += "ID"
 		} else {
@@ -875,6 +1330,8 @@ func compareFQN(a *core.FQN, b *core.FQN) bool {
 
 func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 	structs := r.Structs(settings)
+	rows := map[string]*GoStruct{}
+	jsonStructs := map[string]bool{}
 
 	qs := make([]GoQuery, 0, len(r.Queries))
 	for _, query := range r.Queries {
@@ -887,19 +1344,76 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 
 		gq := GoQuery{
 			Cmd:          query.Cmd,
-			ConstantName: LowerTitle(query.Name),
+			Name:         query.Name,
+			ConstantName: ConstantName(query.Name, settings),
 			FieldName:    LowerTitle(query.Name) + "Stmt",
-			MethodName:   query.Name,
+			MethodName:   query.MethodName(),
 			SourceName:   query.Filename,
 			SQL:          query.SQL,
+			SQLiteSQL:    sqliteSQL(query.SQL, settings),
 			Comments:     query.Comments,
 		}
+		if query.Timeout > 0 {
+			gq.Timeout = durationExpr(query.Timeout)
+		}
+		for _, v := range query.SessionVars {
+			col := v.Column
+			col.NotNull = true
+			gq.SessionVars = append(gq.SessionVars, GoSessionVar{Name: v.Name, Type: r.goType(col, settings), Cast: v.Cast})
+		}
+		for _, pt := range query.ParamTypes {
+			if pt.Package == "" {
+				continue
+			}
+			if gq.ParamPackages == nil {
+				gq.ParamPackages = map[string]string{}
+			}
+			gq.ParamPackages[pt.GoType] = pt.Package
+		}
+		for i := range query.Columns {
+			jt, ok := query.JSONTypes[i]
+			if !ok {
+				continue
+			}
+			if jt.Package != "" {
+				if gq.ParamPackages == nil {
+					gq.ParamPackages = map[string]string{}
+				}
+				gq.ParamPackages[strings.TrimLeft(jt.GoType, "[]*")] = jt.Package
+			}
+			// Queries declaring the same struct share it
+			if jt.Struct != "" && !jsonStructs[jt.Struct] {
+				jsonStructs[jt.Struct] = true
+				gq.JSONStructs = append(gq.JSONStructs, r.jsonStruct(jt, settings))
+			}
+		}
+
+		if query.Sort != nil {
+			gq.Sort = &GoSort{
+				Name:   gq.MethodName + "Sort",
+				Marker: sortMarker,
+			}
+			for _, col := range query.Sort.Columns {
+				name := gq.Sort.Name + StructName(strings.Replace(col, ".", "_", -1), settings)
+				for _, dir := range []string{"ASC", "DESC"} {
+					gq.Sort.Constants = append(gq.Sort.Constants, GoConstant{
+						Name:  name + strings.Title(strings.ToLower(dir)),
+						Value: col + " " + dir,
+						Type:  gq.Sort.Name,
+					})
+				}
+			}
+		}
 
 		if len(query.Params) == 1 {
 			p := query.Params[0]
 			gq.Arg = GoQueryValue{
-				Name: paramName(p),
-				Typ:  r.goType(p.Column, settings),
+				Name:     paramName(p),
+				Typ:      r.goType(p.Column, settings),
+				Interval: r.isInterval(p.Column, settings),
+			}
+			if pt, ok := query.ParamTypes[p.Number]; ok {
+				gq.Arg.Typ, gq.Arg.Interval = pt.GoType, false
 			}
 		} else if len(query.Params) > 1 {
 			var cols []goColumn
@@ -907,6 +1421,7 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 				cols = append(cols, goColumn{
 					id:     p.Number,
 					Column: p.Column,
+					typ:    query.ParamTypes[p.Number].GoType,
 				})
 			}
 			gq.Arg = GoQueryValue{
@@ -919,15 +1434,19 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 		if len(query.Columns) == 1 {
 			c := query.Columns[0]
 			gq.Ret = GoQueryValue{
-				Name: columnName(c, 0),
-				Typ:  r.goType(c, settings),
+				Name:     columnName(c, 0),
+				Typ:      r.goType(c, settings),
+				Interval: r.isInterval(c, settings),
+			}
+			if jt, ok := query.JSONTypes[0]; ok {
+				gq.Ret.Typ, gq.Ret.JSON = jt.GoType, true
 			}
 		} else if len(query.Columns) > 1 {
 			var gs *GoStruct
 			var emit bool
 
 			for _, s := range structs {
-				if len(s.Fields) != len(query.Columns) {
+				if settings.Go.EmitRowStructs || len(query.JSONTypes) > 0 || len(s.Fields) != len(query.Columns) {
 					continue
 				}
 				same := true
@@ -950,13 +1469,24 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 			if gs == nil {
 				var columns []goColumn
 				for i, c := range query.Columns {
+					jt, ok := query.JSONTypes[i]
 					columns = append(columns, goColumn{
 						id:     i,
 						Column: c,
+						typ:    jt.GoType,
+						json:   ok,
 					})
 				}
 				gs = r.columnsToStruct(gq.MethodName+"Row", columns, settings)
 				emit = true
+				// Queries with the same columns share the struct of the first
+				if settings.Go.EmitSharedRowStructs {
+					if shared, ok := rows[gs.shape()]; ok {
+						gs, emit = shared, false
+					} else {
+						rows[gs.shape()] = gs
+					}
+				}
 			}
 			gq.Ret = GoQueryValue{
 				Emit:   emit,
@@ -965,6 +1495,65 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 			}
 		}
 
+		if query.Pagination != nil {
+			gq.Page = &GoPage{
+				Cursor:       gq.MethodName + "Cursor",
+				NextConstant: gq.ConstantName + "Next",
+				NextSQL:       query.Pagination.NextSQL,
+				NextSQLiteSQL: sqliteSQL(query.Pagination.NextSQL, settings),
+			}
+			for _, i := range query.Pagination.Columns {
+				c := query.Columns[i]
+				f := GoCursorField{
+					GoField: GoField{
+						Name:     StructName(columnName(c, i), settings),
+						Type:     r.goType(c, settings),
+						Tags:     map[string]string{"json:": columnName(c, i)},
+						Interval: r.isInterval(c, settings),
+					},
+					Value: "last",
+				}
+				if gq.Ret.Struct != nil {
+					f.Value = "last." + gq.Ret.Struct.Fields[i].Name
+				}
+				gq.Page.Fields = append(gq.Page.Fields, f)
+			}
+		}
+
+		// The parameter would hide the query constant in the method. The
+		// result of a :one query is declared next to the parameter.
+		if gq.Arg.Struct == nil && gq.Arg.Name != "" && gq.Arg.Name == gq.ConstantName {
+			gq.Arg.Name += "Arg"
+		}
+		if gq.Cmd == ":one" && gq.Ret.Struct == nil && gq.Ret.Name != "" && gq.Ret.Name == gq.Arg.Name {
+			gq.Ret.Name += "Result"
+		}
+
+		// The parameters of the method are in the order of the query's
+		// parameters; each statement of a script passes the ones it uses
+		args := gq.Arg.paramList()
+		for i, stmt := range query.Script {
+			gs := GoScriptStatement{
+				ConstantName: gq.ConstantName,
+				SQL:          stmt.SQL,
+				SQLiteSQL:    sqliteSQL(stmt.SQL, settings),
+				Last:         i == len(query.Script)-1,
+			}
+			if i > 0 {
+				gs.ConstantName = fmt.Sprintf("%sStep%d", gq.ConstantName, i+1)
+			}
+			var params []string
+			for _, number := range stmt.Params {
+				for j, p := range query.Params {
+					if p.Number == number {
+						params = append(params, args[j])
+					}
+				}
+			}
+			gs.Params = formatParams(params)
+			gq.Script = append(gq.Script, gs)
+		}
+
 		qs = append(qs, gq)
 	}
 	sort.Slice(qs, func(i, j int) bool { return qs[i].MethodName < qs[j].MethodName })
@@ -984,6 +1573,81 @@ import (
 )
 
 {{template "dbCode" . }}
+{{if .UsesJSONScan}}
+// jsonScan unmarshals a json or jsonb column into dest. A NULL leaves dest
+// unchanged.
+type jsonScan struct {
+	dest interface{}
+}
+
+func (s jsonScan) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(src, s.dest)
+	case string:
+		return json.Unmarshal([]byte(src), s.dest)
+	}
+	return fmt.Errorf("cannot unmarshal %T into %T", src, s.dest)
+}
+{{end}}
+{{if .UsesIntervalScan}}
+{{template "intervalScan" ""}}
+{{end}}
+{{if .HasScripts}}
+// script runs the statements of an :execscript query in a transaction, so that
+// they run on the same connection and a SET LOCAL applies to the statements
+// after it. Queries made with WithTx, or with a *sql.Conn, run them as they
+// are.
+func (q *Queries) script(ctx context.Context, fn func(DBTX) error) error {
+	db, ok := q.db.(*sql.DB)
+	if !ok {
+		return fn(q.db)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+{{end}}
+{{range .SessionSetters}}
+// {{.MethodName}} sets the {{.Name}} session variable until the end of the
+// transaction, for the queries that read it with current_setting. It must be
+// called on Queries made with WithTx, as the setting wouldn't outlast the
+// statement otherwise.
+func (q *Queries) {{.MethodName}}(ctx context.Context, value {{.Type}}) error {
+	if _, ok := q.db.(*sql.DB); ok {
+		return errors.New("{{.MethodName}} must be called in a transaction")
+	}
+	_, err := q.db.ExecContext(ctx, "SELECT set_config('{{.Name}}', $1, true)", value)
+	return err
+}
+{{end}}
+{{end}}
+
+{{define "intervalScan"}}
+// intervalScan scans a NOT NULL interval column into dest
+type intervalScan struct {
+	dest *time.Duration
+}
+
+func (s intervalScan) Scan(src interface{}) error {
+	var n {{.}}NullDuration
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	if !n.Valid {
+		return fmt.Errorf("cannot scan NULL into %T", s.dest)
+	}
+	*s.dest = n.Duration
+	return nil
+}
 {{end}}
 
 {{define "dbCode"}}
@@ -997,19 +1661,35 @@ type DBTX interface {
 func New(db DBTX) *Queries {
 	return &Queries{db: db}
 }
+{{if .SQLite}}
+// NewSQLite returns the queries for a SQLite database, which run the SQLite
+// versions of their SQL
+func NewSQLite(db DBTX) *Queries {
+	return &Queries{db: db, sqlite: true}
+}
 
-{{if .EmitPreparedQueries}}
-func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+func (q *Queries) dialect(postgres, sqlite string) string {
+	if q.sqlite {
+		return sqlite
+	}
+	return postgres
+}
+{{end}}
+
+{{if .EmitPreparedQueries}}
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
-	{{- if eq (len .GoQueries) 0 }}
+	{{- if not .HasPreparedQueries }}
 	_ = err
 	{{- end }}
 	{{- range .GoQueries }}
+	{{- if .Prepared}}
 	if q.{{.FieldName}}, err = db.PrepareContext(ctx, {{.ConstantName}}); err != nil {
 		return nil, fmt.Errorf("error preparing query {{.MethodName}}: %w", err)
 	}
 	{{- end}}
+	{{- end}}
 	return &q, nil
 }
 
@@ -1061,6 +1741,9 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 
 type Queries struct {
 	db DBTX
+	{{- if .SQLite}}
+	sqlite bool
+	{{- end}}
 
     {{- if .EmitPreparedQueries}}
 	tx         *sql.Tx
@@ -1073,6 +1756,9 @@ type Queries struct {
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
 		db: tx,
+		{{- if .SQLite}}
+		sqlite: q.sqlite,
+		{{- end}}
      	{{- if .EmitPreparedQueries}}
 		tx: tx,
 		{{- range .GoQueries}}
@@ -1083,6 +1769,539 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 }
 {{end}}
 
+{{define "hooksFile"}}// Code generated by sqlc. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
+)
+
+{{template "hooksCode" . }}
+{{end}}
+
+{{define "hooksCode"}}
+// QueryHook observes the queries run through HookedQueries, such as to record
+// their latency or to start a tracing span for each one.
+type QueryHook interface {
+	// BeforeQuery is called before the query runs. The returned context is
+	// used to run the query and is passed to AfterQuery.
+	BeforeQuery(ctx context.Context, name string) context.Context
+
+	// AfterQuery is called once the query has finished
+	AfterQuery(ctx context.Context, name string, duration time.Duration, err error)
+}
+
+// HookedQueries runs the queries of Queries, calling the hook around each one
+type HookedQueries struct {
+	q    *Queries
+	hook QueryHook
+}
+
+func NewHooked(q *Queries, hook QueryHook) *HookedQueries {
+	return &HookedQueries{q: q, hook: hook}
+}
+
+func (h *HookedQueries) WithTx(tx *sql.Tx) *HookedQueries {
+	return &HookedQueries{q: h.q.WithTx(tx), hook: h.hook}
+}
+
+type queryCall struct {
+	ctx   context.Context
+	hook  QueryHook
+	name  string
+	start time.Time
+}
+
+func (h *HookedQueries) before(ctx context.Context, name string) (context.Context, queryCall) {
+	ctx = h.hook.BeforeQuery(ctx, name)
+	return ctx, queryCall{ctx: ctx, hook: h.hook, name: name, start: time.Now()}
+}
+
+func (c queryCall) end(err error) {
+	c.hook.AfterQuery(c.ctx, c.name, time.Since(c.start), err)
+}
+
+{{if .EmitInterface}}
+var _ Querier = (*HookedQueries)(nil)
+{{end}}
+
+{{range .GoQueries}}
+{{if eq .Cmd ":one"}}
+func (h *HookedQueries) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ({{.Ret.Type}}, error) {
+	ctx, call := h.before(ctx, "{{.MethodName}}")
+	i, err := h.q.{{.MethodName}}(ctx, {{.CallArgs}})
+	call.end(err)
+	return i, err
+}
+{{end}}
+
+{{if eq .Cmd ":many"}}
+func (h *HookedQueries) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, error) {
+	ctx, call := h.before(ctx, "{{.MethodName}}")
+	items, err := h.q.{{.MethodName}}(ctx, {{.CallArgs}})
+	call.end(err)
+	return items, err
+}
+{{end}}
+
+{{if eq .Cmd ":paginated"}}
+func (h *HookedQueries) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, *{{.Page.Cursor}}, error) {
+	ctx, call := h.before(ctx, "{{.MethodName}}")
+	items, next, err := h.q.{{.MethodName}}(ctx, {{.CallArgs}})
+	call.end(err)
+	return items, next, err
+}
+{{end}}
+
+{{if or (eq .Cmd ":exec") (eq .Cmd ":execscript")}}
+func (h *HookedQueries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
+	ctx, call := h.before(ctx, "{{.MethodName}}")
+	err := h.q.{{.MethodName}}(ctx, {{.CallArgs}})
+	call.end(err)
+	return err
+}
+{{end}}
+
+{{if eq .Cmd ":execrows"}}
+func (h *HookedQueries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error) {
+	ctx, call := h.before(ctx, "{{.MethodName}}")
+	n, err := h.q.{{.MethodName}}(ctx, {{.CallArgs}})
+	call.end(err)
+	return n, err
+}
+{{end}}
+{{end}}
+{{end}}
+
+{{define "retryFile"}}// Code generated by sqlc. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
+)
+
+{{template "retryCode" . }}
+{{end}}
+
+{{define "retryCode"}}
+// RetryPolicy controls how RetryQueries runs queries
+type RetryPolicy struct {
+	// Timeout of each attempt. Attempts don't time out if zero.
+	Timeout time.Duration
+
+	// Number of attempts, including the first. Queries are only attempted
+	// once if zero.
+	MaxAttempts int
+
+	// Delay before the second attempt, doubled before each attempt after it
+	Backoff time.Duration
+
+	// Retryable reports whether a failed attempt should be retried. If nil,
+	// serialization failures and deadlocks are retried.
+	Retryable func(error) bool
+}
+
+// RetryQueries runs the queries of Queries with the timeout of its policy, and
+// runs them again when they fail with a retryable error
+type RetryQueries struct {
+	q      *Queries
+	policy RetryPolicy
+	inTx   bool
+}
+
+func NewRetry(q *Queries, policy RetryPolicy) *RetryQueries {
+	return &RetryQueries{q: q, policy: policy}
+}
+
+// WithTx runs the queries in a transaction. Once a statement fails, the
+// transaction is aborted, so queries are only attempted once. Use RunInTx to
+// retry the whole transaction instead.
+func (r *RetryQueries) WithTx(tx *sql.Tx) *RetryQueries {
+	return &RetryQueries{q: r.q.WithTx(tx), policy: r.policy, inTx: true}
+}
+
+// RunInTx runs fn in a transaction and commits it. The transaction is rolled
+// back and fn is run again in a new transaction when it fails with a
+// retryable error, as SERIALIZABLE transactions must be.
+func (r *RetryQueries) RunInTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(context.Context, *RetryQueries) error) error {
+	return r.run(ctx, func(ctx context.Context) error {
+		tx, err := db.BeginTx(ctx, opts)
+		if err != nil {
+			return err
+		}
+		if err := fn(ctx, r.WithTx(tx)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+func (r *RetryQueries) run(ctx context.Context, fn func(context.Context) error) error {
+	attempts := r.policy.MaxAttempts
+	if attempts < 1 || r.inTx {
+		attempts = 1
+	}
+	backoff := r.policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := r.attempt(ctx, fn)
+		if err == nil || attempt >= attempts || !r.retryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (r *RetryQueries) attempt(ctx context.Context, fn func(context.Context) error) error {
+	if r.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.policy.Timeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+func (r *RetryQueries) retryable(err error) bool {
+	if r.policy.Retryable != nil {
+		return r.policy.Retryable(err)
+	}
+	return IsSerializationFailure(err)
+}
+
+// IsSerializationFailure reports whether err is a PostgreSQL serialization
+// failure (40001) or deadlock (40P01). Both lib/pq and pgx errors report their
+// SQLSTATE code.
+func IsSerializationFailure(err error) bool {
+	var state interface{ SQLState() string }
+	if !errors.As(err, &state) {
+		return false
+	}
+	switch state.SQLState() {
+	case "40001", "40P01":
+		return true
+	}
+	return false
+}
+
+{{if .EmitInterface}}
+var _ Querier = (*RetryQueries)(nil)
+{{end}}
+
+{{range .GoQueries}}
+{{if eq .Cmd ":one"}}
+func (r *RetryQueries) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ({{.Ret.Type}}, error) {
+	var i {{.Ret.Type}}
+	err := r.run(ctx, func(ctx context.Context) error {
+		var err error
+		i, err = r.q.{{.MethodName}}(ctx, {{.CallArgs}})
+		return err
+	})
+	return i, err
+}
+{{end}}
+
+{{if eq .Cmd ":many"}}
+func (r *RetryQueries) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, error) {
+	var items []{{.Ret.Type}}
+	err := r.run(ctx, func(ctx context.Context) error {
+		var err error
+		items, err = r.q.{{.MethodName}}(ctx, {{.CallArgs}})
+		return err
+	})
+	return items, err
+}
+{{end}}
+
+{{if eq .Cmd ":paginated"}}
+func (r *RetryQueries) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, *{{.Page.Cursor}}, error) {
+	var items []{{.Ret.Type}}
+	var next *{{.Page.Cursor}}
+	err := r.run(ctx, func(ctx context.Context) error {
+		var err error
+		items, next, err = r.q.{{.MethodName}}(ctx, {{.CallArgs}})
+		return err
+	})
+	return items, next, err
+}
+{{end}}
+
+{{if or (eq .Cmd ":exec") (eq .Cmd ":execscript")}}
+func (r *RetryQueries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
+	return r.run(ctx, func(ctx context.Context) error {
+		return r.q.{{.MethodName}}(ctx, {{.CallArgs}})
+	})
+}
+{{end}}
+
+{{if eq .Cmd ":execrows"}}
+func (r *RetryQueries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error) {
+	var n int64
+	err := r.run(ctx, func(ctx context.Context) error {
+		var err error
+		n, err = r.q.{{.MethodName}}(ctx, {{.CallArgs}})
+		return err
+	})
+	return n, err
+}
+{{end}}
+{{end}}
+{{end}}
+
+{{define "mockFile"}}// Code generated by sqlc. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
+)
+
+{{template "mockCode" . }}
+{{end}}
+
+{{define "mockCode"}}
+// MockQuerier implements Querier for tests. Each method calls the function in
+// the matching field, which panics if the field is nil, and every call is
+// recorded.
+type MockQuerier struct {
+	{{- range .GoQueries}}
+	{{- if .Exported}}
+	{{- if eq .Cmd ":one"}}
+	{{.MethodName}}Func func(ctx context.Context, {{.ArgPair}}) ({{.Ret.Type}}, error)
+	{{- end}}
+	{{- if eq .Cmd ":many"}}
+	{{.MethodName}}Func func(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, error)
+	{{- end}}
+	{{- if eq .Cmd ":paginated"}}
+	{{.MethodName}}Func func(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, *{{.Page.Cursor}}, error)
+	{{- end}}
+	{{- if or (eq .Cmd ":exec") (eq .Cmd ":execscript")}}
+	{{.MethodName}}Func func(ctx context.Context, {{.Arg.Pair}}) error
+	{{- end}}
+	{{- if eq .Cmd ":execrows"}}
+	{{.MethodName}}Func func(ctx context.Context, {{.Arg.Pair}}) (int64, error)
+	{{- end}}
+	{{- end}}
+	{{- end}}
+
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+// A call to a method of MockQuerier, with the arguments following the context
+type MockCall struct {
+	Method string
+	Args   []interface{}
+}
+
+var _ Querier = (*MockQuerier)(nil)
+
+// Calls returns the calls made so far, in order
+func (m *MockQuerier) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall(nil), m.calls...)
+}
+
+func (m *MockQuerier) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+}
+
+func mockNotSet(method string) string {
+	return fmt.Sprintf("MockQuerier.%sFunc is not set", method)
+}
+
+{{range .GoQueries}}
+{{- if .Exported}}
+{{if eq .Cmd ":one"}}
+func (m *MockQuerier) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ({{.Ret.Type}}, error) {
+	m.record("{{.MethodName}}", {{.CallArgs}})
+	if m.{{.MethodName}}Func == nil {
+		panic(mockNotSet("{{.MethodName}}"))
+	}
+	return m.{{.MethodName}}Func(ctx, {{.CallArgs}})
+}
+{{end}}
+
+{{if eq .Cmd ":many"}}
+func (m *MockQuerier) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, error) {
+	m.record("{{.MethodName}}", {{.CallArgs}})
+	if m.{{.MethodName}}Func == nil {
+		panic(mockNotSet("{{.MethodName}}"))
+	}
+	return m.{{.MethodName}}Func(ctx, {{.CallArgs}})
+}
+{{end}}
+
+{{if eq .Cmd ":paginated"}}
+func (m *MockQuerier) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, *{{.Page.Cursor}}, error) {
+	m.record("{{.MethodName}}", {{.CallArgs}})
+	if m.{{.MethodName}}Func == nil {
+		panic(mockNotSet("{{.MethodName}}"))
+	}
+	return m.{{.MethodName}}Func(ctx, {{.CallArgs}})
+}
+{{end}}
+
+{{if or (eq .Cmd ":exec") (eq .Cmd ":execscript")}}
+func (m *MockQuerier) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
+	m.record("{{.MethodName}}", {{.CallArgs}})
+	if m.{{.MethodName}}Func == nil {
+		panic(mockNotSet("{{.MethodName}}"))
+	}
+	return m.{{.MethodName}}Func(ctx, {{.CallArgs}})
+}
+{{end}}
+
+{{if eq .Cmd ":execrows"}}
+func (m *MockQuerier) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error) {
+	m.record("{{.MethodName}}", {{.CallArgs}})
+	if m.{{.MethodName}}Func == nil {
+		panic(mockNotSet("{{.MethodName}}"))
+	}
+	return m.{{.MethodName}}Func(ctx, {{.CallArgs}})
+}
+{{end}}
+{{- end}}
+{{end}}
+{{end}}
+
+{{define "fixturesFile"}}// Code generated by sqlc. DO NOT EDIT.
+
+// Package {{.Package}} inserts rows of the tables in package {{.Models}} for tests.
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
+)
+
+{{range .Fixtures}}
+// {{.Name}} inserts a {{$.Models}}.{{.Struct.Name}}. The columns that were set
+// are inserted, along with the NOT NULL columns without a default. The others
+// get their default value.
+type {{.Name}} struct {
+	row {{$.Models}}.{{.Struct.Name}}
+	set map[string]bool
+}
+
+func New{{.Name}}() *{{.Name}} {
+	return &{{.Name}}{set: map[string]bool{
+		{{- range .Columns}}
+		{{- if .Required}}
+		"{{.Column}}": true,
+		{{- end}}
+		{{- end}}
+	}}
+}
+{{$fixture := .}}
+{{range .Columns}}
+func (f *{{$fixture.Name}}) With{{.Field.Name}}(v {{.Type}}) *{{$fixture.Name}} {
+	f.row.{{.Field.Name}} = v
+	f.set["{{.Column}}"] = true
+	return f
+}
+{{end}}
+
+// Insert the row, returning it as stored by the database
+func (f *{{.Name}}) Insert(ctx context.Context, conn {{$.Models}}.DBTX) ({{$.Models}}.{{.Struct.Name}}, error) {
+	var cols []string
+	var args []interface{}
+	{{- range .Columns}}
+	if f.set["{{.Column}}"] {
+		cols = append(cols, {{$.Q}}{{quoteIdent .Column}}{{$.Q}})
+		{{- if .Field.Interval}}
+		args = append(args, {{$.Models}}.NullDuration{Duration: f.row.{{.Field.Name}}, Valid: true})
+		{{- else if .IsArray}}
+		args = append(args, pq.Array(f.row.{{.Field.Name}}))
+		{{- else}}
+		args = append(args, f.row.{{.Field.Name}})
+		{{- end}}
+	}
+	{{- end}}
+	query := {{$.Q}}INSERT INTO {{.Table}}{{$.Q}}
+	if len(cols) == 0 {
+		query += " DEFAULT VALUES"
+	} else {
+		params := make([]string, len(cols))
+		for i := range params {
+			params[i] = fmt.Sprintf("$%d", i+1)
+		}
+		query += " (" + strings.Join(cols, ", ") + ") VALUES (" + strings.Join(params, ", ") + ")"
+	}
+	row := conn.QueryRowContext(ctx, query+{{$.Q}} RETURNING {{.Returning}}{{$.Q}}, args...)
+	var i {{$.Models}}.{{.Struct.Name}}
+	err := row.Scan({{.Scan}})
+	return i, err
+}
+{{end}}
+{{if .UsesIntervalScan}}
+{{template "intervalScan" (printf "%s." .Models)}}
+{{end}}
+{{end}}
+
+{{define "protobufFile"}}// Code generated by sqlc. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}{{.}}
+	{{end}}
+	{{end}}
+)
+
+{{range .Messages}}
+// {{.Name}}ToProto converts the model to its protobuf message
+func {{.Name}}ToProto(m {{.Name}}) *pb.{{.Name}} {
+	p := &pb.{{.Name}}{
+		{{- range .Fields}}{{if .To}}
+		{{.GoName}}: {{.To}},
+		{{- end}}{{end}}
+	}
+	{{- range .Fields}}{{if .ToStmt}}
+	{{.ToStmt}}
+	{{- end}}{{end}}
+	return p
+}
+
+// {{.Name}}FromProto converts the protobuf message to its model
+func {{.Name}}FromProto(p *pb.{{.Name}}) ({{.Name}}, error) {
+	m := {{.Name}}{
+		{{- range .Fields}}{{if .From}}
+		{{.Field.Name}}: {{.From}},
+		{{- end}}{{end}}
+	}
+	{{- if .FromErr}}
+	var err error
+	{{- end}}
+	{{- range .Fields}}{{if .FromStmt}}
+	{{.FromStmt}}
+	{{- end}}{{end}}
+	return m, nil
+}
+{{end}}
+{{end}}
+
 {{define "interfaceFile"}}// Code generated by sqlc. DO NOT EDIT.
 
 package {{.Package}}
@@ -1100,19 +2319,24 @@ import (
 {{define "interfaceCode"}}
 type Querier interface {
 	{{- range .GoQueries}}
+	{{- if .Exported}}
 	{{- if eq .Cmd ":one"}}
-	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Type}}, error)
+	{{.MethodName}}(ctx context.Context, {{.ArgPair}}) ({{.Ret.Type}}, error)
 	{{- end}}
 	{{- if eq .Cmd ":many"}}
-	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.Type}}, error)
+	{{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, error)
+	{{- end}}
+	{{- if eq .Cmd ":paginated"}}
+	{{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, *{{.Page.Cursor}}, error)
 	{{- end}}
-	{{- if eq .Cmd ":exec"}}
+	{{- if or (eq .Cmd ":exec") (eq .Cmd ":execscript")}}
 	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error
 	{{- end}}
 	{{- if eq .Cmd ":execrows"}}
 	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error)
 	{{- end}}
 	{{- end}}
+	{{- end}}
 }
 
 var _ Querier = (*Queries)(nil)
@@ -1130,6 +2354,108 @@ import (
 )
 
 {{template "modelsCode" . }}
+{{if .UsesNullDuration}}
+// NullDuration is an interval that may be NULL. Intervals are read in the
+// default postgres IntervalStyle, counting a month as 30 days and a year as
+// 365.25 days. They're written in microseconds, the precision of an interval.
+type NullDuration struct {
+	Duration time.Duration
+	Valid    bool // Valid is true if Duration is not NULL
+}
+
+func (n *NullDuration) Scan(src interface{}) error {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		n.Duration, n.Valid = 0, false
+		return nil
+	case []byte:
+		s = string(src)
+	case string:
+		s = src
+	default:
+		return fmt.Errorf("cannot scan %T into NullDuration", src)
+	}
+	d, err := parseInterval(s)
+	if err != nil {
+		return err
+	}
+	n.Duration, n.Valid = d, true
+	return nil
+}
+
+func (n NullDuration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return fmt.Sprintf("%d microseconds", int64(n.Duration/time.Microsecond)), nil
+}
+
+// parseInterval reads an interval such as "1 year 2 mons -3 days +04:05:06.5"
+func parseInterval(s string) (time.Duration, error) {
+	var d time.Duration
+	add := func(n int64, unit time.Duration) error {
+		v := time.Duration(n) * unit
+		if n != 0 && v/unit != time.Duration(n) || v > 0 && d > math.MaxInt64-v || v < 0 && d < math.MinInt64-v {
+			return fmt.Errorf("interval %q overflows time.Duration", s)
+		}
+		d += v
+		return nil
+	}
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			sign := int64(1)
+			if strings.HasPrefix(fields[i], "-") {
+				sign = -1
+			}
+			hms := strings.Split(strings.TrimLeft(fields[i], "+-"), ":")
+			if len(hms) != 3 {
+				return 0, fmt.Errorf("invalid interval %q", s)
+			}
+			sec := strings.SplitN(hms[2], ".", 2)
+			frac := "0"
+			if len(sec) == 2 {
+				frac = (sec[1] + "000000000")[:9]
+			}
+			units := []time.Duration{time.Hour, time.Minute, time.Second, time.Nanosecond}
+			for j, value := range []string{hms[0], hms[1], sec[0], frac} {
+				n, err := strconv.ParseInt(value, 10, 64)
+				if err != nil || n < 0 {
+					return 0, fmt.Errorf("invalid interval %q", s)
+				}
+				if err := add(sign*n, units[j]); err != nil {
+					return 0, err
+				}
+			}
+			continue
+		}
+		if i+1 == len(fields) {
+			return 0, fmt.Errorf("invalid interval %q", s)
+		}
+		n, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q", s)
+		}
+		var unit time.Duration
+		switch fields[i+1] {
+		case "year", "years":
+			unit = 8766 * time.Hour
+		case "mon", "mons":
+			unit = 720 * time.Hour
+		case "day", "days":
+			unit = 24 * time.Hour
+		default:
+			return 0, fmt.Errorf("invalid interval %q, expected the postgres IntervalStyle", s)
+		}
+		if err := add(n, unit); err != nil {
+			return 0, err
+		}
+		i++
+	}
+	return d, nil
+}
+{{end}}
 {{end}}
 
 {{define "modelsCode"}}
@@ -1147,20 +2473,78 @@ func (e *{{.Name}}) Scan(src interface{}) error {
 	*e = {{.Name}}(src.([]byte))
 	return nil
 }
+{{- if $.EmitEnumHelpers}}
+
+// All{{.Name}}Values returns the values of {{.Name}} in the order they're declared
+func All{{.Name}}Values() []{{.Name}} {
+	return []{{.Name}}{
+		{{- range .Constants}}
+		{{.Name}},
+		{{- end}}
+	}
+}
+
+// Valid reports whether e is one of the values of {{.Name}}
+func (e {{.Name}}) Valid() bool {
+	switch e {
+	case {{range $i, $c := .Constants}}{{if $i}}, {{end}}{{$c.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+
+// Parse{{.Name}} converts s to a {{.Name}}, failing if it isn't one of its values
+func Parse{{.Name}}(s string) ({{.Name}}, error) {
+	e := {{.Name}}(s)
+	if !e.Valid() {
+		return "", fmt.Errorf("invalid {{.Name}} value: %q", s)
+	}
+	return e, nil
+}
+{{- end}}
 {{end}}
 
 {{range .Structs}}
 {{if .Comment}}{{comment .Comment}}{{end}}
+{{- if and .Comment .Security}}
+//{{end}}
+{{- if .Security}}
+{{comment .Security}}{{end}}
 type {{.Name}} struct { {{- range .Fields}}
   {{- if .Comment}}
   // {{.Comment}}{{else}}
   {{- end}}
-  {{.Name}} {{.Type}} {{if $.EmitJSONTags}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{.Name}} {{.Type}} {{with .StructTag $.EmitJSONTags $.SensitiveTag}}{{$.Q}}{{.}}{{$.Q}}{{end}}
   {{- end}}
 }
+{{template "redactedString" .}}
+{{- if and $.EmitModelHelpers .HasModelHelpers}}
+
+// TableName returns the table of {{.Name}}
+func ({{.Name}}) TableName() string {
+	return {{.TableName}}
+}
+
+// Columns returns the columns of {{.Name}}'s table, in the order of its fields
+func ({{.Name}}) Columns() []string {
+	return []string{ {{- .ColumnNames -}} }
+}
+
+// ScanRow scans a row of the columns returned by Columns into s
+func (s *{{.Name}}) ScanRow(row interface{ Scan(...interface{}) error }) error {
+	return row.Scan({{.ScanTargets}})
+}
+{{- end}}
 {{end}}
 {{end}}
 
+{{define "redactedString"}}{{if .HasSensitive}}
+// String formats the {{.Name}} without its sensitive fields
+func (s {{.Name}}) String() string {
+	return fmt.Sprintf({{.StringFormat}}{{range .Fields}}{{if not .Sensitive}}, s.{{.Name}}{{end}}{{end}})
+}
+{{end}}{{end}}
+
 {{define "queryFile"}}// Code generated by sqlc. DO NOT EDIT.
 // source: {{.SourceName}}
 
@@ -1176,35 +2560,130 @@ import (
 {{template "queryCode" . }}
 {{end}}
 
+{{define "queryTimeout"}}{{if .Timeout}}
+	ctx, cancel := context.WithTimeout(ctx, {{.Timeout}})
+	defer cancel()
+{{- end}}{{end}}
+
 {{define "queryCode"}}
 {{range .GoQueries}}
 {{if $.OutputQuery .SourceName}}
-const {{.ConstantName}} = {{$.Q}}-- name: {{.MethodName}} {{.Cmd}}
+const {{.ConstantName}} = {{$.Q}}-- name: {{.Name}} {{.Cmd}}
 {{.SQL}}
 {{$.Q}}
+{{if .SQLiteSQL}}
+const {{.ConstantName}}SQLite = {{$.Q}}-- name: {{.Name}} {{.Cmd}}
+{{.SQLiteSQL}}
+{{$.Q}}
+{{end}}
+{{$query := .}}
+{{- range $i, $stmt := .Script}}{{if $i}}
+const {{$stmt.ConstantName}} = {{$.Q}}-- name: {{$query.Name}} {{$query.Cmd}}
+{{$stmt.SQL}}
+{{$.Q}}
+{{if $stmt.SQLiteSQL}}
+const {{$stmt.ConstantName}}SQLite = {{$.Q}}-- name: {{$query.Name}} {{$query.Cmd}}
+{{$stmt.SQLiteSQL}}
+{{$.Q}}
+{{end}}
+{{end}}{{end}}
 
 {{if .Arg.EmitStruct}}
 type {{.Arg.Type}} struct { {{- range .Arg.Struct.Fields}}
-  {{.Name}} {{.Type}} {{if $.EmitJSONTags}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{.Name}} {{.Type}} {{with .StructTag $.EmitJSONTags $.SensitiveTag}}{{$.Q}}{{.}}{{$.Q}}{{end}}
+  {{- end}}
+}
+{{template "redactedString" .Arg.Struct}}
+{{end}}
+
+{{range .JSONStructs}}
+type {{.Name}} struct { {{- range .Fields}}
+  {{.Name}} {{.Type}} {{$.Q}}{{.Tag}}{{$.Q}}
   {{- end}}
 }
 {{end}}
 
 {{if .Ret.EmitStruct}}
 type {{.Ret.Type}} struct { {{- range .Ret.Struct.Fields}}
-  {{.Name}} {{.Type}} {{if $.EmitJSONTags}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{.Name}} {{.Type}} {{with .StructTag $.EmitJSONTags $.SensitiveTag}}{{$.Q}}{{.}}{{$.Q}}{{end}}
   {{- end}}
 }
+{{template "redactedString" .Ret.Struct}}
+{{end}}
+
+{{if .Sort}}
+type {{.Sort.Name}} string
+
+const (
+	{{- range .Sort.Constants}}
+	{{.Name}} {{.Type}} = "{{.Value}}"
+	{{- end}}
+)
+
+func (s {{.Sort.Name}}) Valid() bool {
+	switch s {
+	case {{range $i, $c := .Sort.Constants}}{{if $i}}, {{end}}{{$c.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+{{end}}
+
+{{if .Page}}
+const {{.Page.NextConstant}} = {{$.Q}}-- name: {{.Name}} {{.Cmd}}
+{{.Page.NextSQL}}
+{{$.Q}}
+{{if .Page.NextSQLiteSQL}}
+const {{.Page.NextConstant}}SQLite = {{$.Q}}-- name: {{.Name}} {{.Cmd}}
+{{.Page.NextSQLiteSQL}}
+{{$.Q}}
+{{end}}
+
+// {{.Page.Cursor}} holds the ORDER BY columns of the last row of a page of
+// {{.MethodName}}, where the next page starts
+type {{.Page.Cursor}} struct { {{- range .Page.Fields}}
+  {{.Name}} {{.Type}} {{$.Q}}{{.Tag}}{{$.Q}}
+  {{- end}}
+}
+
+// Encode returns the cursor as an opaque string, such as for an API response
+func (c {{.Page.Cursor}}) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Decode{{.Page.Cursor}} reads a cursor returned by {{.Page.Cursor}}.Encode
+func Decode{{.Page.Cursor}}(s string) (*{{.Page.Cursor}}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var c {{.Page.Cursor}}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return &c, nil
+}
 {{end}}
 
 {{if eq .Cmd ":one"}}
 {{range .Comments}}//{{.}}
 {{end -}}
-func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Type}}, error) {
+func (q *Queries) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ({{.Ret.Type}}, error) {
+	{{- template "queryTimeout" .}}
+	{{- if .Sort}}
+	if !orderBy.Valid() {
+		var {{.Ret.Name}} {{.Ret.Type}}
+		return {{.Ret.Name}}, fmt.Errorf("invalid sort order: %q", orderBy)
+	}
+	{{- end}}
   	{{- if $.EmitPreparedQueries}}
-	row := q.queryRow(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+	row := q.queryRow(ctx, q.{{.FieldName}}, {{.Query}}, {{.Arg.Params}})
 	{{- else}}
-	row := q.db.QueryRowContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
+	row := q.db.QueryRowContext(ctx, {{.Query}}, {{.Arg.Params}})
 	{{- end}}
 	var {{.Ret.Name}} {{.Ret.Type}}
 	err := row.Scan({{.Ret.Scan}})
@@ -1215,11 +2694,17 @@ func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Ty
 {{if eq .Cmd ":many"}}
 {{range .Comments}}//{{.}}
 {{end -}}
-func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.Type}}, error) {
+func (q *Queries) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, error) {
+	{{- template "queryTimeout" .}}
+	{{- if .Sort}}
+	if !orderBy.Valid() {
+		return nil, fmt.Errorf("invalid sort order: %q", orderBy)
+	}
+	{{- end}}
   	{{- if $.EmitPreparedQueries}}
-	rows, err := q.query(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+	rows, err := q.query(ctx, q.{{.FieldName}}, {{.Query}}, {{.Arg.Params}})
   	{{- else}}
-	rows, err := q.db.QueryContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
+	rows, err := q.db.QueryContext(ctx, {{.Query}}, {{.Arg.Params}})
   	{{- end}}
 	if err != nil {
 		return nil, err
@@ -1243,14 +2728,65 @@ func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.
 }
 {{end}}
 
+{{if eq .Cmd ":paginated"}}
+{{range .Comments}}//{{.}}
+{{end -}}
+func (q *Queries) {{.MethodName}}(ctx context.Context, {{.ArgPair}}) ([]{{.Ret.Type}}, *{{.Page.Cursor}}, error) {
+	{{- template "queryTimeout" .}}
+	var rows *sql.Rows
+	var err error
+	if after == nil {
+		{{- if $.EmitPreparedQueries}}
+		rows, err = q.query(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.PageParams false}})
+		{{- else}}
+		rows, err = q.db.QueryContext(ctx, {{.Ref}}, {{.PageParams false}})
+		{{- end}}
+	} else {
+		{{- if $.EmitPreparedQueries}}
+		rows, err = q.query(ctx, q.{{.FieldName}}, {{.Page.NextConstant}}, {{.PageParams true}})
+		{{- else}}
+		rows, err = q.db.QueryContext(ctx, {{.Page.NextRef}}, {{.PageParams true}})
+		{{- end}}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	var items []{{.Ret.Type}}
+	for rows.Next() {
+		var {{.Ret.Name}} {{.Ret.Type}}
+		if err := rows.Scan({{.Ret.Scan}}); err != nil {
+			return nil, nil, err
+		}
+		items = append(items, {{.Ret.Name}})
+	}
+	if err := rows.Close(); err != nil {
+		return nil, nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	var next *{{.Page.Cursor}}
+	if limit > 0 && len(items) == int(limit) {
+		last := items[len(items)-1]
+		next = &{{.Page.Cursor}}{ {{- range .Page.Fields}}
+			{{.Name}}: {{.Value}},
+			{{- end}}
+		}
+	}
+	return items, next, nil
+}
+{{end}}
+
 {{if eq .Cmd ":exec"}}
 {{range .Comments}}//{{.}}
 {{end -}}
 func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
+	{{- template "queryTimeout" .}}
   	{{- if $.EmitPreparedQueries}}
 	_, err := q.exec(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
   	{{- else}}
-	_, err := q.db.ExecContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
+	_, err := q.db.ExecContext(ctx, {{.Ref}}, {{.Arg.Params}})
   	{{- end}}
 	return err
 }
@@ -1260,10 +2796,11 @@ func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
 {{range .Comments}}//{{.}}
 {{end -}}
 func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error) {
+	{{- template "queryTimeout" .}}
   	{{- if $.EmitPreparedQueries}}
 	result, err := q.exec(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
   	{{- else}}
-	result, err := q.db.ExecContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
+	result, err := q.db.ExecContext(ctx, {{.Ref}}, {{.Arg.Params}})
   	{{- end}}
 	if err != nil {
 		return 0, err
@@ -1271,6 +2808,26 @@ func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, er
 	return result.RowsAffected()
 }
 {{end}}
+
+{{if eq .Cmd ":execscript"}}
+{{range .Comments}}//{{.}}
+{{end -}}
+func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
+	{{- template "queryTimeout" .}}
+	return q.script(ctx, func(db DBTX) error {
+		{{- range .Script}}
+		{{- if .Last}}
+		_, err := db.ExecContext(ctx, {{.Ref}}, {{.Params}})
+		return err
+		{{- else}}
+		if _, err := db.ExecContext(ctx, {{.Ref}}, {{.Params}}); err != nil {
+			return err
+		}
+		{{- end}}
+		{{- end}}
+	})
+}
+{{end}}
 {{end}}
 {{end}}
 {{end}}
@@ -1289,13 +2846,123 @@ type tmplCtx struct {
 
 	EmitJSONTags        bool
 	EmitPreparedQueries bool
+
+	// The queries are also run with SQLite
+	SQLite bool
 	EmitInterface       bool
+	EmitHooks           bool
+	EmitMock            bool
+	EmitEnumHelpers     bool
+	EmitModelHelpers    bool
+
+	// The tag key set to "-" on sensitive fields
+	SensitiveTag string
+
+	// The models declare NullDuration for interval columns
+	UsesNullDuration bool
+
+	// The Set methods of the session variables read by queries
+	SessionSetters []GoSessionSetter
 }
 
 func (t *tmplCtx) OutputQuery(sourceName string) bool {
 	return t.SourceName == sourceName
 }
 
+func (t *tmplCtx) UsesJSONScan() bool {
+	return usesJSONScan(t.GoQueries)
+}
+
+func usesJSONScan(gq []GoQuery) bool {
+	for _, q := range gq {
+		if q.Ret.JSON {
+			return true
+		}
+		if q.Ret.Struct != nil {
+			for _, f := range q.Ret.Struct.Fields {
+				if f.JSON {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (t *tmplCtx) UsesIntervalScan() bool {
+	return usesIntervalScan(t.GoQueries) || t.EmitModelHelpers && modelHelpersScanIntervals(t.Structs)
+}
+
+func usesIntervalScan(gq []GoQuery) bool {
+	for _, q := range gq {
+		if !q.hasRetType() {
+			continue
+		}
+		if q.Ret.Interval {
+			return true
+		}
+		if q.Ret.Struct != nil {
+			for _, f := range q.Ret.Struct.Fields {
+				if f.Interval {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// The models declare NullDuration if a field has the type, or if a
+// time.Duration is passed or scanned as an interval
+func usesNullDuration(r Generateable, settings config.CombinedSettings) bool {
+	if UsesType(r, "NullDuration", settings) {
+		return true
+	}
+	if settings.Go.EmitModelHelpers && modelHelpersScanIntervals(r.Structs(settings)) {
+		return true
+	}
+	values := func(v GoQueryValue) []GoField {
+		if v.Struct != nil {
+			return v.Struct.Fields
+		}
+		return []GoField{{Type: v.Typ, Interval: v.Interval}}
+	}
+	for _, q := range r.GoQueries(settings) {
+		var fields []GoField
+		fields = append(fields, values(q.Arg)...)
+		fields = append(fields, values(q.Ret)...)
+		if q.Page != nil {
+			for _, f := range q.Page.Fields {
+				fields = append(fields, f.GoField)
+			}
+		}
+		for _, f := range fields {
+			if f.Interval || strings.TrimPrefix(f.Type, "[]") == "NullDuration" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *tmplCtx) HasScripts() bool {
+	for _, q := range t.GoQueries {
+		if q.Script != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *tmplCtx) HasPreparedQueries() bool {
+	for _, q := range t.GoQueries {
+		if q.Prepared() {
+			return true
+		}
+	}
+	return false
+}
+
 func LowerTitle(s string) string {
 	a := []rune(s)
 	a[0] = unicode.ToLower(a[0])
@@ -1307,10 +2974,23 @@ func DoubleSlashComment(s string) string {
 }
 
 func Generate(r Generateable, settings config.CombinedSettings) (map[string]string, error) {
+	if err := validateEnumSettings(settings); err != nil {
+		return nil, err
+	}
+	if err := validateSensitiveSettings(settings); err != nil {
+		return nil, err
+	}
+	if err := validateModelHelpers(r, settings); err != nil {
+		return nil, err
+	}
+	if err := validateSessionSetters(r, settings); err != nil {
+		return nil, err
+	}
 	funcMap := template.FuncMap{
 		"lowerTitle": LowerTitle,
 		"comment":    DoubleSlashComment,
 		"imports":    Imports(r, settings),
+		"quoteIdent": quoteIdent,
 	}
 
 	tmpl := template.Must(template.New("table").Funcs(funcMap).Parse(templateSet))
@@ -1321,11 +3001,19 @@ func Generate(r Generateable, settings config.CombinedSettings) (map[string]stri
 		EmitInterface:       golang.EmitInterface,
 		EmitJSONTags:        golang.EmitJSONTags,
 		EmitPreparedQueries: golang.EmitPreparedQueries,
+		SQLite:              checksSQLite(settings),
+		EmitHooks:           golang.EmitHooks,
+		EmitMock:            golang.EmitMock,
+		EmitEnumHelpers:     golang.EmitEnumHelpers,
+		EmitModelHelpers:    golang.EmitModelHelpers,
+		SensitiveTag:        sensitiveTag(settings),
 		Q:                   "`",
 		Package:             golang.Package,
 		GoQueries:           r.GoQueries(settings),
 		Enums:               r.Enums(settings),
 		Structs:             r.Structs(settings),
+		UsesNullDuration:    usesNullDuration(r, settings),
+		SessionSetters:      sessionSetters(r.GoQueries(settings), settings),
 	}
 
 	output := map[string]string{}
@@ -1363,6 +3051,25 @@ func Generate(r Generateable, settings config.CombinedSettings) (map[string]stri
 		}
 	}
 
+	if golang.EmitMock {
+		if !golang.EmitInterface {
+			return nil, fmt.Errorf("emit_mock requires emit_interface")
+		}
+		if err := execute("querier_mock.go", "mockFile"); err != nil {
+			return nil, err
+		}
+	}
+	if golang.EmitHooks {
+		if err := execute("hooks.go", "hooksFile"); err != nil {
+			return nil, err
+		}
+	}
+	if golang.EmitRetries {
+		if err := execute("retry.go", "retryFile"); err != nil {
+			return nil, err
+		}
+	}
+
 	files := map[string]struct{}{}
 	for _, gq := range r.GoQueries(settings) {
 		files[gq.SourceName] = struct{}{}
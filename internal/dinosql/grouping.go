@@ -0,0 +1,73 @@
+package dinosql
+
+import (
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// Rows produced by ROLLUP, CUBE and GROUPING SETS aggregate over some of the
+// grouped columns. In those super-aggregate rows, the columns that are not
+// part of the current grouping set are NULL.
+//
+// https://www.postgresql.org/docs/current/queries-table-expressions.html#QUERIES-GROUPING-SETS
+func nullableGroupingColumns(groupClause nodes.List) map[string]struct{} {
+	nullable := map[string]struct{}{}
+	for _, item := range groupClause.Items {
+		set, ok := item.(nodes.GroupingSet)
+		if !ok {
+			continue
+		}
+		markGroupingSet(nullable, set)
+	}
+	return nullable
+}
+
+func markGroupingSet(nullable map[string]struct{}, set nodes.GroupingSet) {
+	switch set.Kind {
+
+	case nodes.GROUPING_SET_ROLLUP, nodes.GROUPING_SET_CUBE:
+		for _, name := range groupingColumnNames(set.Content) {
+			nullable[name] = struct{}{}
+		}
+
+	case nodes.GROUPING_SET_SETS:
+		// A column is only guaranteed to be present if it is part of every
+		// grouping set
+		counts := map[string]int{}
+		for _, item := range set.Content.Items {
+			if nested, ok := item.(nodes.GroupingSet); ok {
+				markGroupingSet(nullable, nested)
+			}
+			for _, name := range groupingColumnNames(item) {
+				counts[name] += 1
+			}
+		}
+		for name, count := range counts {
+			if count < len(set.Content.Items) {
+				nullable[name] = struct{}{}
+			}
+		}
+
+	}
+}
+
+func groupingColumnNames(root nodes.Node) []string {
+	refs := search(root, func(node nodes.Node) bool {
+		_, ok := node.(nodes.ColumnRef)
+		return ok
+	})
+	seen := map[string]struct{}{}
+	var names []string
+	for _, item := range refs.Items {
+		fields := stringSlice(item.(nodes.ColumnRef).Fields)
+		if len(fields) == 0 {
+			continue
+		}
+		name := fields[len(fields)-1]
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,275 @@
+package dinosql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// A named piece of SQL shared between queries. Fragments are declared in any
+// queries file and run until the next fragment or query:
+//
+//	-- fragment: visible_books
+//	deleted_at IS NULL AND published
+//
+// Queries splice a fragment in place of an include directive, before the
+// query is parsed:
+//
+//	-- name: ListBooks :many
+//	SELECT * FROM books
+//	WHERE
+//	  -- include: visible_books
+//	ORDER BY title;
+type fragment struct {
+	Name     string
+	SQL      string
+	Filename string
+	Source   string
+	Location int
+}
+
+type queryFile struct {
+	Filename string
+	Source   string
+}
+
+const (
+	fragmentPrefix = "-- fragment:"
+	includePrefix  = "-- include:"
+)
+
+type sourceLine struct {
+	Text  string
+	Start int
+}
+
+func sourceLines(source string) []sourceLine {
+	var lines []sourceLine
+	start := 0
+	for _, text := range strings.SplitAfter(source, "\n") {
+		lines = append(lines, sourceLine{Text: strings.TrimRight(text, "\r\n"), Start: start})
+		start += len(text)
+	}
+	return lines
+}
+
+// Directives are comments, which lineno skips over, so errors about them are
+// reported at the start of the directive itself.
+func addDirectiveErr(merr *ParserErr, filename, source string, loc int, err error) {
+	line := strings.Count(source[:loc], "\n") + 1
+	column := loc - strings.LastIndex(source[:loc], "\n")
+	merr.Errs = append(merr.Errs, FileErr{filename, line, column, err})
+}
+
+func directive(line, prefix string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(trimmed, prefix)), true
+}
+
+// Remove fragment declarations from the file, replacing them with whitespace
+// so that the locations of the remaining statements don't change.
+func extractFragments(filename, source string, merr *ParserErr) (string, []fragment) {
+	var frags []fragment
+	out := []byte(source)
+	lines := sourceLines(source)
+	for i := 0; i < len(lines); i++ {
+		name, ok := directive(lines[i].Text, fragmentPrefix)
+		if !ok {
+			continue
+		}
+		start := lines[i].Start + strings.Index(lines[i].Text, fragmentPrefix)
+		end := len(source)
+		j := i + 1
+		for ; j < len(lines); j++ {
+			if _, ok := directive(lines[j].Text, fragmentPrefix); ok {
+				end = lines[j].Start
+				break
+			}
+			if strings.HasPrefix(strings.TrimSpace(lines[j].Text), "-- name:") {
+				end = lines[j].Start
+				break
+			}
+		}
+		bodyStart := lines[i].Start + len(lines[i].Text)
+		body := source[bodyStart:end]
+		trimmed := strings.TrimSpace(body)
+		loc := bodyStart + strings.Index(body, trimmed)
+		switch {
+		case name == "":
+			addDirectiveErr(merr, filename, source, start, fmt.Errorf("fragment is missing a name"))
+		case trimmed == "":
+			addDirectiveErr(merr, filename, source, start, fmt.Errorf("fragment %q is empty", name))
+		case strings.Contains(trimmed, includePrefix):
+			addDirectiveErr(merr, filename, source, start, fmt.Errorf("fragment %q includes another fragment", name))
+		default:
+			frags = append(frags, fragment{
+				Name:     name,
+				SQL:      trimmed,
+				Filename: filename,
+				Source:   source,
+				Location: loc,
+			})
+		}
+		for k := start; k < end; k++ {
+			if out[k] != '\n' {
+				out[k] = ' '
+			}
+		}
+		i = j - 1
+	}
+	return string(out), frags
+}
+
+// Collect the fragments declared across all queries files
+func collectFragments(files []queryFile, merr *ParserErr) ([]queryFile, map[string]fragment) {
+	all := map[string]fragment{}
+	var out []queryFile
+	for _, f := range files {
+		source, frags := extractFragments(f.Filename, f.Source, merr)
+		for _, frag := range frags {
+			if _, exists := all[frag.Name]; exists {
+				merr.Add(frag.Filename, frag.Source, frag.Location, fmt.Errorf("duplicate fragment name: %s", frag.Name))
+				continue
+			}
+			all[frag.Name] = frag
+		}
+		out = append(out, queryFile{Filename: f.Filename, Source: source})
+	}
+	return out, all
+}
+
+// A fragment or constant spliced into a queries file
+type splice struct {
+	Start   int // Start of the spliced SQL in the expanded source
+	End     int
+	OrigLen int // Length of the text it replaced
+
+	// Locations inside a fragment map to the file that declares it. Constants
+	// have no source file, so locations inside them map to where they're used.
+	Fragment *fragment
+}
+
+// Maps locations in an expanded queries file back to the file, or to the
+// fragment, they came from. Constants are expanded after fragments, so their
+// map points at the map of the fragment expansion.
+type sourceMap struct {
+	Filename string
+	Source   string
+	Splices  []splice
+	Parent   *sourceMap
+}
+
+func (m sourceMap) position(loc int) (string, string, int) {
+	delta := 0
+	for _, s := range m.Splices {
+		if loc < s.Start {
+			break
+		}
+		if loc < s.End {
+			if s.Fragment != nil {
+				return s.Fragment.Filename, s.Fragment.Source, s.Fragment.Location + loc - s.Start
+			}
+			loc = s.Start
+			break
+		}
+		delta += (s.End - s.Start) - s.OrigLen
+	}
+	if m.Parent != nil {
+		return m.Parent.position(loc - delta)
+	}
+	return m.Filename, m.Source, loc - delta
+}
+
+// The original position of a location in the expanded source
+func (m sourceMap) fileErr(loc int) FileErr {
+	filename, source, pos := m.position(loc)
+	line, column := lineno(source, pos)
+	return FileErr{Filename: filename, Line: line, Column: column}
+}
+
+// Record an error found in the expanded source against its original location
+func (m sourceMap) add(merr *ParserErr, loc int, err error) {
+	if lerr, ok := err.(core.Error); ok && lerr.Location != 0 {
+		loc = lerr.Location
+		filename, source, pos := m.position(loc)
+		lerr.Location = pos
+		merr.Add(filename, source, pos, lerr)
+		return
+	}
+	if loc == 0 {
+		merr.Add(m.Filename, m.Source, 0, err)
+		return
+	}
+	filename, source, pos := m.position(loc)
+	merr.Add(filename, source, pos, err)
+}
+
+// Replace each include directive with the SQL of the named fragment
+func expandIncludes(f queryFile, frags map[string]fragment, merr *ParserErr) (string, sourceMap, bool) {
+	m := sourceMap{Filename: f.Filename, Source: f.Source}
+	ok := true
+	var b strings.Builder
+	prev := 0
+	for _, line := range sourceLines(f.Source) {
+		name, isInclude := directive(line.Text, includePrefix)
+		if !isInclude {
+			continue
+		}
+		start := line.Start + strings.Index(line.Text, includePrefix)
+		frag, found := frags[name]
+		if !found {
+			addDirectiveErr(merr, f.Filename, f.Source, start, fmt.Errorf("fragment %q does not exist", name))
+			ok = false
+			continue
+		}
+		end := line.Start + len(line.Text)
+		b.WriteString(f.Source[prev:start])
+		m.Splices = append(m.Splices, splice{
+			Start:    b.Len(),
+			End:      b.Len() + len(frag.SQL),
+			OrigLen:  end - start,
+			Fragment: &frag,
+		})
+		b.WriteString(frag.SQL)
+		prev = end
+	}
+	b.WriteString(f.Source[prev:])
+	return b.String(), m, ok
+}
+
+var constPattern = regexp.MustCompile(`sqlc\.const\(\s*([A-Za-z_][A-Za-z0-9_]*)\s*\)`)
+
+// Replace each sqlc.const(name) reference with the SQL the configuration
+// defines for that constant
+func expandConstants(source string, consts map[string]string, parent sourceMap, merr *ParserErr) (string, sourceMap, bool) {
+	m := sourceMap{Filename: parent.Filename, Source: parent.Source, Parent: &parent}
+	ok := true
+	var b strings.Builder
+	prev := 0
+	for _, match := range constPattern.FindAllStringSubmatchIndex(source, -1) {
+		start, end := match[0], match[1]
+		name := source[match[2]:match[3]]
+		value, found := consts[name]
+		if !found {
+			filename, orig, pos := parent.position(start)
+			merr.Add(filename, orig, pos, fmt.Errorf("constant %q does not exist", name))
+			ok = false
+			continue
+		}
+		b.WriteString(source[prev:start])
+		m.Splices = append(m.Splices, splice{
+			Start:   b.Len(),
+			End:     b.Len() + len(value),
+			OrigLen: end - start,
+		})
+		b.WriteString(value)
+		prev = end
+	}
+	b.WriteString(source[prev:])
+	return b.String(), m, ok
+}
@@ -0,0 +1,46 @@
+package dinosql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSourceMapPosition(t *testing.T) {
+	frags := `-- fragment: visible
+deleted_at IS NULL
+`
+	query := `SELECT * FROM books
+WHERE
+  -- include: visible
+  AND sqlc.const(tenant) AND title = $2;
+`
+	merr := NewParserErr()
+	files, found := collectFragments([]queryFile{
+		{Filename: "fragments.sql", Source: frags},
+		{Filename: "query.sql", Source: query},
+	}, merr)
+	source, smap, ok := expandIncludes(files[1], found, merr)
+	if !ok {
+		t.Fatal(merr.Errs)
+	}
+	source, smap, ok = expandConstants(source, map[string]string{"tenant": "tenant_id = $1"}, smap, merr)
+	if !ok {
+		t.Fatal(merr.Errs)
+	}
+
+	for _, test := range []struct {
+		text     string
+		filename string
+		loc      int
+	}{
+		{"SELECT", "query.sql", 0},
+		{"IS NULL", "fragments.sql", strings.Index(frags, "IS NULL")},
+		{"$1", "query.sql", strings.Index(query, "sqlc.const")},
+		{"title", "query.sql", strings.Index(query, "title")},
+	} {
+		filename, _, loc := smap.position(strings.Index(source, test.text))
+		if filename != test.filename || loc != test.loc {
+			t.Errorf("%s: expected %s:%d, got %s:%d", test.text, test.filename, test.loc, filename, loc)
+		}
+	}
+}
@@ -184,6 +184,7 @@ type KtQuery struct {
 	SourceName   string
 	Ret          KtQueryValue
 	Arg          KtParams
+	Sorted       bool
 }
 
 type KtGenerateable interface {
@@ -548,8 +549,8 @@ func (r Result) ktInnerType(col core.Column, settings config.CombinedSettings) (
 	case "bool", "pg_catalog.bool":
 		return "Boolean", false
 
-	case "jsonb":
-		// TODO: support json and byte types
+	case "json", "jsonb":
+		// TODO: support byte types
 		return "String", false
 
 	case "bytea", "blob", "pg_catalog.bytea":
@@ -698,6 +699,7 @@ func (r Result) KtQueries(settings config.CombinedSettings) []KtQuery {
 			SourceName:   query.Filename,
 			SQL:          jdbcSQL(query.SQL),
 			Comments:     query.Comments,
+			Sorted:       query.Sort != nil,
 		}
 
 		var cols []goColumn
@@ -996,6 +998,12 @@ func KtGenerate(r KtGenerateable, settings config.CombinedSettings) (map[string]
 	sqlFile := template.Must(template.New("table").Funcs(funcMap).Parse(ktSqlTmpl))
 	ifaceFile := template.Must(template.New("table").Funcs(funcMap).Parse(ktIfaceTmpl))
 
+	for _, q := range r.KtQueries(settings) {
+		if q.Sorted {
+			return nil, fmt.Errorf("query %q specifies a sort annotation, which is not supported for Kotlin", q.ClassName)
+		}
+	}
+
 	pkg := settings.Package
 	tctx := ktTmplCtx{
 		Settings:      settings.Global,
@@ -0,0 +1,55 @@
+package dinosql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const methodPrefix = "-- method:"
+
+// Read the Go method name given by a method annotation. Methods are named after
+// the query by default; a lower case name makes the method unexported:
+//
+//	-- name: ListStaleSessions :many
+//	-- method: listStaleSessions
+//	SELECT * FROM sessions WHERE expires_at < now();
+func parseMethod(t string) (string, error) {
+	for _, line := range strings.Split(t, "\n") {
+		if !strings.HasPrefix(line, methodPrefix) {
+			continue
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(line, methodPrefix))
+		if err := validateMethodName(name); err != nil {
+			return "", err
+		}
+		return name, nil
+	}
+	return "", nil
+}
+
+func validateMethodName(name string) error {
+	if name == "" || name == "_" {
+		return fmt.Errorf("invalid method name: %q", name)
+	}
+	for i, c := range name {
+		if !(unicode.IsLetter(c) || c == '_' || (i > 0 && unicode.IsDigit(c))) {
+			return fmt.Errorf("invalid method name: %q", name)
+		}
+	}
+	return nil
+}
+
+// The name of the method generated for the query
+func (q Query) MethodName() string {
+	if q.Method != "" {
+		return q.Method
+	}
+	return q.Name
+}
+
+func isExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
@@ -2,7 +2,11 @@ package dinosql
 
 import (
 	"bufio"
+	"fmt"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 // Remove all lines after a rollback comment.
@@ -27,3 +31,42 @@ func RemoveRollbackStatements(contents string) string {
 	}
 	return strings.Join(lines, "\n")
 }
+
+// The leading digits of a migration file's name, its version with goose,
+// golang-migrate and tern, such as 20200102150405 in
+// 20200102150405_add_users.sql
+func migrationVersion(filename string) (uint64, bool) {
+	base := filepath.Base(filename)
+	end := strings.IndexFunc(base, func(r rune) bool { return !unicode.IsDigit(r) })
+	if end == 0 {
+		return 0, false
+	}
+	if end < 0 {
+		end = len(base)
+	}
+	v, err := strconv.ParseUint(base[:end], 10, 64)
+	return v, err == nil
+}
+
+// Keep the schema files up to and including the one of the given migration,
+// which is either a file name, with or without its .sql or .up.sql
+// extension, or a version. Versions are compared as numbers, so 3 matches
+// 0003_add_users.up.sql.
+func migrationFiles(files []string, migration string) ([]string, error) {
+	want, numeric := migrationVersion(migration)
+	numeric = numeric && strings.TrimLeft(migration, "0123456789") == ""
+	for i, filename := range files {
+		base := filepath.Base(filename)
+		match := base == migration ||
+			strings.TrimSuffix(base, ".sql") == migration ||
+			strings.TrimSuffix(base, ".up.sql") == migration
+		if numeric {
+			v, ok := migrationVersion(base)
+			match = match || ok && v == want
+		}
+		if match {
+			return files[:i+1], nil
+		}
+	}
+	return nil, fmt.Errorf("no schema file matches migration %q", migration)
+}
@@ -78,3 +78,59 @@ func TestRemoveGolangMigrateRollback(t *testing.T) {
 		t.Errorf("golang-migrate filtering mismatch: \n %s", diff)
 	}
 }
+
+const inputDump = `SET client_encoding = 'UTF8';
+\connect app
+CREATE TABLE public.people (id int);
+COPY public.people (id) FROM stdin;
+1
+2
+\.
+`
+
+const outputDump = `SET client_encoding = 'UTF8';
+
+CREATE TABLE public.people (id int);
+COPY public.people (id) FROM stdin;
+
+
+
+`
+
+func TestRemovePsqlMetaCommands(t *testing.T) {
+	if diff := cmp.Diff(outputDump, RemovePsqlMetaCommands(inputDump)); diff != "" {
+		t.Errorf("pg_dump mismatch:\n%s", diff)
+	}
+}
+
+func TestMigrationFiles(t *testing.T) {
+	files := []string{
+		"migrations/0001_create_users.up.sql",
+		"migrations/0002_add_email.up.sql",
+		"migrations/0010_drop_name.up.sql",
+	}
+	for _, tc := range []struct {
+		migration string
+		n         int
+	}{
+		{"0001_create_users.up.sql", 1},
+		{"0002_add_email", 2},
+		{"0002_add_email.up", 2},
+		{"2", 2},
+		{"0010", 3},
+	} {
+		got, err := migrationFiles(files, tc.migration)
+		if err != nil {
+			t.Errorf("%s: %s", tc.migration, err)
+			continue
+		}
+		if diff := cmp.Diff(files[:tc.n], got); diff != "" {
+			t.Errorf("%s: files differed (-want +got):\n%s", tc.migration, diff)
+		}
+	}
+	for _, migration := range []string{"3", "11", "create_users"} {
+		if _, err := migrationFiles(files, migration); err == nil {
+			t.Errorf("%s: expected an error", migration)
+		}
+	}
+}
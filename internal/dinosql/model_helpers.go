@@ -0,0 +1,97 @@
+package dinosql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// The methods generated for models by emit_model_helpers, which a column can't
+// share a name with
+var modelHelpers = []string{"TableName", "Columns", "ScanRow"}
+
+func validateModelHelpers(r Generateable, settings config.CombinedSettings) error {
+	if !settings.Go.EmitModelHelpers {
+		return nil
+	}
+	for _, s := range r.Structs(settings) {
+		for _, f := range s.Fields {
+			for _, name := range modelHelpers {
+				if f.Name == name {
+					return fmt.Errorf("emit_model_helpers: the %s field of %s conflicts with its %s method", f.Name, s.Name, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Helpers are generated for the models of tables. Structs without a table,
+// such as row structs, don't get them.
+func (s GoStruct) HasModelHelpers() bool {
+	if s.Table.Rel == "" {
+		return false
+	}
+	for _, f := range s.Fields {
+		if f.Column == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// The name of the table as a Go string literal, qualified with its schema
+// outside of public and quoted where PostgreSQL needs it
+func (s GoStruct) TableName() string {
+	name := quoteIdentIfNeeded(s.Table.Rel)
+	if s.Table.Schema != "" && s.Table.Schema != "public" {
+		name = quoteQualifiedName([]string{s.Table.Schema, s.Table.Rel})
+	}
+	return strconv.Quote(name)
+}
+
+// The columns of the table as Go string literals, in the order of the fields
+func (s GoStruct) ColumnNames() string {
+	names := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		names[i] = strconv.Quote(quoteIdentIfNeeded(f.Column))
+	}
+	return strings.Join(names, ", ")
+}
+
+// The Scan targets of the fields of a model, s, in the order of its columns
+func (s GoStruct) ScanTargets() string {
+	targets := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		targets[i] = scanTarget("s."+f.Name, f.Type, f.JSON, f.Interval)
+	}
+	return formatParams(targets)
+}
+
+// ScanRow scans arrays with pq.Array, and NOT NULL intervals with intervalScan
+func modelHelpersScanArrays(r Generateable, settings config.CombinedSettings) bool {
+	if !settings.Go.EmitModelHelpers {
+		return false
+	}
+	for _, s := range r.Structs(settings) {
+		for _, f := range s.Fields {
+			if s.HasModelHelpers() && strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func modelHelpersScanIntervals(structs []GoStruct) bool {
+	for _, s := range structs {
+		for _, f := range s.Fields {
+			if s.HasModelHelpers() && f.Interval {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package dinosql
+
+import (
+	"testing"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+func TestModelHelperNames(t *testing.T) {
+	s := GoStruct{
+		Table: core.FQN{Schema: "public", Rel: "authors"},
+		Fields: []GoField{
+			{Name: "ID", Column: "id"},
+			{Name: "FirstName", Column: "firstName"},
+			{Name: "Order", Column: "order"},
+		},
+	}
+	if got, want := s.TableName(), `"authors"`; got != want {
+		t.Errorf("expected table %s, got %s", want, got)
+	}
+	if got, want := s.ColumnNames(), `"id", "\"firstName\"", "\"order\""`; got != want {
+		t.Errorf("expected columns %s, got %s", want, got)
+	}
+
+	s.Table = core.FQN{Schema: "Jobs", Rel: "runs"}
+	if got, want := s.TableName(), `"\"Jobs\".runs"`; got != want {
+		t.Errorf("expected table %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,240 @@
+package dinosql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kyleconroy/sqlc/internal/catalog"
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+
+	pg "github.com/lfittl/pg_query_go"
+)
+
+const (
+	paramPrefix    = "-- param:"
+	timeoutPrefix  = "-- timeout:"
+	jsonPrefix     = "-- json:"
+	readOnlyPrefix = "-- read_only:"
+)
+
+// The Go type of a parameter set by a param annotation
+type ParamType struct {
+	GoType  string // e.g. uuid.UUID
+	Package string // The import path of a type outside the generator's defaults
+}
+
+// Read the Go types given to parameters by param annotations. A parameter is
+// named like its column, or by its number for a parameter without a name.
+// Fully qualified types are imported like overrides:
+//
+//	-- name: GetAuthor :one
+//	-- param: id github.com/gofrs/uuid.UUID
+//	SELECT * FROM authors WHERE id = $1;
+//
+// Also returns the names of annotated parameters that the query doesn't use,
+// which are reported as warnings.
+func parseParamTypes(t string, params []Parameter) (map[int]ParamType, []string, error) {
+	types := map[int]ParamType{}
+	var unused []string
+	for _, line := range strings.Split(t, "\n") {
+		if !strings.HasPrefix(line, paramPrefix) {
+			continue
+		}
+		parts := strings.Fields(strings.TrimPrefix(line, paramPrefix))
+		if len(parts) != 2 {
+			return nil, nil, fmt.Errorf("invalid param annotation, expected a name and a Go type: %s", line)
+		}
+		name, goType := parts[0], parts[1]
+		number := 0
+		for _, p := range params {
+			if p.Column.Name == name || fmt.Sprintf("$%d", p.Number) == name {
+				number = p.Number
+				break
+			}
+		}
+		if number == 0 {
+			unused = append(unused, name)
+			continue
+		}
+		if _, ok := types[number]; ok {
+			return nil, nil, fmt.Errorf("duplicate param annotation: %s", name)
+		}
+		pt := ParamType{GoType: goType}
+		if strings.Contains(goType, "/") {
+			o := config.Override{GoType: goType, DBType: name}
+			if err := o.Parse(); err != nil {
+				return nil, nil, err
+			}
+			pt = ParamType{GoType: o.GoTypeName, Package: o.GoPackage}
+		}
+		types[number] = pt
+	}
+	return types, unused, nil
+}
+
+// The Go type of a json or jsonb column set by a json annotation. The
+// generated method unmarshals the column into the type.
+type JSONType struct {
+	GoType  string // e.g. []AuthorBook
+	Package string // The import path of a type outside the generated package
+
+	// The fields of a struct generated for the type, named Struct, if the
+	// annotation declares them
+	Struct string
+	Fields []core.Column
+}
+
+var jsonTypeSpec = regexp.MustCompile(`^(\[\]|\*)?([^\s(]+)\s*(?:\((.*)\))?$`)
+
+// Read the Go types given to json and jsonb columns by json annotations. The
+// type is a type of the package, a fully qualified type, or a struct to
+// generate with the fields of a column definition list:
+//
+//	-- name: ListAuthors :many
+//	-- json: books []AuthorBook(id bigint, title text)
+//	SELECT a.*, jsonb_agg(b) AS books
+//	FROM authors a JOIN books b ON b.author_id = a.id
+//	GROUP BY a.id;
+func parseJSONTypes(t string, cols []core.Column) (map[int]JSONType, error) {
+	types := map[int]JSONType{}
+	for _, line := range strings.Split(t, "\n") {
+		if !strings.HasPrefix(line, jsonPrefix) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(line, jsonPrefix)), " ", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid json annotation, expected a column and a Go type: %s", line)
+		}
+		name := parts[0]
+		match := jsonTypeSpec.FindStringSubmatch(strings.TrimSpace(parts[1]))
+		if match == nil {
+			return nil, fmt.Errorf("invalid json annotation, expected a column and a Go type: %s", line)
+		}
+		index := -1
+		for i, c := range cols {
+			if c.Name == name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("json annotation names an unknown column: %s", name)
+		}
+		switch cols[index].DataType {
+		case "json", "jsonb", "pg_catalog.json", "pg_catalog.jsonb":
+		default:
+			return nil, fmt.Errorf("json annotation names column %s of type %s, expected json or jsonb", name, cols[index].DataType)
+		}
+		if _, ok := types[index]; ok {
+			return nil, fmt.Errorf("duplicate json annotation: %s", name)
+		}
+
+		prefix, typ, fields := match[1], match[2], match[3]
+		jt := JSONType{GoType: prefix + typ}
+		switch {
+		case strings.Contains(parts[1], "("):
+			if err := validateMethodName(typ); err != nil {
+				return nil, fmt.Errorf("invalid json annotation, %q isn't a valid struct name", typ)
+			}
+			columns, err := parseJSONFields(fields)
+			if err != nil {
+				return nil, fmt.Errorf("invalid json annotation fields %q: %w", fields, err)
+			}
+			jt.Struct, jt.Fields = typ, columns
+		case strings.Contains(typ, "/"):
+			o := config.Override{GoType: typ, DBType: name}
+			if err := o.Parse(); err != nil {
+				return nil, err
+			}
+			jt = JSONType{GoType: prefix + o.GoTypeName, Package: o.GoPackage}
+		}
+		types[index] = jt
+	}
+	return types, nil
+}
+
+// Read a column definition list, such as "id bigint, tags text[]", like the
+// columns of a table
+func parseJSONFields(defs string) ([]core.Column, error) {
+	stmts, err := pg.Parse("CREATE TABLE json_fields (" + defs + ");")
+	if err != nil {
+		return nil, err
+	}
+	c := core.NewCatalog()
+	for _, stmt := range stmts.Statements {
+		if err := catalog.Update(&c, stmt); err != nil {
+			return nil, err
+		}
+	}
+	table := c.Schemas["public"].Tables["json_fields"]
+	if len(table.Columns) == 0 {
+		return nil, fmt.Errorf("no fields")
+	}
+	return table.Columns, nil
+}
+
+// Read the duration of a timeout annotation. The generated method runs the
+// query with a context that's cancelled after the duration:
+//
+//	-- name: DeleteExpiredSessions :exec
+//	-- timeout: 30s
+//	DELETE FROM sessions WHERE expires_at < now();
+func parseTimeout(t string) (time.Duration, error) {
+	for _, line := range strings.Split(t, "\n") {
+		if !strings.HasPrefix(line, timeoutPrefix) {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(line, timeoutPrefix))
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return 0, fmt.Errorf("invalid timeout: %q", value)
+		}
+		return d, nil
+	}
+	return 0, nil
+}
+
+// A Go expression for a duration, such as 5 * time.Second
+func durationExpr(d time.Duration) string {
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "Hour"},
+		{time.Minute, "Minute"},
+		{time.Second, "Second"},
+		{time.Millisecond, "Millisecond"},
+		{time.Microsecond, "Microsecond"},
+	} {
+		if d%unit.d == 0 {
+			return fmt.Sprintf("%d*time.%s", d/unit.d, unit.name)
+		}
+	}
+	return fmt.Sprintf("%d*time.Nanosecond", d)
+}
+
+// Read a read_only annotation, which overrides the read_only setting of the
+// package for one query. ok is false if the query doesn't have one.
+//
+//	-- name: RefreshStats :exec
+//	-- read_only: false
+//	UPDATE stats SET refreshed_at = now();
+func parseReadOnly(t string) (readOnly, ok bool, err error) {
+	for _, line := range strings.Split(t, "\n") {
+		if !strings.HasPrefix(line, readOnlyPrefix) {
+			continue
+		}
+		switch value := strings.TrimSpace(strings.TrimPrefix(line, readOnlyPrefix)); value {
+		case "true":
+			return true, true, nil
+		case "false":
+			return false, true, nil
+		default:
+			return false, false, fmt.Errorf("invalid read_only: %q", value)
+		}
+	}
+	return false, false, nil
+}
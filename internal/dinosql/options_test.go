@@ -0,0 +1,41 @@
+package dinosql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		d     time.Duration
+		err   bool
+	}{
+		{"-- name: Foo :one\n-- timeout: 5s\nSELECT 1", 5 * time.Second, false},
+		{"-- name: Foo :one\nSELECT 1", 0, false},
+		{"-- name: Foo :one\n-- timeout: soon\nSELECT 1", 0, true},
+		{"-- name: Foo :one\n-- timeout: -1s\nSELECT 1", 0, true},
+	} {
+		d, err := parseTimeout(tc.query)
+		if (err != nil) != tc.err {
+			t.Errorf("%q: unexpected error: %v", tc.query, err)
+		}
+		if d != tc.d {
+			t.Errorf("%q: expected %s, got %s", tc.query, tc.d, d)
+		}
+	}
+}
+
+func TestDurationExpr(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		2 * time.Hour:           "2*time.Hour",
+		90 * time.Minute:        "90*time.Minute",
+		1500 * time.Millisecond: "1500*time.Millisecond",
+		5 * time.Second:         "5*time.Second",
+		7:                       "7*time.Nanosecond",
+	} {
+		if got := durationExpr(d); got != want {
+			t.Errorf("%s: expected %s, got %s", d, want, got)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package dinosql
+
+import (
+	"fmt"
+	"strings"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+
+	pg "github.com/lfittl/pg_query_go"
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// A :paginated query returns its rows one page at a time, using the values of
+// its ORDER BY columns in the last row of a page as the cursor of the next:
+//
+//	-- name: ListAuthors :paginated
+//	SELECT * FROM authors ORDER BY created_at, id;
+//
+// The query is wrapped in a subquery that's ordered by the same columns and
+// limited to the size of the page. The SQL of the query is the first page, and
+// NextSQL compares the columns with the cursor, which follows the parameters
+// of the query.
+type Pagination struct {
+	Columns []int // The output columns of the cursor, in ORDER BY order
+	Desc    bool
+	NextSQL string
+}
+
+func paginate(stmt nodes.Node, name string, cols []core.Column, params []Parameter, sql string) (*Pagination, string, error) {
+	sel, ok := stmt.(nodes.SelectStmt)
+	if !ok {
+		return nil, "", fmt.Errorf("paginated query %q must be a SELECT statement", name)
+	}
+	if sel.LimitCount != nil || sel.LimitOffset != nil {
+		return nil, "", fmt.Errorf("paginated query %q must not have a LIMIT or OFFSET", name)
+	}
+	if len(sel.SortClause.Items) == 0 {
+		return nil, "", fmt.Errorf("paginated query %q must have an ORDER BY clause", name)
+	}
+
+	var p Pagination
+	var names []string
+	for i, item := range sel.SortClause.Items {
+		sortBy, ok := item.(nodes.SortBy)
+		if !ok {
+			return nil, "", fmt.Errorf("paginated query %q has an unsupported ORDER BY clause", name)
+		}
+		ref, ok := sortBy.Node.(nodes.ColumnRef)
+		if !ok || HasStarRef(ref) {
+			return nil, "", fmt.Errorf("paginated query %q must be ordered by columns", name)
+		}
+		fields := stringSlice(ref.Fields)
+		column := fields[len(fields)-1]
+
+		if sortBy.SortbyDir == nodes.SORTBY_USING {
+			return nil, "", fmt.Errorf("paginated query %q must not sort USING an operator", name)
+		}
+		desc := sortBy.SortbyDir == nodes.SORTBY_DESC
+		if i == 0 {
+			p.Desc = desc
+		} else if desc != p.Desc {
+			return nil, "", fmt.Errorf("paginated query %q must sort every ORDER BY column in the same direction", name)
+		}
+		if sortBy.SortbyNulls != nodes.SORTBY_NULLS_DEFAULT {
+			return nil, "", fmt.Errorf("paginated query %q must not use NULLS FIRST or NULLS LAST", name)
+		}
+
+		index := -1
+		for j, c := range cols {
+			if c.Name != column {
+				continue
+			}
+			if index >= 0 {
+				return nil, "", fmt.Errorf("ORDER BY column %q of paginated query %q is returned more than once", column, name)
+			}
+			index = j
+		}
+		if index < 0 {
+			return nil, "", fmt.Errorf("ORDER BY column %q of paginated query %q must be returned by the query", column, name)
+		}
+		if !cols[index].NotNull || cols[index].IsArray {
+			return nil, "", fmt.Errorf("ORDER BY column %q of paginated query %q must be NOT NULL", column, name)
+		}
+		p.Columns = append(p.Columns, index)
+		names = append(names, quoteIdentIfNeeded(column))
+	}
+
+	last := 0
+	for _, param := range params {
+		if param.Number > last {
+			last = param.Number
+		}
+	}
+
+	order := strings.Join(names, ", ")
+	op := ">"
+	if p.Desc {
+		order = strings.Join(names, " DESC, ") + " DESC"
+		op = "<"
+	}
+	var cursor []string
+	for i := range names {
+		cursor = append(cursor, fmt.Sprintf("$%d", last+i+1))
+	}
+	page := "SELECT * FROM (\n" + sql + "\n) AS page\n"
+	first := fmt.Sprintf("%sORDER BY %s\nLIMIT $%d", page, order, last+1)
+	p.NextSQL = fmt.Sprintf("%sWHERE (%s) %s (%s)\nORDER BY %s\nLIMIT $%d", page,
+		strings.Join(names, ", "), op, strings.Join(cursor, ", "), order, last+len(names)+1)
+
+	for _, q := range []string{first, p.NextSQL} {
+		if _, err := pg.Parse(q); err != nil {
+			return nil, "", fmt.Errorf("paginated query syntax is invalid: %w", err)
+		}
+	}
+	return &p, first, nil
+}
@@ -10,12 +10,17 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/kyleconroy/sqlc/internal/cache"
 	"github.com/kyleconroy/sqlc/internal/catalog"
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/parallel"
 	core "github.com/kyleconroy/sqlc/internal/pg"
 	"github.com/kyleconroy/sqlc/internal/postgres"
 	"github.com/kyleconroy/sqlc/internal/postgresql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/token"
 
 	"github.com/davecgh/go-spew/spew"
 	pg "github.com/lfittl/pg_query_go"
@@ -51,6 +56,17 @@ func (e *ParserErr) Add(filename, source string, loc int, err error) {
 	e.Errs = append(e.Errs, FileErr{filename, line, column, err})
 }
 
+// A query or method name that's already used by another query of the package
+type DuplicateErr struct {
+	Kind  string
+	Name  string
+	First FileErr // The position of the first query using the name
+}
+
+func (e *DuplicateErr) Error() string {
+	return fmt.Sprintf("duplicate %s name: %s, first defined at %s:%d:%d", e.Kind, e.Name, e.First.Filename, e.First.Line, e.First.Column)
+}
+
 func NewParserErr() *ParserErr {
 	return &ParserErr{}
 }
@@ -95,24 +111,80 @@ func ReadSQLFiles(path string) ([]string, error) {
 	return sql, nil
 }
 
+type CatalogOpts struct {
+	// Accept the CockroachDB dialect of PostgreSQL
+	CockroachDB bool
+
+	// Accept Redshift DDL, ignoring its storage clauses
+	Redshift bool
+
+	// Build the catalog from the schema files up to and including the one of
+	// this migration, to check queries against a partially migrated database.
+	// Every file is read if empty.
+	Migration string
+}
+
 func ParseCatalog(schema string) (core.Catalog, error) {
+	return ParseCatalogOpts(schema, CatalogOpts{})
+}
+
+func ParseCatalogOpts(schema string, opts CatalogOpts) (core.Catalog, error) {
 	files, err := ReadSQLFiles(schema)
 	if err != nil {
 		return core.Catalog{}, err
 	}
-
-	merr := NewParserErr()
-	c := core.NewCatalog()
-	for _, filename := range files {
-		blob, err := ioutil.ReadFile(filename)
+	if opts.Migration != "" {
+		files, err = migrationFiles(files, opts.Migration)
 		if err != nil {
-			merr.Add(filename, "", 0, err)
-			continue
+			return core.Catalog{}, err
 		}
-		contents := RemoveRollbackStatements(string(blob))
-		tree, err := pg.Parse(contents)
+	}
+
+	// Parsing is independent for each file, but the catalog has to be
+	// updated in file order.
+	type schemaFile struct {
+		contents   string
+		procedures []int
+		tree       pg.ParsetreeList
+		err        error
+	}
+	parsed := make([]schemaFile, len(files))
+	parallel.Do(len(files), func(i int) {
+		blob, err := ioutil.ReadFile(files[i])
 		if err != nil {
-			merr.Add(filename, contents, 0, err)
+			parsed[i].err = err
+			return
+		}
+		parsed[i].contents = RemovePsqlMetaCommands(RemoveRollbackStatements(string(blob)))
+		if opts.CockroachDB {
+			parsed[i].contents = RemoveCockroachClauses(parsed[i].contents)
+		}
+		if opts.Redshift {
+			parsed[i].contents = RemoveRedshiftClauses(parsed[i].contents)
+		}
+		parsed[i].contents, parsed[i].procedures = rewriteProcedures(parsed[i].contents)
+		parsed[i].tree, parsed[i].err = parseSQL(parsed[i].contents)
+		if parsed[i].err != nil {
+			// Fall back to skipping unsupported statements, but report the
+			// original error if that doesn't help
+			if tree, err := parseDump(parsed[i].contents); err == nil {
+				parsed[i].tree, parsed[i].err = tree, nil
+			}
+		}
+	})
+
+	merr := NewParserErr()
+	c := core.NewCatalog()
+	if opts.CockroachDB {
+		addCockroachFunctions(&c)
+	}
+	if opts.Redshift {
+		addRedshiftFunctions(&c)
+	}
+	for i, filename := range files {
+		contents, tree := parsed[i].contents, parsed[i].tree
+		if parsed[i].err != nil {
+			merr.Add(filename, contents, 0, parsed[i].err)
 			continue
 		}
 		for _, stmt := range tree.Statements {
@@ -124,6 +196,8 @@ func ParseCatalog(schema string) (core.Catalog, error) {
 				merr.Add(filename, contents, location(stmt), err)
 				continue
 			}
+			recordPolicySQL(&c, stmt, contents)
+			markProcedure(&c, stmt, parsed[i].procedures)
 		}
 	}
 
@@ -183,18 +257,79 @@ type Query struct {
 	Name     string
 	Cmd      string // TODO: Pick a better name. One of: one, many, exec, execrows
 	Comments []string
+	Sort     *Sort
+	Method   string // Overrides the name of the generated method
+
+	// Go types of parameters, by number, and the timeout of the generated
+	// method, set by the query's annotations
+	ParamTypes map[int]ParamType
+	Timeout    time.Duration
+
+	// Go types of json and jsonb columns, by index, set by json annotations
+	JSONTypes map[int]JSONType
+
+	// The cursor and the SQL of the next pages of a :paginated query
+	Pagination *Pagination
+
+	// The statements of an :execscript query, starting with the one in SQL
+	Script []ScriptStatement
+
+	// The session variables the query reads with current_setting
+	SessionVars []SessionVar
+
+	// Problems found by analysis that don't stop generation
+	Warnings []Warning
+
+	// The parse tree and relations of the query, with ParserOpts.Debug
+	Trace *QueryTrace
 
 	// XXX: Hack
 	Filename string
 }
 
+// A problem with a query that doesn't stop generation, such as a parameter
+// that can't change the result. Location is an offset in the queries file, or
+// 0 for the start of the query.
+type Warning struct {
+	Message  string
+	Location int
+}
+
 type Result struct {
 	Queries []*Query
 	Catalog core.Catalog
+
+	// The warnings of every query, in file order
+	Warnings []FileErr
 }
 
 type ParserOpts struct {
 	UsePositionalParameters bool
+
+	// The engine of the package, which adds its own built-in functions to
+	// pg_catalog
+	Engine config.Engine
+
+	// SQL substituted for sqlc.const(name) references before parsing
+	Constants map[string]string
+
+	// Schemas searched for unqualified table names in queries
+	SearchPath []string
+
+	// Fail if a query writes to the database, unless it's annotated with
+	// read_only: false
+	ReadOnly bool
+
+	// Report warnings as errors
+	WarningsAsErrors bool
+
+	// Reuse the analysis of query files that haven't changed since the last
+	// run. Caching is disabled if nil.
+	Cache *cache.Cache
+
+	// Record the Trace of each query, for Result.Debug. The cache doesn't
+	// keep traces, so Cache is ignored when Debug is set.
+	Debug bool
 }
 
 func ParseQueries(c core.Catalog, queries string, opts ParserOpts) (*Result, error) {
@@ -217,8 +352,7 @@ func ParseQueries(c core.Catalog, queries string, opts ParserOpts) (*Result, err
 	}
 
 	merr := NewParserErr()
-	var q []*Query
-	set := map[string]struct{}{}
+	var sources []queryFile
 	for _, filename := range files {
 		if !strings.HasSuffix(filename, ".sql") {
 			continue
@@ -231,33 +365,64 @@ func ParseQueries(c core.Catalog, queries string, opts ParserOpts) (*Result, err
 			merr.Add(filename, "", 0, err)
 			continue
 		}
-		source := string(blob)
-		tree, err := pg.Parse(source)
-		if err != nil {
-			merr.Add(filename, source, 0, err)
-			continue
+		sources = append(sources, queryFile{Filename: filename, Source: string(blob)})
+	}
+	sources, frags := collectFragments(sources, merr)
+
+	// Files are analyzed concurrently. Query names must be unique across all
+	// files, so duplicates are found afterwards, in file order.
+	if opts.Debug {
+		opts.Cache = nil
+	}
+	var catalogKey string
+	if opts.Cache != nil {
+		if catalogKey, err = catalogCacheKey(c); err != nil {
+			opts.Cache = nil
 		}
-		for _, stmt := range tree.Statements {
-			query, err := parseQuery(c, stmt, source, opts.UsePositionalParameters)
-			if err == errUnsupportedStatementType {
-				continue
-			}
-			if err != nil {
-				merr.Add(filename, source, location(stmt), err)
-				continue
-			}
-			if query.Name != "" {
-				if _, exists := set[query.Name]; exists {
-					merr.Add(filename, source, location(stmt), fmt.Errorf("duplicate query name: %s", query.Name))
+	}
+	parsed := make([]parsedFile, len(sources))
+	parallel.Do(len(sources), func(i int) {
+		parsed[i] = parseQueryFile(c, sources[i], frags, opts, catalogKey)
+	})
+
+	var q []*Query
+	var warnings []FileErr
+	set := map[string]FileErr{}
+	methods := map[string]FileErr{}
+	for _, file := range parsed {
+		prev := 0
+		for _, fq := range file.queries {
+			merr.Errs = append(merr.Errs, file.errs.Errs[prev:fq.errCount]...)
+			prev = fq.errCount
+			if fq.query.Name != "" {
+				if first, exists := set[fq.query.Name]; exists {
+					file.smap.add(merr, fq.location, &DuplicateErr{"query", fq.query.Name, first})
+					continue
+				}
+				if first, exists := methods[fq.query.MethodName()]; exists {
+					file.smap.add(merr, fq.location, &DuplicateErr{"method", fq.query.MethodName(), first})
 					continue
 				}
-				set[query.Name] = struct{}{}
+				pos := file.smap.fileErr(fq.location)
+				set[fq.query.Name] = pos
+				methods[fq.query.MethodName()] = pos
 			}
-			query.Filename = filepath.Base(filename)
-			if query != nil {
-				q = append(q, query)
+			for _, w := range fq.query.Warnings {
+				loc := w.Location
+				if loc == 0 {
+					loc = fq.location
+				}
+				if opts.WarningsAsErrors {
+					file.smap.add(merr, loc, errors.New(w.Message))
+					continue
+				}
+				fe := file.smap.fileErr(loc)
+				fe.Err = errors.New(w.Message)
+				warnings = append(warnings, fe)
 			}
+			q = append(q, fq.query)
 		}
+		merr.Errs = append(merr.Errs, file.errs.Errs[prev:]...)
 	}
 	if len(merr.Errs) > 0 {
 		return nil, merr
@@ -266,11 +431,160 @@ func ParseQueries(c core.Catalog, queries string, opts ParserOpts) (*Result, err
 		return nil, fmt.Errorf("path %s contains no queries", queries)
 	}
 	return &Result{
-		Catalog: c,
-		Queries: q,
+		Catalog:  c,
+		Queries:  q,
+		Warnings: warnings,
 	}, nil
 }
 
+type fileQuery struct {
+	query    *Query
+	location int
+
+	// The number of errors found in the file before this query
+	errCount int
+}
+
+type parsedFile struct {
+	queries []fileQuery
+	errs    *ParserErr
+	smap    sourceMap
+}
+
+func parseQueryFile(c core.Catalog, file queryFile, frags map[string]fragment, opts ParserOpts, catalogKey string) parsedFile {
+	merr := NewParserErr()
+	result := parsedFile{errs: merr}
+	source, smap, ok := expandIncludes(file, frags, merr)
+	if !ok {
+		return result
+	}
+	source, smap, ok = expandConstants(source, opts.Constants, smap, merr)
+	if !ok {
+		return result
+	}
+	source, smap, calls := expandCalls(source, smap)
+	result.smap = smap
+
+	var key string
+	if opts.Cache != nil {
+		key = opts.Cache.Key(catalogKey, optsCacheKey(opts), file.Filename, source)
+		if queries, ok := cachedQueries(opts.Cache, key); ok {
+			result.queries = queries
+			return result
+		}
+	}
+
+	tree, err := parseSQL(source)
+	if err != nil {
+		merr.Add(file.Filename, file.Source, 0, err)
+		return result
+	}
+	sc := sessionCatalog(c, opts.SearchPath)
+	for i := 0; i < len(tree.Statements); i++ {
+		stmt := tree.Statements[i]
+		var query *Query
+		var err error
+		if _, cmd, _ := statementMetadata(stmt, source); cmd == ":execscript" {
+			n := scriptLen(tree.Statements[i:], source)
+			query, err = parseScript(sc, tree.Statements[i:i+n], source, calls, opts)
+			i += n - 1
+		} else if raw, ok := stmt.(nodes.RawStmt); ok && isCall(raw, calls) {
+			query, err = parseCall(sc, stmt, source, opts)
+		} else {
+			query, err = parseQuery(sc, stmt, source, opts)
+		}
+		if err == errUnsupportedStatementType {
+			continue
+		}
+		if err != nil {
+			smap.add(merr, location(stmt), err)
+			continue
+		}
+		if isTempTableStmt(stmt) {
+			if err := catalog.Update(&sc, stmt); err != nil {
+				smap.add(merr, location(stmt), err)
+				continue
+			}
+		}
+		query.Filename = filepath.Base(file.Filename)
+		if opts.Debug {
+			query.Trace = traceQuery(sc, query)
+		}
+		result.queries = append(result.queries, fileQuery{
+			query:    query,
+			location: location(stmt),
+			errCount: len(merr.Errs),
+		})
+	}
+	if opts.Cache != nil && len(merr.Errs) == 0 {
+		storeQueries(opts.Cache, key, result.queries)
+	}
+	return result
+}
+
+// Temporary tables created in a queries file are scoped to the session that
+// runs those queries. Each file gets its own copy of the catalog with an empty
+// pg_temp schema, so that temporary tables are only visible to the queries
+// that follow them in the same file. Unqualified table names are resolved with
+// the search path of the session.
+func sessionCatalog(c core.Catalog, searchPath []string) core.Catalog {
+	schemas := make(map[string]core.Schema, len(c.Schemas)+1)
+	for name, schema := range c.Schemas {
+		schemas[name] = schema
+	}
+	schemas["pg_temp"] = core.NewSchema()
+	return core.Catalog{Schemas: schemas, SearchPath: searchPath}
+}
+
+func isTempTableStmt(node nodes.Node) bool {
+	raw, ok := node.(nodes.RawStmt)
+	if !ok {
+		return false
+	}
+	stmt, ok := raw.Stmt.(nodes.CreateStmt)
+	return ok && catalog.IsTemporary(stmt.Relation)
+}
+
+// The temporary schema is searched for unqualified relation names before any
+// other schema, followed by the schemas of the search path in order. A name
+// that isn't in any of them refers to the first schema of the path, so that
+// it's reported as a missing relation. The $user entry of PostgreSQL's default
+// path has no schema to refer to and is skipped.
+//
+// https://www.postgresql.org/docs/current/runtime-config-client.html
+func resolveRange(c core.Catalog, rv *nodes.RangeVar) (core.FQN, error) {
+	fqn, err := catalog.ParseRange(rv)
+	if err != nil {
+		return fqn, err
+	}
+	if rv.Schemaname != nil {
+		return fqn, nil
+	}
+	if temp, exists := c.Schemas["pg_temp"]; exists {
+		if _, exists := temp.Tables[fqn.Rel]; exists {
+			fqn.Schema = "pg_temp"
+			return fqn, nil
+		}
+	}
+	var first string
+	for _, name := range c.SearchPath {
+		if name == "$user" {
+			continue
+		}
+		if first == "" {
+			first = name
+		}
+		if _, exists := c.Schemas[name].Tables[fqn.Rel]; exists {
+			fqn.Schema = name
+			return fqn, nil
+		}
+	}
+	if first != "" {
+		fqn.Schema = first
+	}
+	return fqn, nil
+}
+
 func location(node nodes.Node) int {
 	switch n := node.(type) {
 	case nodes.Query:
@@ -378,7 +692,7 @@ func ParseMetadata(t string, commentStyle CommentSyntax) (string, string, error)
 		queryName := part[2]
 		queryType := strings.TrimSpace(part[3])
 		switch queryType {
-		case ":one", ":many", ":exec", ":execrows":
+		case ":one", ":many", ":exec", ":execrows", ":paginated", ":execscript":
 		default:
 			return "", "", fmt.Errorf("invalid query type: %s", queryType)
 		}
@@ -405,6 +719,8 @@ func validateCmd(n nodes.Node, name, cmd string) error {
 		list = stmt.ReturningList
 	case nodes.UpdateStmt:
 		list = stmt.ReturningList
+	case nodes.NotifyStmt:
+		return fmt.Errorf("query %q specifies parameter %q, but NOTIFY doesn't return rows", name, cmd)
 	default:
 		return nil
 	}
@@ -416,7 +732,7 @@ func validateCmd(n nodes.Node, name, cmd string) error {
 
 var errUnsupportedStatementType = errors.New("parseQuery: unsupported statement type")
 
-func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameters bool) (*Query, error) {
+func parseQuery(c core.Catalog, stmt nodes.Node, source string, opts ParserOpts) (*Query, error) {
 	if err := validateParamRef(stmt); err != nil {
 		return nil, err
 	}
@@ -435,6 +751,11 @@ func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameter
 			return nil, err
 		}
 	case nodes.UpdateStmt:
+	case nodes.NotifyStmt:
+	case nodes.CreateStmt:
+		if !catalog.IsTemporary(n.Relation) {
+			return nil, errUnsupportedStatementType
+		}
 	default:
 		return nil, errUnsupportedStatementType
 	}
@@ -456,12 +777,46 @@ func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameter
 	if err := validateCmd(raw.Stmt, name, cmd); err != nil {
 		return nil, err
 	}
+	sortSpec, err := parseSort(strings.TrimSpace(rawSQL))
+	if err != nil {
+		return nil, err
+	}
+	if cmd == ":paginated" && sortSpec != nil {
+		return nil, fmt.Errorf("paginated query %q can't have a sort annotation", name)
+	}
+	if cmd == ":paginated" && opts.UsePositionalParameters {
+		return nil, fmt.Errorf("paginated query %q: the :paginated command is only supported for Go", name)
+	}
+	method, err := parseMethod(strings.TrimSpace(rawSQL))
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := parseTimeout(strings.TrimSpace(rawSQL))
+	if err != nil {
+		return nil, err
+	}
+	readOnly, ok, err := parseReadOnly(strings.TrimSpace(rawSQL))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		readOnly = opts.ReadOnly
+	}
+	if readOnly {
+		if err := validateReadOnly(raw.Stmt, name); err != nil {
+			return nil, err
+		}
+	}
 
 	// Re-write query AST
 	raw, namedParams, edits := rewriteNamedParameters(raw)
 	rvs := rangeVars(raw.Stmt)
 	refs := findParameters(raw.Stmt)
-	if rewriteParameters {
+	casts, err := paramCasts(refs)
+	if err != nil {
+		return nil, err
+	}
+	if opts.UsePositionalParameters {
 		edits, err = rewriteNumberedParameters(refs, raw, rawSQL)
 		if err != nil {
 			return nil, err
@@ -470,7 +825,18 @@ func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameter
 		refs = uniqueParamRefs(refs)
 		sort.Slice(refs, func(i, j int) bool { return refs[i].ref.Number < refs[j].ref.Number })
 	}
-	params, err := resolveCatalogRefs(c, rvs, refs, namedParams)
+	params, err := resolveCatalogRefs(c, rvs, refs, namedParams, usingColumns(raw.Stmt))
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range params {
+		if col, ok := casts[p.Number]; ok {
+			params[i].Column.DataType = col.DataType
+			params[i].Column.NotNull = col.NotNull
+			params[i].Column.IsArray = col.IsArray
+		}
+	}
+	paramTypes, unusedParams, err := parseParamTypes(strings.TrimSpace(rawSQL), params)
 	if err != nil {
 		return nil, err
 	}
@@ -483,13 +849,28 @@ func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameter
 	if err != nil {
 		return nil, err
 	}
+	jsonTypes, err := parseJSONTypes(strings.TrimSpace(rawSQL), cols)
+	if err != nil {
+		return nil, err
+	}
 
-	expandEdits, err := expand(qc, raw)
+	// Expanding star references into the columns known to the catalog, in
+	// catalog order, keeps the query string in sync with the generated Scan
+	// targets even after new columns are added to a table.
+	expandEdits, err := expand(qc, raw, rawSQL)
 	if err != nil {
 		return nil, err
 	}
 	edits = append(edits, expandEdits...)
 
+	if sortSpec != nil {
+		se, err := sortEdit(qc, raw, rawSQL, name, cmd, sortSpec)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, se)
+	}
+
 	expanded, err := editQuery(rawSQL, edits)
 	if err != nil {
 		return nil, err
@@ -507,13 +888,29 @@ func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameter
 		return nil, err
 	}
 
+	var pagination *Pagination
+	if cmd == ":paginated" {
+		pagination, trimmed, err = paginate(raw.Stmt, name, cols, params, trimmed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Query{
-		Cmd:      cmd,
-		Comments: comments,
-		Name:     name,
-		Params:   params,
-		Columns:  cols,
-		SQL:      trimmed,
+		Cmd:         cmd,
+		Comments:    comments,
+		Sort:        sortSpec,
+		Method:      method,
+		ParamTypes:  paramTypes,
+		JSONTypes:   jsonTypes,
+		Timeout:     timeout,
+		Pagination:  pagination,
+		Warnings:    queryWarnings(raw.Stmt, name, cmd, cols, namedParams, unusedParams),
+		SessionVars: findSessionVars(raw.Stmt),
+		Name:        name,
+		Params:      params,
+		Columns:     cols,
+		SQL:         trimmed,
 	}, nil
 }
 
@@ -533,7 +930,9 @@ func stripComments(sql string) (string, []string, error) {
 	s := bufio.NewScanner(strings.NewReader(sql))
 	var lines, comments []string
 	for s.Scan() {
-		if strings.HasPrefix(s.Text(), "-- name:") {
+		if strings.HasPrefix(s.Text(), "-- name:") || strings.HasPrefix(s.Text(), "-- sort:") || strings.HasPrefix(s.Text(), methodPrefix) ||
+			strings.HasPrefix(s.Text(), paramPrefix) || strings.HasPrefix(s.Text(), timeoutPrefix) || strings.HasPrefix(s.Text(), jsonPrefix) ||
+			strings.HasPrefix(s.Text(), readOnlyPrefix) {
 			continue
 		}
 		if strings.HasPrefix(s.Text(), "--") {
@@ -551,7 +950,33 @@ type edit struct {
 	New      string
 }
 
-func expand(qc *QueryCatalog, raw nodes.RawStmt) ([]edit, error) {
+// Quote an identifier if PostgreSQL would read it differently without quotes:
+// a reserved keyword, or a name with upper case letters or other characters
+// that are folded or not allowed, such as "firstName"
+func quoteIdentIfNeeded(name string) string {
+	if postgres.IsReservedKeyword(name) {
+		return quoteIdent(name)
+	}
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r == '_':
+		case i > 0 && (r >= '0' && r <= '9' || r == '$'):
+		default:
+			return quoteIdent(name)
+		}
+	}
+	return name
+}
+
+func quoteQualifiedName(parts []string) string {
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = quoteIdentIfNeeded(part)
+	}
+	return strings.Join(quoted, ".")
+}
+
+func expand(qc *QueryCatalog, raw nodes.RawStmt, rawSQL string) ([]edit, error) {
 	list := search(raw, func(node nodes.Node) bool {
 		switch node.(type) {
 		case nodes.DeleteStmt:
@@ -568,7 +993,7 @@ func expand(qc *QueryCatalog, raw nodes.RawStmt) ([]edit, error) {
 	}
 	var edits []edit
 	for _, item := range list.Items {
-		edit, err := expandStmt(qc, raw, item)
+		edit, err := expandStmt(qc, raw, rawSQL, item)
 		if err != nil {
 			return nil, err
 		}
@@ -577,7 +1002,7 @@ func expand(qc *QueryCatalog, raw nodes.RawStmt) ([]edit, error) {
 	return edits, nil
 }
 
-func expandStmt(qc *QueryCatalog, raw nodes.RawStmt, node nodes.Node) ([]edit, error) {
+func expandStmt(qc *QueryCatalog, raw nodes.RawStmt, rawSQL string, node nodes.Node) ([]edit, error) {
 	tables, err := sourceTables(qc, node)
 	if err != nil {
 		return nil, err
@@ -626,40 +1051,67 @@ func expandStmt(qc *QueryCatalog, raw nodes.RawStmt, node nodes.Node) ([]edit, e
 		if scope == "" {
 			for _, t := range tables {
 				for _, c := range t.Columns {
-					counts[c.Name] += 1
+					if t.visible(scope, c) {
+						counts[c.Name] += 1
+					}
 				}
 			}
 		}
+		// Columns sharing a name within a table, such as the columns of
+		// unnest(a, b), can't be referenced by name, so the star is kept
+		ambiguous := false
 		for _, t := range tables {
 			if scope != "" && scope != t.Name {
 				continue
 			}
+			seen := map[string]struct{}{}
 			for _, c := range t.Columns {
-				cname := c.Name
+				if !t.visible(scope, c) {
+					continue
+				}
+				if _, ok := seen[c.Name]; ok {
+					ambiguous = true
+				}
+				seen[c.Name] = struct{}{}
+				name := c.Name
 				if res.Name != nil {
-					cname = *res.Name
+					name = *res.Name
 				}
+				cname := quoteIdentIfNeeded(name)
 				if scope != "" {
-					cname = scope + "." + cname
-				}
-				if counts[cname] > 1 {
-					cname = t.Name + "." + cname
+					cname = quoteQualifiedName(parts[:len(parts)-1]) + "." + cname
 				}
-				if postgres.IsReservedKeyword(cname) {
-					cname = "\"" + cname + "\""
+				if counts[name] > 1 && t.Name != "" {
+					cname = quoteIdentIfNeeded(t.Name) + "." + cname
 				}
 				cols = append(cols, cname)
 			}
 		}
+		if ambiguous {
+			continue
+		}
 		edits = append(edits, edit{
 			Location: res.Location - raw.StmtLocation,
-			Old:      strings.Join(parts, "."),
+			Old:      starRefSource(rawSQL, res.Location-raw.StmtLocation, parts),
 			New:      strings.Join(cols, ", "),
 		})
 	}
 	return edits, nil
 }
 
+// The star reference as written, which is longer than its parts when they're
+// quoted or spaced out, as in "UserAccounts" . *
+func starRefSource(rawSQL string, loc int, parts []string) string {
+	if loc >= 0 && loc < len(rawSQL) {
+		for _, t := range token.Split(rawSQL[loc:]) {
+			if t.Text == "*" {
+				return rawSQL[loc : loc+t.End]
+			}
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
 func editQuery(raw string, a []edit) (string, error) {
 	if len(a) == 0 {
 		return raw, nil
@@ -695,6 +1147,8 @@ type QueryCatalog struct {
 func buildQueryCatalog(c core.Catalog, node nodes.Node) (*QueryCatalog, error) {
 	var with *nodes.WithClause
 	switch n := node.(type) {
+	case nodes.DeleteStmt:
+		with = n.WithClause
 	case nodes.InsertStmt:
 		with = n.WithClause
 	case nodes.UpdateStmt:
@@ -747,50 +1201,40 @@ func (qc QueryCatalog) GetTable(fqn core.FQN) (core.Table, *core.Error) {
 // Return an error if column references don't exist
 // Return an error if a table is referenced twice
 // Return an error if an unknown column is referenced
-func sourceTables(qc *QueryCatalog, node nodes.Node) ([]core.Table, error) {
+func sourceTables(qc *QueryCatalog, node nodes.Node) ([]sourceTable, error) {
 	var list nodes.List
 	switch n := node.(type) {
+	case nodes.CreateStmt, nodes.NotifyStmt:
+		return nil, nil
 	case nodes.DeleteStmt:
+		// The target table comes first, followed by the tables listed in the
+		// USING clause
 		list = nodes.List{
-			Items: []nodes.Node{*n.Relation},
+			Items: append([]nodes.Node{*n.Relation}, n.UsingClause.Items...),
 		}
 	case nodes.InsertStmt:
 		list = nodes.List{
 			Items: []nodes.Node{*n.Relation},
 		}
 	case nodes.UpdateStmt:
+		// The target table comes first, followed by the tables listed in the
+		// FROM clause
 		list = nodes.List{
-			Items: append(n.FromClause.Items, *n.Relation),
+			Items: append([]nodes.Node{*n.Relation}, n.FromClause.Items...),
 		}
 	case nodes.SelectStmt:
-		list = search(n.FromClause, func(node nodes.Node) bool {
-			_, ok := node.(nodes.RangeVar)
-			return ok
-		})
+		list = n.FromClause
 	default:
 		return nil, fmt.Errorf("sourceTables: unsupported node type: %T", n)
 	}
 
-	var tables []core.Table
+	var tables []sourceTable
 	for _, item := range list.Items {
-		switch n := item.(type) {
-		case nodes.RangeVar:
-			fqn, err := catalog.ParseRange(&n)
-			if err != nil {
-				return nil, err
-			}
-			table, cerr := qc.GetTable(fqn)
-			if cerr != nil {
-				cerr.Location = n.Location
-				return nil, *cerr
-			}
-			if n.Alias != nil {
-				table.Name = *n.Alias.Aliasname
-			}
-			tables = append(tables, table)
-		default:
-			return nil, fmt.Errorf("sourceTable: unsupported list item type: %T", n)
+		found, err := fromItemTables(qc, item)
+		if err != nil {
+			return nil, err
 		}
+		tables = append(tables, found...)
 	}
 	return tables, nil
 }
@@ -815,13 +1259,17 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 	}
 
 	var targets nodes.List
+	nullable := map[string]struct{}{}
 	switch n := node.(type) {
+	case nodes.CreateStmt, nodes.NotifyStmt:
+		return nil, nil
 	case nodes.DeleteStmt:
 		targets = n.ReturningList
 	case nodes.InsertStmt:
 		targets = n.ReturningList
 	case nodes.SelectStmt:
 		targets = n.TargetList
+		nullable = nullableGroupingColumns(n.GroupClause)
 	case nodes.UpdateStmt:
 		targets = n.ReturningList
 	default:
@@ -901,17 +1349,22 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 						continue
 					}
 					for _, c := range t.Columns {
+						if !t.visible(scope, c) {
+							continue
+						}
 						cname := c.Name
 						if res.Name != nil {
 							cname = *res.Name
 						}
+						_, isNullable := nullable[c.Name]
 						cols = append(cols, core.Column{
-							Table:    t.ID,
-							Name:     cname,
-							Scope:    scope,
-							DataType: c.DataType,
-							NotNull:  c.NotNull,
-							IsArray:  c.IsArray,
+							Table:     t.ID,
+							Name:      cname,
+							Scope:     scope,
+							DataType:  c.DataType,
+							NotNull:   c.NotNull && !isNullable,
+							IsArray:   c.IsArray,
+							Sensitive: c.Sensitive,
 						})
 					}
 				}
@@ -922,6 +1375,13 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 			if err != nil {
 				return nil, err
 			}
+			for _, name := range groupingColumnNames(n) {
+				if _, ok := nullable[name]; ok {
+					for i := range columns {
+						columns[i].NotNull = false
+					}
+				}
+			}
 			cols = append(cols, columns...)
 
 		case nodes.FuncCall:
@@ -937,11 +1397,18 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 
 			fun, err := qc.catalog.LookupFunctionN(fqn, len(n.Args.Items))
 			if err == nil {
-				cols = append(cols, core.Column{Name: name, DataType: fun.ReturnType, NotNull: true})
+				cols = append(cols, core.Column{Name: name, DataType: fun.ReturnType, NotNull: !fun.NullableResult})
 			} else {
 				cols = append(cols, core.Column{Name: name, DataType: "any"})
 			}
 
+		case nodes.GroupingFunc:
+			name := "grouping"
+			if res.Name != nil {
+				name = *res.Name
+			}
+			cols = append(cols, core.Column{Name: name, DataType: "integer", NotNull: true})
+
 		case nodes.TypeCast:
 			if n.TypeName == nil {
 				return nil, errors.New("no type name type cast")
@@ -970,7 +1437,7 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 	return cols, nil
 }
 
-func outputColumnRefs(res nodes.ResTarget, tables []core.Table, node nodes.ColumnRef) ([]core.Column, error) {
+func outputColumnRefs(res nodes.ResTarget, tables []sourceTable, node nodes.ColumnRef) ([]core.Column, error) {
 	parts := stringSlice(node.Fields)
 	var name, alias string
 	switch {
@@ -990,18 +1457,19 @@ func outputColumnRefs(res nodes.ResTarget, tables []core.Table, node nodes.Colum
 			continue
 		}
 		for _, c := range t.Columns {
-			if c.Name == name {
+			if c.Name == name && t.visible(alias, c) {
 				found += 1
 				cname := c.Name
 				if res.Name != nil {
 					cname = *res.Name
 				}
 				cols = append(cols, core.Column{
-					Table:    t.ID,
-					Name:     cname,
-					DataType: c.DataType,
-					NotNull:  c.NotNull,
-					IsArray:  c.IsArray,
+					Table:     t.ID,
+					Name:      cname,
+					DataType:  c.DataType,
+					NotNull:   c.NotNull,
+					IsArray:   c.IsArray,
+					Sensitive: c.Sensitive,
 				})
 			}
 		}
@@ -1037,9 +1505,10 @@ type paramSearch struct {
 	refs     *[]paramRef
 	seen     map[int]struct{}
 
-	// XXX: Gross state hack for limit
-	limitCount  nodes.Node
-	limitOffset nodes.Node
+	// Locations of the parameters found in the LIMIT and OFFSET clauses of
+	// the closest SELECT statement
+	limitCount  map[int]struct{}
+	limitOffset map[int]struct{}
 }
 
 type nodeImpl struct {
@@ -1105,12 +1574,10 @@ func (p paramSearch) Visit(node nodes.Node) ast.Visitor {
 		p.parent = node
 
 	case nodes.SelectStmt:
-		if n.LimitCount != nil {
-			p.limitCount = n.LimitCount
-		}
-		if n.LimitOffset != nil {
-			p.limitOffset = n.LimitOffset
-		}
+		// Parameters may appear anywhere inside a LIMIT or OFFSET expression,
+		// e.g. OFFSET ($1 - 1) * $2
+		p.limitCount = paramLocations(n.LimitCount)
+		p.limitOffset = paramLocations(n.LimitOffset)
 
 	case nodes.TypeCast:
 		p.parent = node
@@ -1118,16 +1585,12 @@ func (p paramSearch) Visit(node nodes.Node) ast.Visitor {
 	case nodes.ParamRef:
 		parent := p.parent
 
-		if count, ok := p.limitCount.(nodes.ParamRef); ok {
-			if n.Number == count.Number {
-				parent = limitCount{}
-			}
+		if _, ok := p.limitCount[n.Location]; ok {
+			parent = limitCount{}
 		}
 
-		if offset, ok := p.limitOffset.(nodes.ParamRef); ok {
-			if n.Number == offset.Number {
-				parent = limitOffset{}
-			}
+		if _, ok := p.limitOffset[n.Location]; ok {
+			parent = limitOffset{}
 		}
 		if _, found := p.seen[n.Location]; found {
 			break
@@ -1159,6 +1622,21 @@ func (p paramSearch) Visit(node nodes.Node) ast.Visitor {
 	return p
 }
 
+func paramLocations(root nodes.Node) map[int]struct{} {
+	locs := map[int]struct{}{}
+	if root == nil {
+		return locs
+	}
+	refs := search(root, func(node nodes.Node) bool {
+		_, ok := node.(nodes.ParamRef)
+		return ok
+	})
+	for _, item := range refs.Items {
+		locs[item.(nodes.ParamRef).Location] = struct{}{}
+	}
+	return locs
+}
+
 func findParameters(root nodes.Node) []paramRef {
 	refs := make([]paramRef, 0)
 	v := paramSearch{seen: make(map[int]struct{}), refs: &refs}
@@ -1184,7 +1662,7 @@ func search(root nodes.Node, f func(nodes.Node) bool) nodes.List {
 	return ns.list
 }
 
-func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, names map[int]string) ([]Parameter, error) {
+func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, names map[int]string, using map[string]struct{}) ([]Parameter, error) {
 	aliasMap := map[string]core.FQN{}
 	// TODO: Deprecate defaultTable
 	var defaultTable *core.FQN
@@ -1201,7 +1679,7 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 		if rv.Relname == nil {
 			continue
 		}
-		fqn, err := catalog.ParseRange(&rv)
+		fqn, err := resolveRange(c, &rv)
 		if err != nil {
 			return nil, err
 		}
@@ -1305,6 +1783,11 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 					}
 				}
 
+				// An unqualified reference to a column named in a USING
+				// clause refers to the single merged column
+				_, merged := using[key]
+				merged = merged && alias == ""
+
 				var found int
 				for _, table := range search {
 					if c, ok := typeMap[table.Schema][table.Rel][key]; ok {
@@ -1315,13 +1798,17 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 						a = append(a, Parameter{
 							Number: ref.ref.Number,
 							Column: core.Column{
-								Name:     parameterName(ref.ref.Number, key),
-								DataType: c.DataType,
-								NotNull:  c.NotNull,
-								IsArray:  c.IsArray,
-								Table:    c.Table,
+								Name:      parameterName(ref.ref.Number, key),
+								DataType:  c.DataType,
+								NotNull:   c.NotNull,
+								IsArray:   c.IsArray,
+								Table:     c.Table,
+								Sensitive: c.Sensitive,
 							},
 						})
+						if merged {
+							break
+						}
 					}
 				}
 				if found == 0 {
@@ -1374,10 +1861,11 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 					})
 					continue
 				}
-				if i >= len(fun.Arguments) {
+				args := fun.InArgs()
+				if i >= len(args) {
 					return nil, fmt.Errorf("incorrect number of arguments to %s", fun.Name)
 				}
-				arg := fun.Arguments[i]
+				arg := args[i]
 				name := arg.Name
 				if name == "" {
 					name = fun.Name
@@ -1402,7 +1890,7 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 			schema := defaultTable.Schema
 			rel := defaultTable.Rel
 			if ref.rv != nil {
-				fqn, err := catalog.ParseRange(ref.rv)
+				fqn, err := resolveRange(c, ref.rv)
 				if err != nil {
 					return nil, err
 				}
@@ -1413,11 +1901,12 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 				a = append(a, Parameter{
 					Number: ref.ref.Number,
 					Column: core.Column{
-						Name:     parameterName(ref.ref.Number, key),
-						DataType: c.DataType,
-						NotNull:  c.NotNull,
-						IsArray:  c.IsArray,
-						Table:    c.Table,
+						Name:      parameterName(ref.ref.Number, key),
+						DataType:  c.DataType,
+						NotNull:   c.NotNull,
+						IsArray:   c.IsArray,
+						Table:     c.Table,
+						Sensitive: c.Sensitive,
 					},
 				})
 			} else {
@@ -1449,10 +1938,47 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 	return a, nil
 }
 
+// The types parameters are cast to. An explicit cast is the type of the
+// parameter wherever it's referenced, as the parameter of the first reference
+// isn't always the cast one:
+//
+//	WHERE id = $1 OR parent_id = $1::int
+//
+// Nested casts convert the value after it's been passed, so the innermost
+// cast is the type of the parameter.
+func paramCasts(refs []paramRef) (map[int]core.Column, error) {
+	casts := map[int]core.Column{}
+	for _, ref := range refs {
+		tc, ok := ref.parent.(nodes.TypeCast)
+		if !ok || tc.TypeName == nil {
+			continue
+		}
+		col := catalog.ToColumn(tc.TypeName)
+		if prev, ok := casts[ref.ref.Number]; ok {
+			if prev.DataType != col.DataType || prev.IsArray != col.IsArray {
+				return nil, core.Error{
+					Code:     "42P08",
+					Message:  fmt.Sprintf("inconsistent types deduced for parameter $%d", ref.ref.Number),
+					Location: tc.Location,
+				}
+			}
+			continue
+		}
+		casts[ref.ref.Number] = col
+	}
+	return casts, nil
+}
+
+// The first reference to each parameter, in the order they're written. The
+// context a parameter first appears in names it, such as LIMIT in
+// LIMIT $1 OFFSET ($2 - 1) * $1, even if the walk reaches another one first.
 func uniqueParamRefs(in []paramRef) []paramRef {
+	written := make([]paramRef, len(in))
+	copy(written, in)
+	sort.SliceStable(written, func(i, j int) bool { return written[i].ref.Location < written[j].ref.Location })
 	m := make(map[int]struct{}, len(in))
 	o := make([]paramRef, 0, len(in))
-	for _, v := range in {
+	for _, v := range written {
 		if _, ok := m[v.ref.Number]; !ok {
 			m[v.ref.Number] = struct{}{}
 			o = append(o, v)
@@ -184,3 +184,35 @@ func TestExpand(t *testing.T) {
 		t.Errorf("mismatch:\nexpected: %s\n  acutal: %s", expected, actual)
 	}
 }
+
+func TestQuoteIdentIfNeeded(t *testing.T) {
+	for name, want := range map[string]string{
+		"last_name":    "last_name",
+		"col$1":        "col$1",
+		"firstName":    `"firstName"`,
+		"ID":           `"ID"`,
+		"order":        `"order"`,
+		"1st":          `"1st"`,
+		"first name":   `"first name"`,
+		`say "hello"`:  `"say ""hello"""`,
+		"UserAccounts": `"UserAccounts"`,
+	} {
+		if got := quoteIdentIfNeeded(name); got != want {
+			t.Errorf("%s: expected %s, got %s", name, want, got)
+		}
+	}
+}
+
+func TestArgName(t *testing.T) {
+	for name, want := range map[string]string{
+		"author_id": "authorID",
+		"firstName": "firstName",
+		"UserID":    "userID",
+		"URLPath":   "urlPath",
+		"ID":        "id",
+	} {
+		if got := argName(name); got != want {
+			t.Errorf("%s: expected %s, got %s", name, want, got)
+		}
+	}
+}
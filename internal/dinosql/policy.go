@@ -0,0 +1,92 @@
+package dinosql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/catalog"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// Record the text of a CREATE POLICY or ALTER POLICY statement, already
+// applied to the catalog, with its policy. The catalog only keeps what a
+// policy applies to, as its expressions can't be printed back as SQL.
+func recordPolicySQL(c *core.Catalog, stmt nodes.Node, source string) {
+	raw, ok := stmt.(nodes.RawStmt)
+	if !ok {
+		return
+	}
+	var rv *nodes.RangeVar
+	var name string
+	switch n := raw.Stmt.(type) {
+	case nodes.CreatePolicyStmt:
+		rv, name = n.Table, *n.PolicyName
+	case nodes.AlterPolicyStmt:
+		rv, name = n.Table, *n.PolicyName
+	case nodes.RenameStmt:
+		if n.RenameType != nodes.OBJECT_POLICY {
+			return
+		}
+		rv, name = n.Relation, *n.Newname
+	default:
+		return
+	}
+	fqn, err := catalog.ParseRange(rv)
+	if err != nil {
+		return
+	}
+	table, ok := c.Schemas[fqn.Schema].Tables[fqn.Rel]
+	if !ok {
+		return
+	}
+	sql, err := pluckQuery(source, raw)
+	if err != nil {
+		return
+	}
+	for i, p := range table.Policies {
+		if p.Name == name {
+			table.Policies[i].SQL = append(p.SQL, strings.TrimSuffix(stripLeadingComments(sql), ";"))
+		}
+	}
+}
+
+// A description of the row-level security of a table, for the doc comment of
+// its model
+func securityComment(t core.Table) string {
+	if !t.RowSecurity && len(t.Policies) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	switch {
+	case t.RowSecurity && t.ForceRowSecurity:
+		b.WriteString("Row-level security is enabled, and forced for the table's owner.")
+	case t.RowSecurity:
+		b.WriteString("Row-level security is enabled.")
+	default:
+		b.WriteString("Row-level security isn't enabled, so the policies don't apply.")
+	}
+	if len(t.Policies) == 0 {
+		b.WriteString("\nThe table has no policies, so its rows can't be read or changed.")
+		return b.String()
+	}
+	b.WriteString(" Policies:\n")
+	for _, p := range t.Policies {
+		b.WriteString("\n")
+		if len(p.SQL) == 0 {
+			kind := ""
+			if !p.Permissive {
+				kind = " AS RESTRICTIVE"
+			}
+			fmt.Fprintf(&b, "\t%s%s FOR %s TO %s\n", p.Name, kind, p.Command, strings.Join(p.Roles, ", "))
+			continue
+		}
+		for _, sql := range p.SQL {
+			for _, line := range strings.Split(sql, "\n") {
+				b.WriteString("\t" + strings.TrimRight(line, " \t\r") + "\n")
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
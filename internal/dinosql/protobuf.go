@@ -0,0 +1,565 @@
+package dinosql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// The file, next to the generated code, that keeps the numbers of the fields
+// of the protobuf messages between runs
+const ProtobufFieldsFile = "protobuf_fields.json"
+
+// The field numbers of the protobuf messages, by message and field name. The
+// numbers of the fields of dropped columns are reserved, so that they're not
+// reused by the fields of new columns.
+type ProtobufFields map[string]*ProtobufMessageFields
+
+type ProtobufMessageFields struct {
+	Fields          map[string]int `json:"fields"`
+	ReservedNumbers []int          `json:"reserved_numbers,omitempty"`
+	ReservedNames   []string       `json:"reserved_names,omitempty"`
+}
+
+// A protobuf message mirroring a model
+type ProtoMessage struct {
+	Struct  GoStruct
+	Name    string
+	Comment string
+	Fields  []ProtoField
+
+	// The sensitive columns, and the columns whose Go types have no protobuf
+	// type, which are left out
+	Skipped []GoField
+
+	ReservedNumbers []int
+	ReservedNames   []string
+}
+
+type ProtoField struct {
+	Field  GoField
+	Name   string // The name of the protobuf field, e.g. first_name
+	GoName string // The name protoc-gen-go gives the field, e.g. FirstName
+	Type   string // e.g. google.protobuf.StringValue
+	Number int
+
+	// The conversions between the model field and the protobuf field, as
+	// expressions or, if they don't fit in one, statements
+	To, ToStmt     string
+	From, FromStmt string
+	FromErr        bool
+
+	typ protoType
+}
+
+// A Go type with a protobuf type. Pkgs are the Go packages used by the
+// conversions, and Import is the .proto file declaring the type.
+type protoType struct {
+	Type   string
+	Import string
+	Pkgs   []string
+
+	// Format strings for the conversions, which are assignments if empty
+	To, From string
+
+	// The conversion from protobuf returns an error
+	FromErr bool
+
+	// A nil value, such as a nil net.IP, is an empty string
+	Nilable bool
+}
+
+const (
+	timestampProto = "google/protobuf/timestamp.proto"
+	durationProto  = "google/protobuf/duration.proto"
+	wrappersProto  = "google/protobuf/wrappers.proto"
+
+	timestamppbPkg = "google.golang.org/protobuf/types/known/timestamppb"
+	durationpbPkg  = "google.golang.org/protobuf/types/known/durationpb"
+	wrapperspbPkg  = "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var protoTypes = map[string]protoType{
+	"string":           {Type: "string"},
+	"bool":             {Type: "bool"},
+	"int32":            {Type: "int32"},
+	"int64":            {Type: "int64"},
+	"float32":          {Type: "float"},
+	"float64":          {Type: "double"},
+	"[]byte":           {Type: "bytes"},
+	"int16":            {Type: "int32", To: "int32(%s)", From: "int16(%s)"},
+	"int":              {Type: "int64", To: "int64(%s)", From: "int(%s)"},
+	"json.RawMessage":  {Type: "bytes", Pkgs: []string{"encoding/json"}, To: "[]byte(%s)", From: "json.RawMessage(%s)"},
+	"time.Time":        {Type: "google.protobuf.Timestamp", Import: timestampProto, Pkgs: []string{timestamppbPkg}, To: "timestamppb.New(%s)", From: "%s.AsTime()"},
+	"time.Duration":    {Type: "google.protobuf.Duration", Import: durationProto, Pkgs: []string{durationpbPkg}, To: "durationpb.New(%s)", From: "%s.AsDuration()"},
+	"uuid.UUID":        {Type: "string", Pkgs: []string{"github.com/google/uuid"}, To: "%s.String()", From: "uuid.Parse(%s)", FromErr: true},
+	"net.IP":           {Type: "string", Pkgs: []string{"net"}, To: "%s.String()", From: "net.ParseIP(%s)", Nilable: true},
+	"net.HardwareAddr": {Type: "string", Pkgs: []string{"net"}, To: "%s.String()", From: "net.ParseMAC(%s)", FromErr: true, Nilable: true},
+}
+
+// A nullable Go type, whose values are wrapped in a message that's nil for
+// NULL. Field is the field of the Go type that holds the value.
+type protoNullType struct {
+	protoType
+	Field string
+}
+
+var protoNullTypes = map[string]protoNullType{
+	"sql.NullString":  {protoType{Type: "google.protobuf.StringValue", Import: wrappersProto, Pkgs: []string{"database/sql", wrapperspbPkg}, To: "wrapperspb.String(%s)", From: "%s.GetValue()"}, "String"},
+	"sql.NullInt32":   {protoType{Type: "google.protobuf.Int32Value", Import: wrappersProto, Pkgs: []string{"database/sql", wrapperspbPkg}, To: "wrapperspb.Int32(%s)", From: "%s.GetValue()"}, "Int32"},
+	"sql.NullInt64":   {protoType{Type: "google.protobuf.Int64Value", Import: wrappersProto, Pkgs: []string{"database/sql", wrapperspbPkg}, To: "wrapperspb.Int64(%s)", From: "%s.GetValue()"}, "Int64"},
+	"sql.NullFloat64": {protoType{Type: "google.protobuf.DoubleValue", Import: wrappersProto, Pkgs: []string{"database/sql", wrapperspbPkg}, To: "wrapperspb.Double(%s)", From: "%s.GetValue()"}, "Float64"},
+	"sql.NullBool":    {protoType{Type: "google.protobuf.BoolValue", Import: wrappersProto, Pkgs: []string{"database/sql", wrapperspbPkg}, To: "wrapperspb.Bool(%s)", From: "%s.GetValue()"}, "Bool"},
+	"sql.NullTime":    {protoType{Type: "google.protobuf.Timestamp", Import: timestampProto, Pkgs: []string{"database/sql", timestamppbPkg}, To: "timestamppb.New(%s)", From: "%s.AsTime()"}, "Time"},
+	"NullDuration":    {protoType{Type: "google.protobuf.Duration", Import: durationProto, Pkgs: []string{durationpbPkg}, To: "durationpb.New(%s)", From: "%s.AsDuration()"}, "Duration"},
+}
+
+var protoPackagePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+func protobufPackage(settings config.CombinedSettings) (string, error) {
+	pkg := settings.Go.ProtobufPackage
+	if pkg == "" {
+		pkg = settings.Go.Package
+	}
+	if !protoPackagePattern.MatchString(pkg) {
+		return "", fmt.Errorf("emit_protobuf: %q isn't a valid protobuf package", pkg)
+	}
+	return pkg, nil
+}
+
+// Generates the protobuf messages of a package with enums, by Go type name
+type protobufGen struct {
+	enums map[string]struct{}
+}
+
+// The protobuf type of a Go type, with enums sent as their string values
+func (r protobufGen) protoType(goType string) (protoType, bool) {
+	if t, ok := protoTypes[goType]; ok {
+		return t, true
+	}
+	if _, ok := r.enums[goType]; ok {
+		return protoType{Type: "string", To: "string(%s)", From: goType + "(%s)"}, true
+	}
+	return protoType{}, false
+}
+
+// The name of a protobuf field for a column. Columns are usually valid
+// protobuf identifiers already.
+func protoFieldName(column string) string {
+	var b strings.Builder
+	for i, c := range column {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+			b.WriteRune(c)
+		case c >= '0' && c <= '9':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(c)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// The name protoc-gen-go gives the Go field of a protobuf field
+func protoGoName(s string) string {
+	var b []byte
+	isLower := func(c byte) bool { return 'a' <= c && c <= 'z' }
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+		case '0' <= c && c <= '9':
+			b = append(b, c)
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func (r protobufGen) field(s GoStruct, f GoField) (ProtoField, bool) {
+	name := protoFieldName(f.Column)
+	pf := ProtoField{Field: f, Name: name, GoName: protoGoName(name)}
+	m, p := "m."+f.Name, "p.Get"+pf.GoName+"()"
+
+	if t, ok := protoNullTypes[f.Type]; ok {
+		pf.Type = t.Type
+		pf.ToStmt = fmt.Sprintf("if %s.Valid {\np.%s = %s\n}", m, pf.GoName, fmt.Sprintf(t.To, m+"."+t.Field))
+		pf.FromStmt = fmt.Sprintf("if v := %s; v != nil {\n%s = %s{%s: %s, Valid: true}\n}", p, m, f.Type, t.Field, fmt.Sprintf(t.From, "v"))
+		pf.typ = t.protoType
+		return pf, true
+	}
+
+	if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
+		t, ok := r.protoType(strings.TrimPrefix(f.Type, "[]"))
+		if !ok {
+			return pf, false
+		}
+		pf.Type, pf.typ = "repeated "+t.Type, t
+		if t.To == "" {
+			pf.To, pf.From = m, p
+			return pf, true
+		}
+		pf.ToStmt = fmt.Sprintf("for _, v := range %s {\np.%s = append(p.%s, %s)\n}", m, pf.GoName, pf.GoName, fmt.Sprintf(t.To, "v"))
+		if t.FromErr {
+			pf.FromStmt = fmt.Sprintf("for _, v := range %s {\nx, err := %s\nif err != nil {\nreturn %s{}, fmt.Errorf(\"%s: %%w\", err)\n}\n%s = append(%s, x)\n}", p, fmt.Sprintf(t.From, "v"), s.Name, name, m, m)
+		} else {
+			pf.FromStmt = fmt.Sprintf("for _, v := range %s {\n%s = append(%s, %s)\n}", p, m, m, fmt.Sprintf(t.From, "v"))
+		}
+		return pf, true
+	}
+
+	t, ok := r.protoType(f.Type)
+	if !ok {
+		return pf, false
+	}
+	pf.Type, pf.typ = t.Type, t
+	to, from := m, p
+	if t.To != "" {
+		to, from = fmt.Sprintf(t.To, m), fmt.Sprintf(t.From, p)
+	}
+	switch {
+	case t.Nilable:
+		pf.ToStmt = fmt.Sprintf("if %s != nil {\np.%s = %s\n}", m, pf.GoName, to)
+		from = fmt.Sprintf(t.From, "v")
+		if t.FromErr {
+			from = fmt.Sprintf("if %s, err = %s; err != nil {\nreturn %s{}, fmt.Errorf(\"%s: %%w\", err)\n}", m, from, s.Name, name)
+		} else {
+			from = fmt.Sprintf("%s = %s", m, from)
+		}
+		pf.FromStmt = fmt.Sprintf("if v := %s; v != \"\" {\n%s\n}", p, from)
+		pf.FromErr = t.FromErr
+	case t.FromErr:
+		pf.To = to
+		pf.FromStmt = fmt.Sprintf("if %s, err = %s; err != nil {\nreturn %s{}, fmt.Errorf(\"%s: %%w\", err)\n}", m, from, s.Name, name)
+		pf.FromErr = true
+	default:
+		pf.To, pf.From = to, from
+	}
+	return pf, true
+}
+
+// The messages for the models of the package, with their fields numbered by
+// fields, which is updated with the numbers of new fields
+func (r protobufGen) messages(structs []GoStruct, fields ProtobufFields) ([]ProtoMessage, error) {
+	var messages []ProtoMessage
+	for _, s := range structs {
+		msg := ProtoMessage{Struct: s, Name: s.Name, Comment: s.Comment}
+		goNames := map[string]string{}
+		var names []string
+		for _, f := range s.Fields {
+			pf, ok := r.field(s, f)
+			if !ok || f.Sensitive {
+				msg.Skipped = append(msg.Skipped, f)
+				continue
+			}
+			if other, ok := goNames[pf.GoName]; ok {
+				return nil, fmt.Errorf("emit_protobuf: the %s and %s columns of %s have the same protobuf field name", other, f.Column, s.Name)
+			}
+			goNames[pf.GoName] = f.Column
+			names = append(names, pf.Name)
+			msg.Fields = append(msg.Fields, pf)
+		}
+		entry, ok := fields[s.Name]
+		if !ok {
+			entry = &ProtobufMessageFields{Fields: map[string]int{}}
+			fields[s.Name] = entry
+		}
+		entry.number(names)
+		for i := range msg.Fields {
+			msg.Fields[i].Number = entry.Fields[msg.Fields[i].Name]
+		}
+		msg.ReservedNumbers, msg.ReservedNames = entry.ReservedNumbers, entry.ReservedNames
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+// Number the fields of a message. Fields keep their numbers, and the fields
+// that are gone are reserved. New fields are numbered after the highest number
+// used so far, skipping the range protobuf reserves for itself.
+func (m *ProtobufMessageFields) number(names []string) {
+	current := map[string]bool{}
+	for _, name := range names {
+		current[name] = true
+	}
+	for name, n := range m.Fields {
+		if current[name] {
+			continue
+		}
+		delete(m.Fields, name)
+		m.ReservedNumbers = append(m.ReservedNumbers, n)
+		if !containsString(m.ReservedNames, name) {
+			m.ReservedNames = append(m.ReservedNames, name)
+		}
+	}
+
+	last := 0
+	for _, n := range m.Fields {
+		if n > last {
+			last = n
+		}
+	}
+	for _, n := range m.ReservedNumbers {
+		if n > last {
+			last = n
+		}
+	}
+	for _, name := range names {
+		if _, ok := m.Fields[name]; ok {
+			continue
+		}
+		last++
+		if last >= 19000 && last <= 19999 {
+			last = 20000
+		}
+		m.Fields[name] = last
+		// A column that was dropped and added again gets a new number
+		var reserved []string
+		for _, r := range m.ReservedNames {
+			if r != name {
+				reserved = append(reserved, r)
+			}
+		}
+		m.ReservedNames = reserved
+	}
+	sort.Ints(m.ReservedNumbers)
+	sort.Strings(m.ReservedNames)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func parseProtobufFields(blob []byte) (ProtobufFields, error) {
+	fields := ProtobufFields{}
+	if len(blob) == 0 {
+		return fields, nil
+	}
+	if err := json.Unmarshal(blob, &fields); err != nil {
+		return nil, fmt.Errorf("%s: %w", ProtobufFieldsFile, err)
+	}
+	for name, m := range fields {
+		if m == nil {
+			m = &ProtobufMessageFields{}
+			fields[name] = m
+		}
+		if m.Fields == nil {
+			m.Fields = map[string]int{}
+		}
+		seen := map[int]bool{}
+		numbers := append([]int{}, m.ReservedNumbers...)
+		for _, n := range m.Fields {
+			numbers = append(numbers, n)
+		}
+		for _, n := range numbers {
+			if n < 1 {
+				return nil, fmt.Errorf("%s: message %s has the invalid field number %d", ProtobufFieldsFile, name, n)
+			}
+			if seen[n] {
+				return nil, fmt.Errorf("%s: message %s uses the field number %d twice", ProtobufFieldsFile, name, n)
+			}
+			seen[n] = true
+		}
+	}
+	return fields, nil
+}
+
+// The .proto file declaring the messages
+func protoFile(pkg, goPkg string, imports []string, messages []ProtoMessage) string {
+	var b strings.Builder
+	b.WriteString("// Code generated by sqlc. DO NOT EDIT.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n", pkg)
+	if len(imports) > 0 {
+		b.WriteString("\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&b, "import %s;\n", strconv.Quote(imp))
+		}
+	}
+	if goPkg != "" {
+		fmt.Fprintf(&b, "\noption go_package = %s;\n", strconv.Quote(goPkg))
+	}
+	for _, m := range messages {
+		b.WriteString("\n")
+		if m.Comment != "" {
+			b.WriteString(DoubleSlashComment(m.Comment) + "\n")
+		}
+		fmt.Fprintf(&b, "message %s {\n", m.Name)
+		if len(m.ReservedNumbers) > 0 {
+			numbers := make([]string, len(m.ReservedNumbers))
+			for i, n := range m.ReservedNumbers {
+				numbers[i] = strconv.Itoa(n)
+			}
+			fmt.Fprintf(&b, "  reserved %s;\n", strings.Join(numbers, ", "))
+		}
+		if len(m.ReservedNames) > 0 {
+			names := make([]string, len(m.ReservedNames))
+			for i, n := range m.ReservedNames {
+				names[i] = strconv.Quote(n)
+			}
+			fmt.Fprintf(&b, "  reserved %s;\n", strings.Join(names, ", "))
+		}
+		if len(m.ReservedNumbers)+len(m.ReservedNames) > 0 && len(m.Fields)+len(m.Skipped) > 0 {
+			b.WriteString("\n")
+		}
+		fields := m.Fields
+		for _, f := range m.Struct.Fields {
+			if len(fields) > 0 && fields[0].Field.Name == f.Name {
+				if f.Comment != "" {
+					b.WriteString("  " + strings.ReplaceAll(DoubleSlashComment(f.Comment), "\n", "\n  ") + "\n")
+				}
+				fmt.Fprintf(&b, "  %s %s = %d;\n", fields[0].Type, fields[0].Name, fields[0].Number)
+				fields = fields[1:]
+				continue
+			}
+			if f.Sensitive {
+				fmt.Fprintf(&b, "  // %s isn't included, as it's sensitive\n", f.Column)
+			} else {
+				fmt.Fprintf(&b, "  // %s isn't included, as %s has no protobuf type\n", f.Column, f.Type)
+			}
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+type protobufCtx struct {
+	Package    string
+	SourceName string
+	Messages   []ProtoMessage
+}
+
+// The model's FromProto function can fail, such as for an invalid UUID
+func (m ProtoMessage) FromErr() bool {
+	for _, f := range m.Fields {
+		if f.FromErr {
+			return true
+		}
+	}
+	return false
+}
+
+// Generate a .proto file with a message for each model. The numbers of the
+// fields are kept in fields, the contents of the ProtobufFieldsFile written by
+// the previous run, which is returned with the numbers of new fields. If the
+// protobuf_go_package setting is set, the package gets functions converting
+// the models to and from the types protoc-gen-go generates for the messages.
+func GenerateProtobuf(r Generateable, settings config.CombinedSettings, fields []byte) (map[string]string, error) {
+	pkg, err := protobufPackage(settings)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := parseProtobufFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	gen := protobufGen{enums: map[string]struct{}{}}
+	for _, e := range r.Enums(settings) {
+		gen.enums[e.Name] = struct{}{}
+	}
+	structs := r.Structs(settings)
+	messages, err := gen.messages(structs, numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	protoImports := map[string]struct{}{}
+	goImports := map[string]struct{}{}
+	for _, m := range messages {
+		for _, f := range m.Fields {
+			if f.typ.Import != "" {
+				protoImports[f.typ.Import] = struct{}{}
+			}
+			for _, p := range f.typ.Pkgs {
+				goImports[p] = struct{}{}
+			}
+			if f.typ.FromErr {
+				goImports["fmt"] = struct{}{}
+			}
+		}
+	}
+	var imports []string
+	for imp := range protoImports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+
+	blob, err := json.MarshalIndent(numbers, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	base := pkg[strings.LastIndex(pkg, ".")+1:]
+	files := map[string]string{
+		base + ".proto":    protoFile(pkg, settings.Go.ProtobufGoPackage, imports, messages),
+		ProtobufFieldsFile: string(blob) + "\n",
+	}
+	if settings.Go.ProtobufGoPackage == "" || len(messages) == 0 {
+		return files, nil
+	}
+
+	var std, dep []string
+	for p := range goImports {
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			dep = append(dep, strconv.Quote(p))
+		} else {
+			std = append(std, strconv.Quote(p))
+		}
+	}
+	// go_package may name the package after the import path, as in
+	// "example.com/api/v1;apiv1"
+	pbPath := strings.SplitN(settings.Go.ProtobufGoPackage, ";", 2)[0]
+	dep = append(dep, "pb "+strconv.Quote(pbPath))
+	sort.Strings(std)
+	sort.Strings(dep)
+	funcMap := template.FuncMap{
+		"lowerTitle": LowerTitle,
+		"comment":    DoubleSlashComment,
+		"imports":    func(string) [][]string { return [][]string{std, dep} },
+		"quoteIdent": quoteIdent,
+	}
+	tmpl := template.Must(template.New("table").Funcs(funcMap).Parse(templateSet))
+	ctx := protobufCtx{
+		Package:    settings.Go.Package,
+		SourceName: "protobuf.go",
+		Messages:   messages,
+	}
+	var b bytes.Buffer
+	w := bufio.NewWriter(&b)
+	err = tmpl.ExecuteTemplate(w, "protobufFile", &ctx)
+	w.Flush()
+	if err != nil {
+		return nil, err
+	}
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("source error: %w", err)
+	}
+	files["protobuf.go"] = string(code)
+	return files, nil
+}
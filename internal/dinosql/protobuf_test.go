@@ -0,0 +1,61 @@
+package dinosql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProtobufFieldNumbers(t *testing.T) {
+	m := &ProtobufMessageFields{Fields: map[string]int{"id": 1, "bio": 2, "name": 3}}
+	m.number([]string{"id", "name", "email"})
+	if want := map[string]int{"id": 1, "name": 3, "email": 4}; !reflect.DeepEqual(m.Fields, want) {
+		t.Errorf("expected fields %v, got %v", want, m.Fields)
+	}
+	if !reflect.DeepEqual(m.ReservedNumbers, []int{2}) || !reflect.DeepEqual(m.ReservedNames, []string{"bio"}) {
+		t.Errorf("expected bio to be reserved, got %v %v", m.ReservedNumbers, m.ReservedNames)
+	}
+
+	// A column added again doesn't get its old number back
+	m.number([]string{"id", "name", "email", "bio"})
+	if m.Fields["bio"] != 5 {
+		t.Errorf("expected bio to be 5, got %d", m.Fields["bio"])
+	}
+	if !reflect.DeepEqual(m.ReservedNumbers, []int{2}) || len(m.ReservedNames) != 0 {
+		t.Errorf("expected only 2 to be reserved, got %v %v", m.ReservedNumbers, m.ReservedNames)
+	}
+
+	m = &ProtobufMessageFields{Fields: map[string]int{"id": 18999}}
+	m.number([]string{"id", "name"})
+	if m.Fields["name"] != 20000 {
+		t.Errorf("expected name to skip the reserved range, got %d", m.Fields["name"])
+	}
+}
+
+func TestParseProtobufFields(t *testing.T) {
+	for _, blob := range []string{
+		`{"Author": {"fields": {"id": 1, "name": 1}}}`,
+		`{"Author": {"fields": {"id": 1}, "reserved_numbers": [1]}}`,
+		`{"Author": {"fields": {"id": 0}}}`,
+		`{"Author": []}`,
+	} {
+		if _, err := parseProtobufFields([]byte(blob)); err == nil {
+			t.Errorf("%s: expected an error", blob)
+		}
+	}
+}
+
+func TestProtoGoName(t *testing.T) {
+	for name, want := range map[string]string{
+		"id":         "Id",
+		"account_id": "AccountId",
+		"firstName":  "FirstName",
+		"_private":   "XPrivate",
+		"ip_v4":      "IpV4",
+		"line_2":     "Line_2",
+		"a__b":       "A_B",
+	} {
+		if got := protoGoName(name); got != want {
+			t.Errorf("%s: expected %s, got %s", name, want, got)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package dinosql
+
+import (
+	core "github.com/kyleconroy/sqlc/internal/pg"
+	"github.com/kyleconroy/sqlc/internal/sql/token"
+)
+
+// Redshift is based on PostgreSQL 8.0. Its tables take storage clauses that
+// PostgreSQL doesn't have, but the columns are declared the same way.
+
+// Blank out the Redshift storage clauses of a schema, so that it parses as
+// PostgreSQL:
+//
+//   - ENCODE, DISTKEY, SORTKEY and IDENTITY(seed, step) column attributes
+//   - DISTSTYLE, DISTKEY (...), [COMPOUND | INTERLEAVED] SORTKEY (...),
+//     SORTKEY AUTO, ENCODE AUTO and BACKUP YES | NO table attributes
+//   - the MAX length of VARCHAR(MAX) and VARBYTE(MAX) columns
+//
+// The clauses are replaced with spaces, so the positions in error messages
+// still point at the original schema.
+func RemoveRedshiftClauses(contents string) string {
+	b := []byte(contents)
+	tokens := token.Split(contents)
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		element := i > 0 && (tokens[i-1].Text == "(" || tokens[i-1].Text == ",")
+		switch {
+		case i > 0 && tokens.At(i, "MAX", ")") && tokens[i-1].Text == "(":
+			token.Blank(b, tokens[i-1].Start, tokens[i+1].End)
+			i++
+
+		case element:
+			// A column named like an attribute, such as sortkey, starts a
+			// table element
+
+		case tokens.At(i, "ENCODE") && i+1 < len(tokens) && tokens[i+1].Text != "(",
+			tokens.At(i, "DISTSTYLE") && i+1 < len(tokens),
+			tokens.At(i, "BACKUP") && i+1 < len(tokens),
+			tokens.At(i, "SORTKEY", "AUTO"):
+			token.Blank(b, t.Start, tokens[i+1].End)
+			i++
+
+		case tokens.At(i, "COMPOUND", "SORTKEY", "("), tokens.At(i, "INTERLEAVED", "SORTKEY", "("):
+			end := tokens.Closing(i + 2)
+			token.Blank(b, t.Start, tokens[end].End)
+			i = end
+
+		case tokens.At(i, "DISTKEY", "("), tokens.At(i, "SORTKEY", "("), tokens.At(i, "IDENTITY", "("):
+			end := tokens.Closing(i + 1)
+			token.Blank(b, t.Start, tokens[end].End)
+			i = end
+
+		case tokens.At(i, "DISTKEY"), tokens.At(i, "SORTKEY"):
+			token.Blank(b, t.Start, t.End)
+		}
+	}
+	return string(b)
+}
+
+// The Redshift functions that PostgreSQL doesn't have
+func addRedshiftFunctions(c *core.Catalog) {
+	schema := c.Schemas["pg_catalog"]
+	for _, f := range []core.Function{
+		{Name: "getdate", ReturnType: "pg_catalog.timestamp"},
+		{Name: "json_extract_path_text", ArgN: 2, ReturnType: "text"},
+		{Name: "json_parse", ArgN: 1, ReturnType: "super"},
+	} {
+		schema.Funcs[f.Name] = append(schema.Funcs[f.Name], f)
+	}
+}
+
+// SUPER holds semi-structured data, which the driver reads as JSON, and
+// VARBYTE is a binary string
+func redshiftType(dataType string) string {
+	switch dataType {
+	case "super":
+		return "jsonb"
+	case "varbyte", "varbinary", "binary varying":
+		return "pg_catalog.bytea"
+	}
+	return dataType
+}
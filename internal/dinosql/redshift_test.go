@@ -0,0 +1,38 @@
+package dinosql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoveRedshiftClauses(t *testing.T) {
+	for _, tc := range []struct {
+		input  string
+		output string
+	}{
+		{
+			"CREATE TABLE foo (id INT IDENTITY(1, 1) ENCODE az64 DISTKEY, name VARCHAR(MAX) ENCODE zstd SORTKEY);",
+			"CREATE TABLE foo (id INT , name VARCHAR );",
+		},
+		{
+			"CREATE TABLE foo (a INT, b INT) BACKUP NO DISTSTYLE KEY DISTKEY (a) COMPOUND SORTKEY (a, b);",
+			"CREATE TABLE foo (a INT, b INT) ;",
+		},
+		{
+			"CREATE TABLE foo (a INT) DISTSTYLE AUTO SORTKEY AUTO ENCODE AUTO;",
+			"CREATE TABLE foo (a INT) ;",
+		},
+		{
+			"CREATE TABLE foo (sortkey INT, encode TEXT DEFAULT encode('a', 'hex'), backup BOOL);",
+			"CREATE TABLE foo (sortkey INT, encode TEXT DEFAULT encode('a', 'hex'), backup BOOL);",
+		},
+	} {
+		actual := RemoveRedshiftClauses(tc.input)
+		if len(actual) != len(tc.input) {
+			t.Errorf("%s: length changed from %d to %d", tc.input, len(tc.input), len(actual))
+		}
+		if got, want := strings.Join(strings.Fields(actual), " "), strings.Join(strings.Fields(tc.output), " "); got != want {
+			t.Errorf("%s:\nwant: %s\n got: %s", tc.input, want, got)
+		}
+	}
+}
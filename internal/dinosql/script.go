@@ -0,0 +1,275 @@
+package dinosql
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// A statement of an :execscript query. Each statement is sent on its own, as
+// PostgreSQL doesn't accept several statements with parameters at once, so
+// its parameters are numbered from $1.
+type ScriptStatement struct {
+	SQL string
+
+	// The numbers of the query's parameters passed to the statement, in order
+	Params []int
+}
+
+// The number of statements of the :execscript query starting at stmts[0]. The
+// statements that follow it belong to the script until the next named query.
+func scriptLen(stmts []nodes.Node, source string) int {
+	for i := 1; i < len(stmts); i++ {
+		if name, _, err := statementMetadata(stmts[i], source); name != "" || err != nil {
+			return i
+		}
+	}
+	return len(stmts)
+}
+
+func statementMetadata(stmt nodes.Node, source string) (string, string, error) {
+	raw, ok := stmt.(nodes.RawStmt)
+	if !ok {
+		return "", "", nil
+	}
+	rawSQL, err := pluckQuery(source, raw)
+	if err != nil {
+		return "", "", err
+	}
+	return ParseMetadata(strings.TrimSpace(rawSQL), CommentSyntaxDash)
+}
+
+// Parse the statements of an :execscript query, which runs them in order with
+// the parameters they share:
+//
+//	-- name: UpdateAuthorAs :execscript
+//	SELECT set_config('app.user_id', @user_id, true);
+//	UPDATE authors SET bio = @bio WHERE id = @id;
+func parseScript(c core.Catalog, stmts []nodes.Node, source string, calls []int, opts ParserOpts) (*Query, error) {
+	first, ok := stmts[0].(nodes.RawStmt)
+	if !ok {
+		return nil, errors.New("node is not a statement")
+	}
+	rawSQL, err := pluckQuery(source, first)
+	if err != nil {
+		return nil, err
+	}
+	head := strings.TrimSpace(rawSQL)
+	name, cmd, err := ParseMetadata(head, CommentSyntaxDash)
+	if err != nil {
+		return nil, err
+	}
+	if opts.UsePositionalParameters {
+		return nil, fmt.Errorf("script %q: the :execscript command is only supported for Go", name)
+	}
+	sortSpec, err := parseSort(head)
+	if err != nil {
+		return nil, err
+	}
+	if sortSpec != nil {
+		return nil, fmt.Errorf("script %q can't have a sort annotation", name)
+	}
+	method, err := parseMethod(head)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := parseTimeout(head)
+	if err != nil {
+		return nil, err
+	}
+	readOnly, ok, err := parseReadOnly(head)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		readOnly = opts.ReadOnly
+	}
+
+	var params []Parameter
+	var script []ScriptStatement
+	var comments []string
+	var sessionVars []SessionVar
+	names := map[string]int{}
+	var positional, named bool
+	for i, stmt := range stmts {
+		raw, ok := stmt.(nodes.RawStmt)
+		if !ok {
+			return nil, errors.New("node is not a statement")
+		}
+		if err := validateParamStyle(raw); err != nil {
+			return nil, err
+		}
+		rawSQL, err := pluckQuery(source, raw)
+		if err != nil {
+			return nil, err
+		}
+		if rawSQL == "" {
+			return nil, errors.New("missing semicolon at end of file")
+		}
+		callAt, call := statementLocation(raw, calls)
+		if call {
+			if _, err := lookupProcedure(c, raw.Stmt); err != nil {
+				return nil, err
+			}
+		}
+		if readOnly {
+			if call {
+				return nil, fmt.Errorf("read-only query %q performs CALL", name)
+			}
+			if err := validateReadOnly(raw.Stmt, name); err != nil {
+				return nil, err
+			}
+		}
+		if err := validateFuncCall(&c, raw); err != nil {
+			return nil, err
+		}
+
+		raw, namedParams, edits := rewriteNamedParameters(raw)
+		refs := findParameters(raw.Stmt)
+		named = named || len(namedParams) > 0
+		positional = positional || len(namedParams) == 0 && len(refs) > 0
+		if named && positional {
+			return nil, fmt.Errorf("script %q mixes positional parameters ($1) and named parameters (sqlc.arg or @arg)", name)
+		}
+		stmtParams, err := scriptParams(c, raw, refs, namedParams, name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Named parameters are numbered by the first statement to use them.
+		// Positional parameters keep their number in the script, and are
+		// numbered from $1 in the statement.
+		var args []int
+		for j, p := range stmtParams {
+			number := p.Number
+			if n, ok := namedParams[p.Number]; ok {
+				if number, ok = names[n]; !ok {
+					number = len(names) + 1
+					names[n] = number
+				}
+			} else if p.Number != j+1 {
+				for _, ref := range refs {
+					if ref.ref.Number == p.Number {
+						edits = append(edits, edit{
+							Location: ref.ref.Location - raw.StmtLocation,
+							Old:      fmt.Sprintf("$%d", p.Number),
+							New:      fmt.Sprintf("$%d", j+1),
+						})
+					}
+				}
+			}
+			args = append(args, number)
+			if !hasParam(params, number) {
+				p.Number = number
+				params = append(params, p)
+			}
+		}
+
+		if call {
+			edits = append(edits, edit{Location: callAt - raw.StmtLocation, Old: "SELECT", New: "CALL"})
+		}
+		edited, err := editQuery(rawSQL, edits)
+		if err != nil {
+			return nil, err
+		}
+		trimmed, stmtComments, err := stripComments(strings.TrimSpace(edited))
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			comments = stmtComments
+		}
+		script = append(script, ScriptStatement{SQL: trimmed, Params: args})
+		sessionVars = append(sessionVars, findSessionVars(raw.Stmt)...)
+	}
+
+	sort.Slice(params, func(i, j int) bool { return params[i].Number < params[j].Number })
+	for i, p := range params {
+		if p.Number != i+1 {
+			return nil, core.Error{
+				Code:    "42P18",
+				Message: fmt.Sprintf("could not determine data type of parameter $%d", i+1),
+			}
+		}
+	}
+	paramTypes, unusedParams, err := parseParamTypes(head, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{
+		Cmd:         cmd,
+		Comments:    comments,
+		Method:      method,
+		ParamTypes:  paramTypes,
+		Timeout:     timeout,
+		Name:        name,
+		Params:      params,
+		SQL:         script[0].SQL,
+		Script:      script,
+		SessionVars: sessionVars,
+		Warnings:    unusedParamWarnings(name, unusedParams),
+	}, nil
+}
+
+// The parameters of a statement of a script, in order, numbered like the
+// statement. Only SELECT, INSERT, UPDATE and DELETE statements can have
+// parameters; the others, such as SET LOCAL, are sent as they're written.
+func scriptParams(c core.Catalog, raw nodes.RawStmt, refs []paramRef, names map[int]string, script string) ([]Parameter, error) {
+	switch n := raw.Stmt.(type) {
+	case nodes.SelectStmt:
+	case nodes.DeleteStmt:
+	case nodes.InsertStmt:
+		if err := validateInsertStmt(n); err != nil {
+			return nil, err
+		}
+	case nodes.UpdateStmt:
+	default:
+		if len(refs) > 0 {
+			return nil, fmt.Errorf("script %q: only SELECT, INSERT, UPDATE and DELETE statements can have parameters", script)
+		}
+		return nil, nil
+	}
+
+	casts, err := paramCasts(refs)
+	if err != nil {
+		return nil, err
+	}
+	refs = uniqueParamRefs(refs)
+	sort.Slice(refs, func(i, j int) bool { return refs[i].ref.Number < refs[j].ref.Number })
+	params, err := resolveCatalogRefs(c, rangeVars(raw.Stmt), refs, names, usingColumns(raw.Stmt))
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range params {
+		if col, ok := casts[p.Number]; ok {
+			params[i].Column.DataType = col.DataType
+			params[i].Column.NotNull = col.NotNull
+			params[i].Column.IsArray = col.IsArray
+		}
+	}
+
+	// The columns aren't returned, but they must exist
+	qc, err := buildQueryCatalog(c, raw.Stmt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := outputColumns(qc, raw.Stmt); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func hasParam(params []Parameter, number int) bool {
+	for _, p := range params {
+		if p.Number == number {
+			return true
+		}
+	}
+	return false
+}
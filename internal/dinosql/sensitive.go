@@ -0,0 +1,112 @@
+package dinosql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// A column is sensitive if its comment contains this word:
+//
+//	COMMENT ON COLUMN users.password_hash IS '@sensitive';
+const sensitiveMarker = "@sensitive"
+
+// The tag added to sensitive fields when sensitive_tag isn't set
+const defaultSensitiveTag = "log"
+
+func sensitiveTag(settings config.CombinedSettings) string {
+	if settings.Go.SensitiveTag != "" {
+		return settings.Go.SensitiveTag
+	}
+	return defaultSensitiveTag
+}
+
+func validateSensitiveSettings(settings config.CombinedSettings) error {
+	tag := settings.Go.SensitiveTag
+	if strings.ContainsAny(tag, ":\"` ") {
+		return fmt.Errorf("invalid sensitive_tag: %q", tag)
+	}
+	return nil
+}
+
+// Mark the columns whose comment contains @sensitive, and the columns listed
+// as [schema.]table.column, as sensitive. The catalog may be shared by
+// several packages, so the tables that change are copied rather than updated
+// in place.
+func MarkSensitive(c core.Catalog, columns []string) (core.Catalog, error) {
+	listed := map[core.FQN]map[string]bool{}
+	for _, spec := range columns {
+		parts := strings.Split(spec, ".")
+		var fqn core.FQN
+		switch len(parts) {
+		case 2:
+			fqn = core.FQN{Schema: "public", Rel: parts[0]}
+		case 3:
+			fqn = core.FQN{Schema: parts[0], Rel: parts[1]}
+		default:
+			return c, fmt.Errorf("sensitive column %q is not the proper format, expected '[schema.]table.column'", spec)
+		}
+		name := parts[len(parts)-1]
+		found := false
+		for _, col := range c.Schemas[fqn.Schema].Tables[fqn.Rel].Columns {
+			found = found || col.Name == name
+		}
+		if !found {
+			return c, fmt.Errorf("sensitive column %q does not exist", spec)
+		}
+		if listed[fqn] == nil {
+			listed[fqn] = map[string]bool{}
+		}
+		listed[fqn][name] = true
+	}
+
+	marked := c
+	marked.Schemas = make(map[string]core.Schema, len(c.Schemas))
+	for name, schema := range c.Schemas {
+		marked.Schemas[name] = schema
+		if name == "pg_catalog" {
+			continue
+		}
+		var tables map[string]core.Table
+		for rel, table := range schema.Tables {
+			fqn := core.FQN{Schema: name, Rel: rel}
+			var cols []core.Column
+			for i, col := range table.Columns {
+				if !listed[fqn][col.Name] && !hasSensitiveMarker(col.Comment) {
+					continue
+				}
+				if cols == nil {
+					cols = append([]core.Column{}, table.Columns...)
+				}
+				cols[i].Sensitive = true
+			}
+			if cols == nil {
+				continue
+			}
+			if tables == nil {
+				tables = make(map[string]core.Table, len(schema.Tables))
+				for k, v := range schema.Tables {
+					tables[k] = v
+				}
+			}
+			table.Columns = cols
+			tables[rel] = table
+		}
+		if tables != nil {
+			schema.Tables = tables
+			marked.Schemas[name] = schema
+		}
+	}
+	return marked, nil
+}
+
+func hasSensitiveMarker(comment string) bool {
+	for _, word := range strings.Fields(comment) {
+		if strings.TrimRight(word, ".,;") == sensitiveMarker {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package dinosql
+
+import (
+	"testing"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+func TestMarkSensitive(t *testing.T) {
+	c := core.NewCatalog()
+	c.Schemas["public"].Tables["users"] = core.Table{
+		Name: "users",
+		Columns: []core.Column{
+			{Name: "id"},
+			{Name: "password_hash", Comment: "@sensitive"},
+			{Name: "api_token"},
+		},
+	}
+
+	marked, err := MarkSensitive(c, []string{"users.api_token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []bool{false, true, true} {
+		col := marked.Schemas["public"].Tables["users"].Columns[i]
+		if col.Sensitive != want {
+			t.Errorf("%s: expected sensitive %t", col.Name, want)
+		}
+	}
+	// The catalog may be shared with other packages
+	for _, col := range c.Schemas["public"].Tables["users"].Columns {
+		if col.Sensitive {
+			t.Errorf("%s: the original catalog was modified", col.Name)
+		}
+	}
+
+	for _, spec := range []string{"users.email", "accounts.id", "api_token"} {
+		if _, err := MarkSensitive(c, []string{spec}); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
+
+func TestHasSensitiveMarker(t *testing.T) {
+	for comment, want := range map[string]bool{
+		"@sensitive":               true,
+		"bcrypt hash, @sensitive.": true,
+		"not @sensitively":         false,
+		"":                         false,
+	} {
+		if got := hasSensitiveMarker(comment); got != want {
+			t.Errorf("%q: expected %t, got %t", comment, want, got)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package dinosql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/catalog"
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+	"github.com/kyleconroy/sqlc/internal/postgresql/ast"
+
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// A session variable read by a query with current_setting, such as
+// app.tenant_id. Only customized options, which have a dot in their name, are
+// session variables; current_setting('search_path') isn't one.
+type SessionVar struct {
+	Name string
+
+	// The type the setting is cast to, or text
+	Column core.Column
+	Cast   bool
+}
+
+// The session variables read by a statement
+func findSessionVars(root nodes.Node) []SessionVar {
+	var vars []SessionVar
+	seen := map[int]bool{}
+	ast.Walk(ast.VisitorFunc(func(node nodes.Node) {
+		switch n := node.(type) {
+		case nodes.TypeCast:
+			// Casts are visited before the calls they wrap
+			if fun, ok := n.Arg.(nodes.FuncCall); ok && n.TypeName != nil {
+				if name, ok := sessionVarName(fun); ok {
+					seen[fun.Location] = true
+					vars = append(vars, SessionVar{Name: name, Column: catalog.ToColumn(n.TypeName), Cast: true})
+				}
+			}
+		case nodes.FuncCall:
+			if name, ok := sessionVarName(n); ok && !seen[n.Location] {
+				vars = append(vars, SessionVar{Name: name, Column: core.Column{DataType: "text"}})
+			}
+		}
+	}), root)
+	return vars
+}
+
+func sessionVarName(fun nodes.FuncCall) (string, bool) {
+	fqn, err := catalog.ParseList(fun.Funcname)
+	if err != nil || fqn.Rel != "current_setting" || (fqn.Schema != "public" && fqn.Schema != "pg_catalog") {
+		return "", false
+	}
+	if len(fun.Args.Items) == 0 {
+		return "", false
+	}
+	arg, ok := fun.Args.Items[0].(nodes.A_Const)
+	if !ok {
+		return "", false
+	}
+	name, ok := arg.Val.(nodes.String)
+	if !ok || !strings.Contains(name.Str, ".") {
+		return "", false
+	}
+	return name.Str, true
+}
+
+// A session variable of a query, with its Go type
+type GoSessionVar struct {
+	Name string
+	Type string
+	Cast bool
+}
+
+// The Set method generated for a session variable, which sets it for the rest
+// of a transaction
+type GoSessionSetter struct {
+	Name       string // e.g. app.tenant_id
+	MethodName string // e.g. SetAppTenantID
+	Type       string
+}
+
+func sessionSetterName(name string, settings config.CombinedSettings) string {
+	return "Set" + StructName(strings.ReplaceAll(name, ".", "_"), settings)
+}
+
+// The setters of the session variables read by queries, ordered by name. A
+// variable that's cast is set with the type of its cast; one that's cast to
+// different types is set as a string.
+func sessionSetters(gq []GoQuery, settings config.CombinedSettings) []GoSessionSetter {
+	types := map[string]string{}
+	casts := map[string]bool{}
+	for _, q := range gq {
+		for _, v := range q.SessionVars {
+			typ, ok := types[v.Name]
+			switch {
+			case !ok || !casts[v.Name] && v.Cast:
+				types[v.Name] = v.Type
+			case v.Cast && typ != v.Type:
+				types[v.Name] = "string"
+			}
+			casts[v.Name] = casts[v.Name] || v.Cast
+		}
+	}
+	setters := make([]GoSessionSetter, 0, len(types))
+	for name, typ := range types {
+		setters = append(setters, GoSessionSetter{
+			Name:       name,
+			MethodName: sessionSetterName(name, settings),
+			Type:       typ,
+		})
+	}
+	sort.Slice(setters, func(i, j int) bool { return setters[i].Name < setters[j].Name })
+	return setters
+}
+
+func validateSessionSetters(r Generateable, settings config.CombinedSettings) error {
+	gq := r.GoQueries(settings)
+	methods := map[string]string{}
+	for _, s := range sessionSetters(gq, settings) {
+		if other, ok := methods[s.MethodName]; ok {
+			return fmt.Errorf("session variables %s and %s both have a setter named %s", other, s.Name, s.MethodName)
+		}
+		methods[s.MethodName] = s.Name
+		for _, q := range gq {
+			if q.MethodName == s.MethodName {
+				return fmt.Errorf("query %s conflicts with the setter of session variable %s", q.MethodName, s.Name)
+			}
+		}
+	}
+	return nil
+}
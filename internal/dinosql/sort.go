@@ -0,0 +1,152 @@
+package dinosql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	nodes "github.com/lfittl/pg_query_go/nodes"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// Identifier that replaces the first ORDER BY column of a sorted query. The
+// generated code swaps it for one of the whitelisted columns at runtime.
+const sortMarker = "sqlc_sort"
+
+// The columns a query can be sorted by, declared using a sort annotation:
+//
+//	-- name: ListBooks :many
+//	-- sort: title, published_at
+//	SELECT * FROM books ORDER BY title;
+//
+// The first ORDER BY column is the default and must be one of the listed
+// columns.
+type Sort struct {
+	Columns []string
+
+	// The first ORDER BY column as it's written in the query, which the
+	// marker replaces
+	Default string
+}
+
+func parseSort(t string) (*Sort, error) {
+	for _, line := range strings.Split(t, "\n") {
+		if !strings.HasPrefix(line, "-- sort:") {
+			continue
+		}
+		var s Sort
+		for _, col := range strings.Split(strings.TrimPrefix(line, "-- sort:"), ",") {
+			col = strings.TrimSpace(col)
+			if col == "" {
+				return nil, fmt.Errorf("invalid sort annotation: %q", line)
+			}
+			s.Columns = append(s.Columns, col)
+		}
+		return &s, nil
+	}
+	return nil, nil
+}
+
+// The SQL of the query sorted by its first ORDER BY column, which needn't be
+// the first column of the sort annotation
+func (q Query) DefaultSQL() string {
+	if q.Sort == nil {
+		return q.SQL
+	}
+	return strings.Replace(q.SQL, sortMarker, q.Sort.Default, 1)
+}
+
+func (s Sort) allows(name string) bool {
+	for _, col := range s.Columns {
+		if col == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify that the sorted query orders its results by one of the whitelisted
+// columns, and return the edit that replaces that column with the sort marker.
+// The column is recorded as the default of the sort.
+func sortEdit(qc *QueryCatalog, raw nodes.RawStmt, rawSQL, name, cmd string, s *Sort) (edit, error) {
+	if !(cmd == ":many" || cmd == ":one") {
+		return edit{}, fmt.Errorf("query %q specifies a sort annotation, which requires :one or :many", name)
+	}
+	stmt, ok := raw.Stmt.(nodes.SelectStmt)
+	if !ok {
+		return edit{}, fmt.Errorf("query %q specifies a sort annotation, which is only supported for SELECT statements", name)
+	}
+	if len(stmt.SortClause.Items) == 0 {
+		return edit{}, fmt.Errorf("query %q specifies a sort annotation without an ORDER BY clause", name)
+	}
+
+	tables, err := sourceTables(qc, stmt)
+	if err != nil {
+		return edit{}, err
+	}
+	cols, err := outputColumns(qc, stmt)
+	if err != nil {
+		return edit{}, err
+	}
+	for _, col := range s.Columns {
+		if err := findSortColumn(col, tables, cols); err != nil {
+			return edit{}, err
+		}
+	}
+
+	sortBy, ok := stmt.SortClause.Items[0].(nodes.SortBy)
+	if !ok {
+		return edit{}, errors.New("sortEdit: expected SortBy node")
+	}
+	ref, ok := sortBy.Node.(nodes.ColumnRef)
+	if !ok {
+		return edit{}, fmt.Errorf("the first ORDER BY item of query %q must be a column", name)
+	}
+	if sortBy.SortbyDir != nodes.SORTBY_DEFAULT || sortBy.SortbyNulls != nodes.SORTBY_NULLS_DEFAULT {
+		return edit{}, fmt.Errorf("the first ORDER BY column of query %q must not specify a sort direction", name)
+	}
+	def := strings.Join(stringSlice(ref.Fields), ".")
+	if !s.allows(def) {
+		return edit{}, fmt.Errorf("the first ORDER BY column of query %q must be listed in the sort annotation: %q", name, def)
+	}
+
+	loc := ref.Location - raw.StmtLocation
+	if loc+len(def) > len(rawSQL) || !strings.EqualFold(rawSQL[loc:loc+len(def)], def) {
+		return edit{}, fmt.Errorf("the first ORDER BY column of query %q must not be quoted", name)
+	}
+	s.Default = rawSQL[loc : loc+len(def)]
+	return edit{
+		Location: loc,
+		Old:      s.Default,
+		New:      sortMarker,
+	}, nil
+}
+
+func findSortColumn(name string, tables []sourceTable, output []core.Column) error {
+	scope, col := "", name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		scope, col = name[:i], name[i+1:]
+	}
+	if scope == "" {
+		for _, c := range output {
+			if c.Name == col {
+				return nil
+			}
+		}
+	}
+	for _, t := range tables {
+		if scope != "" && scope != t.Name {
+			continue
+		}
+		for _, c := range t.Columns {
+			if c.Name == col && t.visible(scope, c) {
+				return nil
+			}
+		}
+	}
+	return core.Error{
+		Code:    "42703",
+		Message: fmt.Sprintf("sort column \"%s\" does not exist", name),
+	}
+}
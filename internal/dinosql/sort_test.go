@@ -0,0 +1,54 @@
+package dinosql
+
+import (
+	"testing"
+
+	"github.com/kyleconroy/sqlc/internal/catalog"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+
+	pg "github.com/lfittl/pg_query_go"
+)
+
+func TestDefaultSQL(t *testing.T) {
+	c := core.NewCatalog()
+	tree, err := pg.Parse("CREATE TABLE books (id bigserial PRIMARY KEY, title text NOT NULL);")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range tree.Statements {
+		if err := catalog.Update(&c, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		query string
+		sql   string
+	}{
+		{
+			"-- name: ListBooks :many\n-- sort: title, id\nSELECT id FROM books ORDER BY title;",
+			"SELECT id FROM books ORDER BY title",
+		},
+		{
+			"-- name: ListBooks :many\n-- sort: title, id\nSELECT id FROM books ORDER BY id, title;",
+			"SELECT id FROM books ORDER BY id, title",
+		},
+		{
+			"-- name: ListBooks :many\n-- sort: books.title, id\nSELECT id FROM books ORDER BY BOOKS.TITLE;",
+			"SELECT id FROM books ORDER BY BOOKS.TITLE",
+		},
+	} {
+		tree, err := pg.Parse(tc.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := parseQuery(c, tree.Statements[0], tc.query, ParserOpts{})
+		if err != nil {
+			t.Errorf("%s: %s", tc.query, err)
+			continue
+		}
+		if got := q.DefaultSQL(); got != tc.sql {
+			t.Errorf("%s:\nwant: %s\n got: %s", tc.query, tc.sql, got)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package dinosql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	pg "github.com/lfittl/pg_query_go"
+	nodes "github.com/lfittl/pg_query_go/nodes"
+
+	"github.com/kyleconroy/sqlc/internal/parallel"
+)
+
+// Files smaller than this are parsed in a single call. Splitting only pays off
+// for large schema dumps.
+const batchThreshold = 256 * 1024
+
+// Parse SQL into statements. Large inputs are split at statement boundaries
+// and the pieces are parsed concurrently, as each call to pg_query parses and
+// serializes the whole input on a single thread.
+func parseSQL(contents string) (pg.ParsetreeList, error) {
+	workers := runtime.GOMAXPROCS(0)
+	if len(contents) < batchThreshold || workers == 1 {
+		return pg.Parse(contents)
+	}
+	return parseBatches(contents, splitBatches(contents, workers))
+}
+
+func parseBatches(contents string, batches []batch) (pg.ParsetreeList, error) {
+	if len(batches) < 2 {
+		return pg.Parse(contents)
+	}
+	trees := make([]pg.ParsetreeList, len(batches))
+	errs := make([]error, len(batches))
+	parallel.Do(len(batches), func(i int) {
+		trees[i], errs[i] = parseBatch(contents, batches[i])
+	})
+	for _, err := range errs {
+		if err != nil {
+			// Report errors exactly as a single call would have
+			return pg.Parse(contents)
+		}
+	}
+	var tree pg.ParsetreeList
+	for _, t := range trees {
+		tree.Statements = append(tree.Statements, t.Statements...)
+	}
+	return tree, nil
+}
+
+var locationPattern = regexp.MustCompile(`"location": (\d+)`)
+
+// Parse contents[b.start:b.end], moving every location so that it's relative
+// to the start of contents.
+func parseBatch(contents string, b batch) (tree pg.ParsetreeList, err error) {
+	out, err := pg.ParseToJSON(contents[b.start:b.end])
+	if err != nil {
+		return tree, err
+	}
+	if b.start > 0 {
+		out = locationPattern.ReplaceAllStringFunc(out, func(m string) string {
+			n, _ := strconv.Atoi(m[len(`"location": `):])
+			return `"location": ` + strconv.Itoa(n+b.start)
+		})
+	}
+
+	// Unmarshalling panics on unsupported nodes, see pg.Parse
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	if err := json.Unmarshal([]byte(out), &tree); err != nil {
+		return tree, err
+	}
+
+	// A statement location of zero is omitted from the output, so statement
+	// locations are moved after unmarshalling
+	for i, stmt := range tree.Statements {
+		if raw, ok := stmt.(nodes.RawStmt); ok {
+			raw.StmtLocation += b.start
+			tree.Statements[i] = raw
+		}
+	}
+	return tree, nil
+}
+
+type batch struct {
+	start, end int
+}
+
+// Split contents into about n batches of whole statements
+func splitBatches(contents string, n int) []batch {
+	size := len(contents)/n + 1
+	var batches []batch
+	start := 0
+	for _, end := range statementEnds(contents) {
+		if end-start >= size {
+			batches = append(batches, batch{start, end})
+			start = end
+		}
+	}
+	if start < len(contents) {
+		batches = append(batches, batch{start, len(contents)})
+	}
+	return batches
+}
+
+// Return the offsets just past each top-level semicolon, skipping over
+// comments, quoted strings and identifiers, dollar-quoted bodies and
+// parentheses (such as the actions of a CREATE RULE).
+func statementEnds(s string) []int {
+	var ends []int
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '-' && i+1 < len(s) && s[i+1] == '-':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(s) && s[i+1] == '*':
+			nested := 0
+			for ; i+1 < len(s); i++ {
+				if s[i] == '/' && s[i+1] == '*' {
+					nested++
+					i++
+				} else if s[i] == '*' && s[i+1] == '/' {
+					nested--
+					i++
+					if nested == 0 {
+						break
+					}
+				}
+			}
+		case c == '\'':
+			escapes := i > 0 && (s[i-1] == 'E' || s[i-1] == 'e') && (i < 2 || !isIdentChar(s[i-2]))
+			for i++; i < len(s); i++ {
+				if escapes && s[i] == '\\' {
+					i++
+				} else if s[i] == '\'' {
+					if i+1 < len(s) && s[i+1] == '\'' {
+						i++
+					} else {
+						break
+					}
+				}
+			}
+		case c == '"':
+			for i++; i < len(s); i++ {
+				if s[i] == '"' {
+					if i+1 < len(s) && s[i+1] == '"' {
+						i++
+					} else {
+						break
+					}
+				}
+			}
+		case c == '$' && (i == 0 || !isIdentChar(s[i-1])):
+			if tag, ok := dollarTag(s[i:]); ok {
+				end := strings.Index(s[i+len(tag):], tag)
+				if end < 0 {
+					return ends
+				}
+				i += len(tag) + end + len(tag) - 1
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ';' && depth == 0:
+			ends = append(ends, i+1)
+		}
+	}
+	return ends
+}
+
+// Return the opening tag of a dollar-quoted string, e.g. $$ or $body$
+func dollarTag(s string) (string, bool) {
+	for j := 1; j < len(s); j++ {
+		if s[j] == '$' {
+			return s[:j+1], true
+		}
+		if !isIdentChar(s[j]) || (j == 1 && s[j] >= '0' && s[j] <= '9') {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
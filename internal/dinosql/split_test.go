@@ -0,0 +1,52 @@
+package dinosql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pg "github.com/lfittl/pg_query_go"
+)
+
+func TestStatementEnds(t *testing.T) {
+	for _, test := range []struct {
+		sql  string
+		ends int
+	}{
+		{"SELECT 1; SELECT 2;", 2},
+		{"SELECT ';'; -- ;\nSELECT 2 /* ; /* ; */ ; */;", 2},
+		{`SELECT E'\';'; SELECT "a;""b";`, 2},
+		{"CREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $body$ LANGUAGE sql; SELECT $1;", 2},
+		{"CREATE RULE r AS ON INSERT TO t DO ALSO (SELECT 1; SELECT 2);", 1},
+	} {
+		if ends := statementEnds(test.sql); len(ends) != test.ends {
+			t.Errorf("%s: expected %d statements, found %d", test.sql, test.ends, len(ends))
+		}
+	}
+}
+
+func TestParseSQLBatches(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(&b, "-- table %d\nCREATE TABLE t%d (id serial PRIMARY KEY, name text NOT NULL DEFAULT 'a;b');\n", i, i)
+		fmt.Fprintf(&b, "CREATE FUNCTION f%d() RETURNS int AS $$ SELECT %d; $$ LANGUAGE sql;\n", i, i)
+	}
+	sql := b.String()
+
+	batches := splitBatches(sql, 4)
+	if len(batches) < 2 {
+		t.Fatalf("expected multiple batches, got %d", len(batches))
+	}
+	expected, err := pg.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := parseBatches(sql, batches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("batched parse differs (-want +got):\n%s", diff)
+	}
+}
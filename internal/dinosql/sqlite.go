@@ -0,0 +1,318 @@
+package dinosql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/postgresql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/token"
+
+	pg "github.com/lfittl/pg_query_go"
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// TranslateSQLite returns the SQLite version of a query analyzed with the
+// PostgreSQL engine. Numbered parameters are written ?1 instead of $1, casts
+// of parameters are dropped, as SQLite binds the Go values as they are, and
+// other :: casts are written CAST(x AS type) with the type affinity of the
+// PostgreSQL type. Features that SQLite doesn't have, such as ILIKE or
+// functions it doesn't define, are reported instead.
+//
+// The translation is best-effort. It's never parsed or type-checked by
+// SQLite, so what SQLite would do differently with valid SQL isn't caught:
+// the type affinity of columns, integer division, case-sensitive LIKE, or
+// functions such as round and substr that both engines have.
+func TranslateSQLite(sql string) (string, error) {
+	if tokens := token.Split(sql); len(tokens) > 0 && tokens[0].Is("CALL") {
+		return "", errors.New("CALL isn't supported")
+	}
+	// Each cast is rewritten on its own, as the expression of a cast can
+	// contain other casts
+	for {
+		tree, err := pg.Parse(sql)
+		if err != nil {
+			return "", err
+		}
+		cast, ok := sqliteCast(sql, tree)
+		if !ok {
+			break
+		}
+		if sql, err = rewriteCast(sql, cast); err != nil {
+			return "", err
+		}
+	}
+
+	tree, err := pg.Parse(sql)
+	if err != nil {
+		return "", err
+	}
+	var params []nodes.ParamRef
+	for _, stmt := range tree.Statements {
+		if err := sqliteUnsupported(stmt); err != nil {
+			return "", err
+		}
+		ast.Walk(ast.VisitorFunc(func(node nodes.Node) {
+			if ref, ok := node.(nodes.ParamRef); ok {
+				params = append(params, ref)
+			}
+		}), stmt)
+	}
+	// ?N is as long as $N, so the locations of the other parameters don't
+	// change
+	b := []byte(sql)
+	for _, ref := range params {
+		if b[ref.Location] == '$' {
+			b[ref.Location] = '?'
+		}
+	}
+	return string(b), nil
+}
+
+// The last cast that SQLite can't run as it's written: a :: cast, or a CAST
+// whose type isn't a SQLite type name. Casts are walked outside in, so the
+// last one doesn't contain any others and x::int::text is rewritten from the
+// inside out.
+func sqliteCast(sql string, tree pg.ParsetreeList) (nodes.TypeCast, bool) {
+	var cast nodes.TypeCast
+	var found bool
+	for _, stmt := range tree.Statements {
+		ast.Walk(ast.VisitorFunc(func(node nodes.Node) {
+			tc, ok := node.(nodes.TypeCast)
+			if !ok || tc.TypeName == nil {
+				return
+			}
+			// TRUE and FALSE are parsed as casts to bool
+			if tc.Location < 0 && tc.TypeName.Location < 0 {
+				return
+			}
+			// The parser turns INTEGER and REAL into int4 and float4, so the type
+			// is read as it's written
+			loc := tc.TypeName.Location
+			if tc.Location >= 0 && !strings.HasPrefix(sql[tc.Location:], "::") && sqliteAffinities[strings.ToUpper(sql[loc:typeNameEnd(sql, loc)])] {
+				return
+			}
+			cast, found = tc, true
+		}), stmt)
+	}
+	return cast, found
+}
+
+var sqliteAffinities = map[string]bool{
+	"INTEGER": true,
+	"REAL":    true,
+	"TEXT":    true,
+	"BLOB":    true,
+	"NUMERIC": true,
+}
+
+func rewriteCast(sql string, cast nodes.TypeCast) (string, error) {
+	typ := cast.TypeName
+	typeEnd := typeNameEnd(sql, typ.Location)
+	name := sql[typ.Location:typeEnd]
+	if cast.Location < 0 {
+		return "", fmt.Errorf("typed literals such as %s '...' aren't supported", name)
+	}
+	affinity, err := sqliteAffinity(typ, name)
+	if err != nil {
+		return "", err
+	}
+
+	// CAST(x AS type)
+	if !strings.HasPrefix(sql[cast.Location:], "::") {
+		return sql[:typ.Location] + affinity + sql[typeEnd:], nil
+	}
+
+	// x::type, where x is a parameter, whose cast is dropped, or a column,
+	// constant, function call or translated CAST
+	if _, ok := cast.Arg.(nodes.ParamRef); ok {
+		return sql[:cast.Location] + sql[typeEnd:], nil
+	}
+	start := -1
+	switch arg := cast.Arg.(type) {
+	case nodes.ColumnRef:
+		start = arg.Location
+	case nodes.A_Const:
+		start = arg.Location
+	case nodes.FuncCall:
+		start = arg.Location
+	case nodes.TypeCast:
+		start = arg.Location
+	}
+	expr := ""
+	if start >= 0 {
+		expr = strings.TrimSpace(sql[start:cast.Location])
+	}
+	if expr == "" || strings.Count(expr, "(") != strings.Count(expr, ")") || strings.HasSuffix(expr, ")") && !callExpr(cast.Arg) {
+		return "", fmt.Errorf("the cast to %s can't be translated; use CAST(... AS %s)", name, name)
+	}
+	return sql[:start] + "CAST(" + expr + " AS " + affinity + ")" + sql[typeEnd:], nil
+}
+
+// Report whether an expression ending in a parenthesis is a function call or
+// CAST(x AS type), rather than a parenthesized expression
+func callExpr(n nodes.Node) bool {
+	switch n.(type) {
+	case nodes.FuncCall, nodes.TypeCast:
+		return true
+	}
+	return false
+}
+
+// The end of the type name starting at start, such as "timestamp with time
+// zone" or "varchar(255)"
+func typeNameEnd(sql string, start int) int {
+	tokens := token.Split(sql[start:])
+	end := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case i == 0:
+		case t.Text == "." && i+1 < len(tokens):
+			i++
+			t = tokens[i]
+		case t.Text == "(":
+			i = tokens.Closing(i)
+			t = tokens[i]
+		case t.Text == "[" && i+1 < len(tokens) && tokens[i+1].Text == "]":
+			i++
+			t = tokens[i]
+		case t.Is("precision"), t.Is("varying"), t.Is("with"), t.Is("without"), t.Is("time"), t.Is("zone"):
+		default:
+			return start + end
+		}
+		end = t.End
+	}
+	return start + end
+}
+
+// The type affinity of a PostgreSQL type, used as the type of the cast
+//
+// https://www.sqlite.org/datatype3.html#type_affinity
+func sqliteAffinity(typ *nodes.TypeName, written string) (string, error) {
+	names := stringSlice(typ.Names)
+	if len(typ.ArrayBounds.Items) > 0 {
+		return "", fmt.Errorf("arrays such as %s aren't supported", written)
+	}
+	switch names[len(names)-1] {
+	case "int2", "int4", "int8", "smallint", "integer", "bigint", "int", "bool", "boolean":
+		return "INTEGER", nil
+	case "float4", "float8", "real", "double precision":
+		return "REAL", nil
+	case "numeric", "decimal":
+		return "NUMERIC", nil
+	case "bytea":
+		return "BLOB", nil
+	case "date", "time", "timetz", "timestamp", "timestamptz", "interval":
+		return "", fmt.Errorf("casts to %s aren't supported; use SQLite's date and time functions", written)
+	}
+	return "TEXT", nil
+}
+
+// Report the first feature of a statement that SQLite doesn't have
+func sqliteUnsupported(stmt nodes.Node) error {
+	var err error
+	fail := func(format string, args ...interface{}) {
+		if err == nil {
+			err = fmt.Errorf(format, args...)
+		}
+	}
+	ast.Walk(ast.VisitorFunc(func(node nodes.Node) {
+		switch n := node.(type) {
+		case nodes.NotifyStmt:
+			fail("NOTIFY isn't supported")
+		case nodes.SelectStmt:
+			if len(n.DistinctClause.Items) > 0 && n.DistinctClause.Items[0] != nil {
+				fail("DISTINCT ON isn't supported")
+			}
+		case nodes.DeleteStmt:
+			if len(n.UsingClause.Items) > 0 {
+				fail("DELETE ... USING isn't supported")
+			}
+		case nodes.OnConflictClause:
+			if n.Infer != nil && n.Infer.Conname != nil {
+				fail("ON CONFLICT ON CONSTRAINT isn't supported")
+			}
+		case nodes.LockingClause:
+			fail("SELECT FOR UPDATE and FOR SHARE aren't supported")
+		case nodes.A_ArrayExpr:
+			fail("arrays aren't supported")
+		case nodes.A_Indices:
+			fail("arrays aren't supported")
+		case nodes.MinMaxExpr:
+			fail("GREATEST and LEAST aren't supported; use max and min")
+		case nodes.SQLValueFunction:
+			switch n.Op {
+			case nodes.SVFOP_CURRENT_DATE, nodes.SVFOP_CURRENT_TIME, nodes.SVFOP_CURRENT_TIMESTAMP:
+			default:
+				fail("SQL value functions such as CURRENT_USER aren't supported")
+			}
+		case nodes.A_Expr:
+			switch n.Kind {
+			case nodes.AEXPR_OP_ANY, nodes.AEXPR_OP_ALL:
+				fail("ANY and ALL aren't supported; use IN")
+			case nodes.AEXPR_DISTINCT, nodes.AEXPR_NOT_DISTINCT:
+				fail("IS DISTINCT FROM isn't supported; use IS and IS NOT")
+			case nodes.AEXPR_ILIKE:
+				fail("ILIKE isn't supported; use LIKE, which ignores the case of ASCII letters")
+			case nodes.AEXPR_SIMILAR:
+				fail("SIMILAR TO isn't supported")
+			case nodes.AEXPR_BETWEEN_SYM, nodes.AEXPR_NOT_BETWEEN_SYM:
+				fail("BETWEEN SYMMETRIC isn't supported")
+			case nodes.AEXPR_OP:
+				if op := join(n.Name, "."); sqlitePostgresOperators[op] {
+					fail("the %s operator isn't supported", op)
+				}
+			}
+		case nodes.FuncCall:
+			names := stringSlice(n.Funcname)
+			name := strings.ToLower(names[len(names)-1])
+			if len(names) > 1 && names[0] != "pg_catalog" || !sqliteFunctions[name] {
+				fail("function %s doesn't exist", join(n.Funcname, "."))
+			}
+		}
+	}), stmt)
+	return err
+}
+
+// PostgreSQL operators that SQLite doesn't have, such as the regular
+// expression and array operators
+var sqlitePostgresOperators = map[string]bool{
+	"~":   true,
+	"~*":  true,
+	"!~":  true,
+	"!~*": true,
+	"@>":  true,
+	"<@":  true,
+	"&&":  true,
+	"#>":  true,
+	"#>>": true,
+	"?":   true,
+	"?|":  true,
+	"?&":  true,
+}
+
+// The core, date and time, aggregate, window and JSON functions of SQLite
+//
+// https://www.sqlite.org/lang_corefunc.html
+var sqliteFunctions = map[string]bool{}
+
+func init() {
+	for _, name := range strings.Fields(`
+		abs changes char coalesce format glob hex ifnull iif instr
+		last_insert_rowid length like likelihood likely lower ltrim max min
+		nullif printf quote random randomblob replace round rtrim sign
+		soundex substr substring total_changes trim typeof unicode unlikely
+		upper zeroblob
+		date time datetime julianday unixepoch strftime
+		avg count group_concat sum total
+		row_number rank dense_rank percent_rank cume_dist ntile lag lead
+		first_value last_value nth_value
+		json json_array json_array_length json_extract json_insert
+		json_object json_patch json_remove json_replace json_set json_type
+		json_valid json_quote json_group_array json_group_object json_each
+		json_tree
+	`) {
+		sqliteFunctions[name] = true
+	}
+}
@@ -0,0 +1,103 @@
+package dinosql
+
+import (
+	"testing"
+)
+
+func TestTranslateSQLite(t *testing.T) {
+	for _, tc := range []struct {
+		input  string
+		output string
+	}{
+		{
+			"SELECT id FROM authors WHERE id = $1::bigint AND name = $2",
+			"SELECT id FROM authors WHERE id = ?1 AND name = ?2",
+		},
+		{
+			"SELECT count(*)::int, id::text, lower(name)::varchar(10) FROM authors",
+			"SELECT CAST(count(*) AS INTEGER), CAST(id AS TEXT), CAST(lower(name) AS TEXT) FROM authors",
+		},
+		{
+			"SELECT CAST(price AS double precision), CAST(id AS INTEGER) FROM books",
+			"SELECT CAST(price AS REAL), CAST(id AS INTEGER) FROM books",
+		},
+		{
+			"INSERT INTO authors (name) VALUES ($1) ON CONFLICT (name) DO NOTHING RETURNING id",
+			"INSERT INTO authors (name) VALUES (?1) ON CONFLICT (name) DO NOTHING RETURNING id",
+		},
+		{
+			"SELECT TRUE, coalesce(bio, 'none') FROM authors LIMIT $1 OFFSET $2",
+			"SELECT TRUE, coalesce(bio, 'none') FROM authors LIMIT ?1 OFFSET ?2",
+		},
+		{
+			"SELECT lower(name::text)::varchar FROM authors WHERE id = $1::int::text",
+			"SELECT CAST(lower(CAST(name AS TEXT)) AS TEXT) FROM authors WHERE id = ?1",
+		},
+		{
+			"SELECT CAST(CAST(id AS bigint) AS character varying(255)) FROM authors",
+			"SELECT CAST(CAST(id AS INTEGER) AS TEXT) FROM authors",
+		},
+		{
+			"SELECT id::int::text, CAST(price AS numeric(10, 2)) FROM books WHERE id = $1::pg_catalog.int8",
+			"SELECT CAST(CAST(id AS INTEGER) AS TEXT), CAST(price AS NUMERIC) FROM books WHERE id = ?1",
+		},
+	} {
+		actual, err := TranslateSQLite(tc.input)
+		if err != nil {
+			t.Errorf("%s: %s", tc.input, err)
+			continue
+		}
+		if actual != tc.output {
+			t.Errorf("%s:\nwant: %s\n got: %s", tc.input, tc.output, actual)
+		}
+	}
+}
+
+func TestTranslateSQLiteErrors(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{"CALL transfer($1, $2)", "CALL isn't supported"},
+		{"SELECT id FROM authors WHERE name ILIKE $1", "ILIKE isn't supported; use LIKE, which ignores the case of ASCII letters"},
+		{"SELECT id FROM authors WHERE id = ANY($1::int[])", "arrays such as int[] aren't supported"},
+		{"SELECT DISTINCT ON (name) id FROM authors", "DISTINCT ON isn't supported"},
+		{"SELECT now()", "function now doesn't exist"},
+		{"SELECT date '2020-01-01'", "typed literals such as date '...' aren't supported"},
+		{"SELECT created_at::timestamp with time zone FROM authors", "casts to timestamp with time zone aren't supported; use SQLite's date and time functions"},
+		{"SELECT (id + 1)::text FROM authors", "the cast to text can't be translated; use CAST(... AS text)"},
+		{"SELECT name::varchar(255)[] FROM authors", "arrays such as varchar(255)[] aren't supported"},
+		{"SELECT (id::int)::text FROM authors", "the cast to text can't be translated; use CAST(... AS text)"},
+		{"SELECT id FROM authors WHERE bio IS DISTINCT FROM $1", "IS DISTINCT FROM isn't supported; use IS and IS NOT"},
+	} {
+		_, err := TranslateSQLite(tc.input)
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.input)
+			continue
+		}
+		if err.Error() != tc.err {
+			t.Errorf("%s:\nwant: %s\n got: %s", tc.input, tc.err, err)
+		}
+	}
+}
+
+func TestTypeNameEnd(t *testing.T) {
+	for _, tc := range []struct {
+		sql  string
+		name string
+	}{
+		{"int FROM authors", "int"},
+		{"pg_catalog.int8, id", "pg_catalog.int8"},
+		{"timestamp with time zone FROM authors", "timestamp with time zone"},
+		{"time without time zone)", "time without time zone"},
+		{"double precision AS price", "double precision"},
+		{"character varying(255) FROM authors", "character varying(255)"},
+		{"numeric(10, 2))", "numeric(10, 2)"},
+		{"varchar(255)[] FROM authors", "varchar(255)[]"},
+		{"text[]", "text[]"},
+	} {
+		if name := tc.sql[:typeNameEnd(tc.sql, 0)]; name != tc.name {
+			t.Errorf("%s:\nwant: %s\n got: %s", tc.sql, tc.name, name)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package dinosql
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Imported packages aren't type-checked: the standard library would have to
+// be checked from source on every run, and other packages aren't available
+// without a module build. Failing to import a package makes the type checker
+// accept every use of it, so only the declarations of the generated package,
+// and the way they're used, are checked.
+type goImporter struct{}
+
+func (goImporter) Import(p string) (*types.Package, error) {
+	return nil, fmt.Errorf("not type-checked")
+}
+
+// Type-check a generated Go package, so that bugs in the generated code, such
+// as a query whose structs collide with a model, are reported by sqlc rather
+// than by the Go compiler. Files in subdirectories belong to other packages
+// and aren't checked.
+func VerifyGo(files map[string]string) []error {
+	var names []string
+	for name := range files {
+		if path.Ext(name) == ".go" && !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	var errs []error
+	for _, name := range names {
+		f, err := parser.ParseFile(fset, name, files[name], 0)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		parsed = append(parsed, f)
+	}
+	if len(errs) > 0 || len(parsed) == 0 {
+		return errs
+	}
+
+	conf := types.Config{
+		Importer: goImporter{},
+		Error: func(err error) {
+			terr, ok := err.(types.Error)
+			if ok && strings.HasPrefix(terr.Msg, "could not import ") {
+				return
+			}
+			// Details of the previous error, such as the other declaration of
+			// a redeclared name, start with a tab
+			if ok && strings.HasPrefix(terr.Msg, "\t") && len(errs) > 0 {
+				return
+			}
+			errs = append(errs, err)
+		},
+	}
+	conf.Check(parsed[0].Name.Name, fset, parsed, nil)
+	return errs
+}
@@ -0,0 +1,52 @@
+package dinosql
+
+import (
+	"strings"
+	"testing"
+)
+
+const verifyModels = `package db
+
+import "database/sql"
+
+type Author struct {
+	ID  int64
+	Bio sql.NullString
+}
+`
+
+const verifyQueries = `package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DBTX interface {
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, "SELECT id, bio FROM authors WHERE id = $1", id)
+	var i Author
+	err := row.Scan(&i.ID, &i.Bio)
+	return i, err
+}
+`
+
+func TestVerifyGo(t *testing.T) {
+	files := map[string]string{"models.go": verifyModels, "query.sql.go": verifyQueries}
+	if errs := VerifyGo(files); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	files["query2.sql.go"] = "package db\n\ntype Author struct{}\n"
+	errs := VerifyGo(files)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "Author redeclared") {
+		t.Errorf("expected Author to be redeclared, got %v", errs)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kyleconroy/sqlc/internal/cmd"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
 )
 
 func TestExamples(t *testing.T) {
@@ -35,7 +36,7 @@ func TestExamples(t *testing.T) {
 			t.Parallel()
 			path := filepath.Join(examples, tc)
 			var stderr bytes.Buffer
-			output, err := cmd.Generate(path, &stderr)
+			output, err := cmd.Generate(cmd.Env{}, path, &stderr)
 			if err != nil {
 				t.Fatalf("sqlc generate failed: %s", stderr.String())
 			}
@@ -62,7 +63,7 @@ func TestReplay(t *testing.T) {
 			path, _ := filepath.Abs(filepath.Join("testdata", tc))
 			var stderr bytes.Buffer
 			expected := expectedStderr(t, path)
-			output, err := cmd.Generate(path, &stderr)
+			output, err := cmd.Generate(cmd.Env{}, path, &stderr)
 			if len(expected) == 0 && err != nil {
 				t.Fatalf("sqlc generate failed: %s", stderr.String())
 			}
@@ -83,7 +84,7 @@ func cmpDirectory(t *testing.T, dir string, actual map[string]string) {
 		if file.IsDir() {
 			return nil
 		}
-		if !strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, ".kt") {
+		if !strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, ".kt") && !strings.HasSuffix(path, ".proto") && filepath.Base(path) != dinosql.ProtobufFieldsFile {
 			return nil
 		}
 		if strings.HasSuffix(path, "_test.go") || strings.Contains(path, "src/test/") {
@@ -0,0 +1,75 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getAccount = `-- name: GetAccount :one
+SELECT id, balance FROM accounts WHERE id = $1
+`
+
+func (q *Queries) GetAccount(ctx context.Context, id int32) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccount, id)
+	var i Account
+	err := row.Scan(&i.ID, &i.Balance)
+	return i, err
+}
+
+const transfer = `-- name: Transfer :exec
+CALL transfer($1, $2, $3)
+`
+
+type TransferParams struct {
+	Src    int32
+	Dst    int32
+	Amount int32
+}
+
+func (q *Queries) Transfer(ctx context.Context, arg TransferParams) error {
+	_, err := q.db.ExecContext(ctx, transfer, arg.Src, arg.Dst, arg.Amount)
+	return err
+}
+
+const transferAll = `-- name: TransferAll :execscript
+CALL transfer($1, $2, $3)
+`
+
+const transferAllStep2 = `-- name: TransferAll :execscript
+UPDATE accounts SET balance = 0 WHERE id = $1
+`
+
+type TransferAllParams struct {
+	Src    int32
+	Dst    int32
+	Amount int32
+}
+
+func (q *Queries) TransferAll(ctx context.Context, arg TransferAllParams) error {
+	return q.script(ctx, func(db DBTX) error {
+		if _, err := db.ExecContext(ctx, transferAll, arg.Src, arg.Dst, arg.Amount); err != nil {
+			return err
+		}
+		_, err := db.ExecContext(ctx, transferAllStep2, arg.Src)
+		return err
+	})
+}
+
+const withdraw = `-- name: Withdraw :one
+CALL withdraw($1, $2, NULL)
+`
+
+type WithdrawParams struct {
+	Account int32
+	Amount  int32
+}
+
+func (q *Queries) Withdraw(ctx context.Context, arg WithdrawParams) (sql.NullInt32, error) {
+	row := q.db.QueryRowContext(ctx, withdraw, arg.Account, arg.Amount)
+	var balance sql.NullInt32
+	err := row.Scan(&balance)
+	return balance, err
+}
@@ -0,0 +1,12 @@
+-- name: GetAccount :one
+SELECT * FROM accounts WHERE id = $1;
+
+-- name: Transfer :exec
+CALL transfer($1, $2, $3);
+
+-- name: Withdraw :one
+CALL withdraw($1, $2, NULL);
+
+-- name: TransferAll :execscript
+CALL transfer(@src, @dst, @amount);
+UPDATE accounts SET balance = 0 WHERE id = @src;
@@ -0,0 +1,20 @@
+CREATE TABLE accounts (id SERIAL PRIMARY KEY, balance INT NOT NULL);
+
+CREATE PROCEDURE transfer(src int, dst int, amount int)
+LANGUAGE sql
+AS $$
+UPDATE accounts SET balance = balance - amount WHERE id = src;
+UPDATE accounts SET balance = balance + amount WHERE id = dst;
+$$;
+
+ALTER PROCEDURE transfer(int, int, int) OWNER TO postgres;
+
+CREATE PROCEDURE withdraw(account int, amount int, INOUT balance int)
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE accounts SET balance = accounts.balance - amount
+    WHERE id = account
+    RETURNING accounts.balance INTO balance;
+END
+$$;
@@ -0,0 +1,27 @@
+-- name: CallFunction :exec
+CALL total();
+
+-- name: MissingProcedure :exec
+CALL refund($1);
+
+-- name: WrongArguments :exec
+CALL transfer($1, $2);
+
+-- name: NoResult :one
+CALL transfer($1, $2, $3);
+
+-- name: ManyRows :many
+CALL transfer($1, $2, $3);
+
+-- name: ReadOnly :exec
+-- read_only: true
+CALL transfer($1, $2, $3);
+
+-- stderr
+-- # package querytest
+-- query.sql:2:6: total is not a procedure
+-- query.sql:5:6: procedure refund(unknown) does not exist
+-- query.sql:8:6: procedure transfer(unknown, unknown) does not exist
+-- query.sql:11:1: query "NoResult" specifies parameter ":one", but procedure transfer has no INOUT parameters to return
+-- query.sql:14:1: query "ManyRows" specifies parameter ":many", but CALL returns at most one row
+-- query.sql:18:1: read-only query "ReadOnly" performs CALL
@@ -0,0 +1,12 @@
+CREATE TABLE accounts (id SERIAL PRIMARY KEY, balance INT NOT NULL);
+
+CREATE PROCEDURE transfer(src int, dst int, amount int)
+LANGUAGE sql
+AS $$
+UPDATE accounts SET balance = balance - amount WHERE id = src;
+UPDATE accounts SET balance = balance + amount WHERE id = dst;
+$$;
+
+CREATE FUNCTION total() RETURNS bigint
+LANGUAGE sql
+AS $$ SELECT sum(balance) FROM accounts $$;
@@ -0,0 +1,17 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+)
+
+type Querier interface {
+	AdvisoryLock(ctx context.Context, dollar_1 int32) error
+	ListAuthorsByExternalID(ctx context.Context, dollar_1 string) ([]int32, error)
+	ListAuthorsByIDs(ctx context.Context, dollar_1 []int64) ([]int32, error)
+	ListAuthorsByLowerName(ctx context.Context, dollar_1 string) ([]int32, error)
+	ListAuthorsByParent(ctx context.Context, parentID int32) ([]int32, error)
+}
+
+var _ Querier = (*Queries)(nil)
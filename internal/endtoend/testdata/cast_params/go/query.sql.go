@@ -0,0 +1,131 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const advisoryLock = `-- name: AdvisoryLock :exec
+SELECT pg_advisory_xact_lock($1::int)
+`
+
+func (q *Queries) AdvisoryLock(ctx context.Context, dollar_1 int32) error {
+	_, err := q.db.ExecContext(ctx, advisoryLock, dollar_1)
+	return err
+}
+
+const listAuthorsByExternalID = `-- name: ListAuthorsByExternalID :many
+SELECT id FROM authors
+WHERE parent_id = $1::text::uuid::text
+`
+
+func (q *Queries) ListAuthorsByExternalID(ctx context.Context, dollar_1 string) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByExternalID, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByIDs = `-- name: ListAuthorsByIDs :many
+SELECT id FROM authors
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) ListAuthorsByIDs(ctx context.Context, dollar_1 []int64) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByIDs, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByLowerName = `-- name: ListAuthorsByLowerName :many
+SELECT id FROM authors
+WHERE lower(name) = lower($1::text)
+`
+
+func (q *Queries) ListAuthorsByLowerName(ctx context.Context, dollar_1 string) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByLowerName, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByParent = `-- name: ListAuthorsByParent :many
+SELECT id FROM authors
+WHERE parent_id = $1 OR id = $1::int
+`
+
+func (q *Queries) ListAuthorsByParent(ctx context.Context, parentID int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByParent, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,24 @@
+CREATE TABLE authors (
+    id        SERIAL PRIMARY KEY,
+    name      TEXT NOT NULL,
+    parent_id TEXT
+);
+
+-- name: ListAuthorsByParent :many
+SELECT id FROM authors
+WHERE parent_id = $1 OR id = $1::int;
+
+-- name: ListAuthorsByExternalID :many
+SELECT id FROM authors
+WHERE parent_id = $1::text::uuid::text;
+
+-- name: ListAuthorsByIDs :many
+SELECT id FROM authors
+WHERE id = ANY($1::bigint[]);
+
+-- name: ListAuthorsByLowerName :many
+SELECT id FROM authors
+WHERE lower(name) = lower($1::text);
+
+-- name: AdvisoryLock :exec
+SELECT pg_advisory_xact_lock($1::int);
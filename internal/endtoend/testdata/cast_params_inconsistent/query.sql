@@ -0,0 +1,9 @@
+CREATE TABLE authors (id SERIAL PRIMARY KEY, parent_id TEXT);
+
+-- name: ListAuthorsByParent :many
+SELECT id FROM authors
+WHERE parent_id = $1::text OR id = $1::int;
+
+-- stderr
+-- # package querytest
+-- query.sql:5:38: inconsistent types deduced for parameter $1
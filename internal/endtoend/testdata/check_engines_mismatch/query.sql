@@ -0,0 +1,16 @@
+CREATE TABLE authors (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL
+);
+
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
+
+-- name: CountAuthors :one
+SELECT count(*) FROM authors;
+
+-- stderr
+-- # package querytest
+-- query.sql: GetAuthor: parameter id is int32 with postgresql and int64 with cockroachdb
+-- query.sql: GetAuthor: column ID is int32 with postgresql and int64 with cockroachdb
+-- error: check_engines: mysql can't be checked with the postgresql engine
@@ -0,0 +1,64 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+// NewSQLite returns the queries for a SQLite database, which run the SQLite
+// versions of their SQL
+func NewSQLite(db DBTX) *Queries {
+	return &Queries{db: db, sqlite: true}
+}
+
+func (q *Queries) dialect(postgres, sqlite string) string {
+	if q.sqlite {
+		return sqlite
+	}
+	return postgres
+}
+
+type Queries struct {
+	db     DBTX
+	sqlite bool
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:     tx,
+		sqlite: q.sqlite,
+	}
+}
+
+// script runs the statements of an :execscript query in a transaction, so that
+// they run on the same connection and a SET LOCAL applies to the statements
+// after it. Queries made with WithTx, or with a *sql.Conn, run them as they
+// are.
+func (q *Queries) script(ctx context.Context, fn func(DBTX) error) error {
+	db, ok := q.db.(*sql.DB)
+	if !ok {
+		return fn(q.db)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
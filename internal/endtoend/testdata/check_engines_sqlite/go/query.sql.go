@@ -0,0 +1,291 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const countBooks = `-- name: CountBooks :one
+SELECT count(*)::int FROM books WHERE author_id = $1
+`
+
+const countBooksSQLite = `-- name: CountBooks :one
+SELECT CAST(count(*) AS INTEGER) FROM books WHERE author_id = ?1
+`
+
+func (q *Queries) CountBooks(ctx context.Context, authorID int64) (int32, error) {
+	row := q.db.QueryRowContext(ctx, q.dialect(countBooks, countBooksSQLite), authorID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createAuthor = `-- name: CreateAuthor :one
+INSERT INTO authors (name, bio) VALUES ($1, $2) RETURNING id, name, bio
+`
+
+const createAuthorSQLite = `-- name: CreateAuthor :one
+INSERT INTO authors (name, bio) VALUES (?1, ?2) RETURNING id, name, bio
+`
+
+type CreateAuthorParams struct {
+	Name string
+	Bio  sql.NullString
+}
+
+func (q *Queries) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error) {
+	row := q.db.QueryRowContext(ctx, q.dialect(createAuthor, createAuthorSQLite), arg.Name, arg.Bio)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name, &i.Bio)
+	return i, err
+}
+
+const deleteAuthor = `-- name: DeleteAuthor :execscript
+DELETE FROM books WHERE author_id = $1
+`
+
+const deleteAuthorSQLite = `-- name: DeleteAuthor :execscript
+DELETE FROM books WHERE author_id = ?1
+`
+
+const deleteAuthorStep2 = `-- name: DeleteAuthor :execscript
+DELETE FROM authors WHERE id = $1
+`
+
+const deleteAuthorStep2SQLite = `-- name: DeleteAuthor :execscript
+DELETE FROM authors WHERE id = ?1
+`
+
+func (q *Queries) DeleteAuthor(ctx context.Context, authorID int64) error {
+	return q.script(ctx, func(db DBTX) error {
+		if _, err := db.ExecContext(ctx, q.dialect(deleteAuthor, deleteAuthorSQLite), authorID); err != nil {
+			return err
+		}
+		_, err := db.ExecContext(ctx, q.dialect(deleteAuthorStep2, deleteAuthorStep2SQLite), authorID)
+		return err
+	})
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, bio FROM authors WHERE id = $1::bigint
+`
+
+const getAuthorSQLite = `-- name: GetAuthor :one
+SELECT id, name, bio FROM authors WHERE id = ?1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, dollar_1 int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, q.dialect(getAuthor, getAuthorSQLite), dollar_1)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name, &i.Bio)
+	return i, err
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT id, name, bio FROM authors WHERE name LIKE $1 ORDER BY sqlc_sort
+`
+
+const listAuthorsSQLite = `-- name: ListAuthors :many
+SELECT id, name, bio FROM authors WHERE name LIKE ?1 ORDER BY sqlc_sort
+`
+
+type ListAuthorsSort string
+
+const (
+	ListAuthorsSortNameAsc  ListAuthorsSort = "name ASC"
+	ListAuthorsSortNameDesc ListAuthorsSort = "name DESC"
+	ListAuthorsSortIDAsc    ListAuthorsSort = "id ASC"
+	ListAuthorsSortIDDesc   ListAuthorsSort = "id DESC"
+)
+
+func (s ListAuthorsSort) Valid() bool {
+	switch s {
+	case ListAuthorsSortNameAsc, ListAuthorsSortNameDesc, ListAuthorsSortIDAsc, ListAuthorsSortIDDesc:
+		return true
+	}
+	return false
+}
+
+func (q *Queries) ListAuthors(ctx context.Context, name string, orderBy ListAuthorsSort) ([]Author, error) {
+	if !orderBy.Valid() {
+		return nil, fmt.Errorf("invalid sort order: %q", orderBy)
+	}
+	rows, err := q.db.QueryContext(ctx, strings.Replace(q.dialect(listAuthors, listAuthorsSQLite), "sqlc_sort", string(orderBy), 1), name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(&i.ID, &i.Name, &i.Bio); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsPage = `-- name: ListAuthorsPage :paginated
+SELECT * FROM (
+SELECT id, name FROM authors ORDER BY id
+) AS page
+ORDER BY id
+LIMIT $1
+`
+
+const listAuthorsPageSQLite = `-- name: ListAuthorsPage :paginated
+SELECT * FROM (
+SELECT id, name FROM authors ORDER BY id
+) AS page
+ORDER BY id
+LIMIT ?1
+`
+
+type ListAuthorsPageRow struct {
+	ID   int64
+	Name string
+}
+
+const listAuthorsPageNext = `-- name: ListAuthorsPage :paginated
+SELECT * FROM (
+SELECT id, name FROM authors ORDER BY id
+) AS page
+WHERE (id) > ($1)
+ORDER BY id
+LIMIT $2
+`
+
+const listAuthorsPageNextSQLite = `-- name: ListAuthorsPage :paginated
+SELECT * FROM (
+SELECT id, name FROM authors ORDER BY id
+) AS page
+WHERE (id) > (?1)
+ORDER BY id
+LIMIT ?2
+`
+
+// ListAuthorsPageCursor holds the ORDER BY columns of the last row of a page of
+// ListAuthorsPage, where the next page starts
+type ListAuthorsPageCursor struct {
+	ID int64 `json:"id"`
+}
+
+// Encode returns the cursor as an opaque string, such as for an API response
+func (c ListAuthorsPageCursor) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeListAuthorsPageCursor reads a cursor returned by ListAuthorsPageCursor.Encode
+func DecodeListAuthorsPageCursor(s string) (*ListAuthorsPageCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var c ListAuthorsPageCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return &c, nil
+}
+
+func (q *Queries) ListAuthorsPage(ctx context.Context, after *ListAuthorsPageCursor, limit int32) ([]ListAuthorsPageRow, *ListAuthorsPageCursor, error) {
+	var rows *sql.Rows
+	var err error
+	if after == nil {
+		rows, err = q.db.QueryContext(ctx, q.dialect(listAuthorsPage, listAuthorsPageSQLite), limit)
+	} else {
+		rows, err = q.db.QueryContext(ctx, q.dialect(listAuthorsPageNext, listAuthorsPageNextSQLite), after.ID, limit)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsPageRow
+	for rows.Next() {
+		var i ListAuthorsPageRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	var next *ListAuthorsPageCursor
+	if limit > 0 && len(items) == int(limit) {
+		last := items[len(items)-1]
+		next = &ListAuthorsPageCursor{
+			ID: last.ID,
+		}
+	}
+	return items, next, nil
+}
+
+const listTitles = `-- name: ListTitles :many
+SELECT title FROM books ORDER BY title
+`
+
+func (q *Queries) ListTitles(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listTitles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, err
+		}
+		items = append(items, title)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateBio = `-- name: UpdateBio :execrows
+UPDATE authors SET bio = $2 WHERE id = $1
+`
+
+const updateBioSQLite = `-- name: UpdateBio :execrows
+UPDATE authors SET bio = ?2 WHERE id = ?1
+`
+
+type UpdateBioParams struct {
+	ID  int64
+	Bio sql.NullString
+}
+
+func (q *Queries) UpdateBio(ctx context.Context, arg UpdateBioParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, q.dialect(updateBio, updateBioSQLite), arg.ID, arg.Bio)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
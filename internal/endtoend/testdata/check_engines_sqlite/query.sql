@@ -0,0 +1,25 @@
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1::bigint;
+
+-- name: ListAuthors :many
+-- sort: name, id
+SELECT * FROM authors WHERE name LIKE $1 ORDER BY name;
+
+-- name: ListAuthorsPage :paginated
+SELECT id, name FROM authors ORDER BY id;
+
+-- name: CountBooks :one
+SELECT count(*)::int FROM books WHERE author_id = $1;
+
+-- name: CreateAuthor :one
+INSERT INTO authors (name, bio) VALUES ($1, $2) RETURNING *;
+
+-- name: UpdateBio :execrows
+UPDATE authors SET bio = $2 WHERE id = $1;
+
+-- name: DeleteAuthor :execscript
+DELETE FROM books WHERE author_id = $1;
+DELETE FROM authors WHERE id = $1;
+
+-- name: ListTitles :many
+SELECT title FROM books ORDER BY title;
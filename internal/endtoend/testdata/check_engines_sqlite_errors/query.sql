@@ -0,0 +1,33 @@
+CREATE TABLE authors (
+    id   SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    tags TEXT[] NOT NULL
+);
+
+-- name: FindAuthors :many
+SELECT id FROM authors WHERE name ILIKE $1;
+
+-- name: GetTags :one
+SELECT tags FROM authors WHERE id = $1;
+
+-- name: CreateAuthor :exec
+INSERT INTO authors (name, tags) VALUES ($1, $2);
+
+-- name: NewAuthors :many
+SELECT id FROM authors WHERE name = ANY($1::text[]);
+
+-- name: LockAuthor :one
+SELECT id FROM authors WHERE id = $1 FOR UPDATE;
+
+-- name: Now :one
+SELECT now()::timestamp;
+
+-- stderr
+-- # package querytest
+-- query.sql: CreateAuthor: sqlite: parameter Tags is []string, but SQLite doesn't have arrays
+-- query.sql: FindAuthors: sqlite: ILIKE isn't supported; use LIKE, which ignores the case of ASCII letters
+-- query.sql: GetTags: sqlite: column tags is []string, but SQLite doesn't have arrays
+-- query.sql: LockAuthor: sqlite: SELECT FOR UPDATE and FOR SHARE aren't supported
+-- query.sql: NewAuthors: sqlite: arrays such as text[] aren't supported
+-- query.sql: NewAuthors: sqlite: parameter dollar_1 is []string, but SQLite doesn't have arrays
+-- query.sql: Now: sqlite: casts to timestamp aren't supported; use SQLite's date and time functions
@@ -0,0 +1,72 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const createCustomer = `-- name: CreateCustomer :one
+INSERT INTO customers (name, region) VALUES ($1, $2) RETURNING id, name, region
+`
+
+type CreateCustomerParams struct {
+	Name   string
+	Region string
+}
+
+func (q *Queries) CreateCustomer(ctx context.Context, arg CreateCustomerParams) (Customer, error) {
+	row := q.db.QueryRowContext(ctx, createCustomer, arg.Name, arg.Region)
+	var i Customer
+	err := row.Scan(&i.ID, &i.Name, &i.Region)
+	return i, err
+}
+
+const createOrder = `-- name: CreateOrder :one
+INSERT INTO orders (customer_id, total) VALUES ($1, $2) RETURNING id
+`
+
+type CreateOrderParams struct {
+	CustomerID int64
+	Total      int32
+}
+
+func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createOrder, arg.CustomerID, arg.Total)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const listOrders = `-- name: ListOrders :many
+SELECT customer_id, id, total, note FROM orders WHERE customer_id = $1
+`
+
+func (q *Queries) ListOrders(ctx context.Context, customerID int64) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOrders, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.CustomerID,
+			&i.ID,
+			&i.Total,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,8 @@
+-- name: CreateCustomer :one
+INSERT INTO customers (name, region) VALUES ($1, $2) RETURNING *;
+
+-- name: ListOrders :many
+SELECT * FROM orders WHERE customer_id = $1;
+
+-- name: CreateOrder :one
+INSERT INTO orders (customer_id, total) VALUES ($1, $2) RETURNING id;
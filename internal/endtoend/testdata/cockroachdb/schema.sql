@@ -0,0 +1,18 @@
+CREATE TABLE customers (
+    id SERIAL PRIMARY KEY,
+    name STRING NOT NULL,
+    region STRING NOT NULL,
+    INDEX customers_region_idx (region) STORING (name),
+    FAMILY "primary" (id, name, region)
+);
+
+CREATE TABLE orders (
+    customer_id INT NOT NULL REFERENCES customers (id),
+    id INT NOT NULL DEFAULT unique_rowid(),
+    total INT4 NOT NULL,
+    note BYTES,
+    PRIMARY KEY (customer_id, id) USING HASH WITH BUCKET_COUNT = 8,
+    UNIQUE INDEX orders_id_key (id)
+) INTERLEAVE IN PARENT customers (customer_id);
+
+CREATE INDEX orders_total_idx ON orders (total) STORING (note);
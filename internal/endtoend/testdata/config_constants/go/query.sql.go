@@ -0,0 +1,54 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const countOpenOrders = `-- name: CountOpenOrders :one
+SELECT count(*) FROM orders
+WHERE tenant_id = $1 AND status IN ('pending', 'processing')
+`
+
+func (q *Queries) CountOpenOrders(ctx context.Context, tenantID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOpenOrders, tenantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const listOpenOrders = `-- name: ListOpenOrders :many
+SELECT id, tenant_id, status, total FROM orders
+WHERE tenant_id = $1 AND status IN ('pending', 'processing')
+ORDER BY id
+`
+
+func (q *Queries) ListOpenOrders(ctx context.Context, tenantID int32) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOpenOrders, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Status,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,8 @@
+-- name: ListOpenOrders :many
+SELECT * FROM orders
+WHERE sqlc.const(tenant_filter) AND status IN (sqlc.const(open_statuses))
+ORDER BY id;
+
+-- name: CountOpenOrders :one
+SELECT count(*) FROM orders
+WHERE sqlc.const(tenant_filter) AND status IN (sqlc.const(open_statuses));
@@ -0,0 +1,6 @@
+CREATE TABLE orders (
+    id        SERIAL PRIMARY KEY,
+    tenant_id integer NOT NULL,
+    status    text NOT NULL,
+    total     integer NOT NULL
+);
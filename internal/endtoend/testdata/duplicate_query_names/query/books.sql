@@ -0,0 +1,5 @@
+-- name: ListBooks :many
+SELECT * FROM books;
+
+-- name: GetAuthor :one
+SELECT authors.* FROM authors JOIN books ON books.author_id = authors.id WHERE books.id = $1;
@@ -0,0 +1,6 @@
+CREATE TABLE authors (id BIGSERIAL PRIMARY KEY, name TEXT NOT NULL);
+CREATE TABLE books (id BIGSERIAL PRIMARY KEY, author_id BIGINT NOT NULL);
+
+-- stderr
+-- # package querytest
+-- query/books.sql:5:1: duplicate query name: GetAuthor, first defined at query/authors.sql:2:1
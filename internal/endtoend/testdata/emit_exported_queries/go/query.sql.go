@@ -0,0 +1,31 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const GetSession = `-- name: GetSession :one
+SELECT id, user_id, expires_at FROM sessions WHERE id = $1
+`
+
+func (q *Queries) GetSession(ctx context.Context, id int32) (Session, error) {
+	row := q.queryRow(ctx, q.getSessionStmt, GetSession, id)
+	var i Session
+	err := row.Scan(&i.ID, &i.UserID, &i.ExpiresAt)
+	return i, err
+}
+
+const DeleteExpiredSessions = `-- name: deleteExpiredSessions :execrows
+DELETE FROM sessions WHERE expires_at < now()
+`
+
+func (q *Queries) deleteExpiredSessions(ctx context.Context) (int64, error) {
+	result, err := q.exec(ctx, q.deleteExpiredSessionsStmt, DeleteExpiredSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,5 @@
+-- name: GetSession :one
+SELECT * FROM sessions WHERE id = $1;
+
+-- name: deleteExpiredSessions :execrows
+DELETE FROM sessions WHERE expires_at < now();
@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusActive Status = "active"
+	StatusBanned Status = "banned"
+)
+
+func (e *Status) Scan(src interface{}) error {
+	*e = Status(src.([]byte))
+	return nil
+}
+
+type BillingInvoice struct {
+	ID     int64
+	UserID int32
+	Order  int32
+}
+
+type User struct {
+	ID        int32
+	Email     string
+	Name      sql.NullString
+	Status    Status
+	Tags      []string
+	CreatedAt time.Time
+}
@@ -0,0 +1,175 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+// Package querytesttest inserts rows of the tables in package querytest for tests.
+package querytesttest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyleconroy/sqlc/internal/endtoend/testdata/emit_fixtures/go"
+	"github.com/lib/pq"
+)
+
+// BillingInvoiceFixture inserts a querytest.BillingInvoice. The columns that were set
+// are inserted, along with the NOT NULL columns without a default. The others
+// get their default value.
+type BillingInvoiceFixture struct {
+	row querytest.BillingInvoice
+	set map[string]bool
+}
+
+func NewBillingInvoiceFixture() *BillingInvoiceFixture {
+	return &BillingInvoiceFixture{set: map[string]bool{
+		"user_id": true,
+		"order":   true,
+	}}
+}
+
+func (f *BillingInvoiceFixture) WithID(v int64) *BillingInvoiceFixture {
+	f.row.ID = v
+	f.set["id"] = true
+	return f
+}
+
+func (f *BillingInvoiceFixture) WithUserID(v int32) *BillingInvoiceFixture {
+	f.row.UserID = v
+	f.set["user_id"] = true
+	return f
+}
+
+func (f *BillingInvoiceFixture) WithOrder(v int32) *BillingInvoiceFixture {
+	f.row.Order = v
+	f.set["order"] = true
+	return f
+}
+
+// Insert the row, returning it as stored by the database
+func (f *BillingInvoiceFixture) Insert(ctx context.Context, conn querytest.DBTX) (querytest.BillingInvoice, error) {
+	var cols []string
+	var args []interface{}
+	if f.set["id"] {
+		cols = append(cols, `"id"`)
+		args = append(args, f.row.ID)
+	}
+	if f.set["user_id"] {
+		cols = append(cols, `"user_id"`)
+		args = append(args, f.row.UserID)
+	}
+	if f.set["order"] {
+		cols = append(cols, `"order"`)
+		args = append(args, f.row.Order)
+	}
+	query := `INSERT INTO "billing"."invoices"`
+	if len(cols) == 0 {
+		query += " DEFAULT VALUES"
+	} else {
+		params := make([]string, len(cols))
+		for i := range params {
+			params[i] = fmt.Sprintf("$%d", i+1)
+		}
+		query += " (" + strings.Join(cols, ", ") + ") VALUES (" + strings.Join(params, ", ") + ")"
+	}
+	row := conn.QueryRowContext(ctx, query+` RETURNING "id", "user_id", "order"`, args...)
+	var i querytest.BillingInvoice
+	err := row.Scan(&i.ID, &i.UserID, &i.Order)
+	return i, err
+}
+
+// UserFixture inserts a querytest.User. The columns that were set
+// are inserted, along with the NOT NULL columns without a default. The others
+// get their default value.
+type UserFixture struct {
+	row querytest.User
+	set map[string]bool
+}
+
+func NewUserFixture() *UserFixture {
+	return &UserFixture{set: map[string]bool{
+		"email": true,
+		"tags":  true,
+	}}
+}
+
+func (f *UserFixture) WithID(v int32) *UserFixture {
+	f.row.ID = v
+	f.set["id"] = true
+	return f
+}
+
+func (f *UserFixture) WithEmail(v string) *UserFixture {
+	f.row.Email = v
+	f.set["email"] = true
+	return f
+}
+
+func (f *UserFixture) WithName(v sql.NullString) *UserFixture {
+	f.row.Name = v
+	f.set["name"] = true
+	return f
+}
+
+func (f *UserFixture) WithStatus(v querytest.Status) *UserFixture {
+	f.row.Status = v
+	f.set["status"] = true
+	return f
+}
+
+func (f *UserFixture) WithTags(v []string) *UserFixture {
+	f.row.Tags = v
+	f.set["tags"] = true
+	return f
+}
+
+func (f *UserFixture) WithCreatedAt(v time.Time) *UserFixture {
+	f.row.CreatedAt = v
+	f.set["created_at"] = true
+	return f
+}
+
+// Insert the row, returning it as stored by the database
+func (f *UserFixture) Insert(ctx context.Context, conn querytest.DBTX) (querytest.User, error) {
+	var cols []string
+	var args []interface{}
+	if f.set["id"] {
+		cols = append(cols, `"id"`)
+		args = append(args, f.row.ID)
+	}
+	if f.set["email"] {
+		cols = append(cols, `"email"`)
+		args = append(args, f.row.Email)
+	}
+	if f.set["name"] {
+		cols = append(cols, `"name"`)
+		args = append(args, f.row.Name)
+	}
+	if f.set["status"] {
+		cols = append(cols, `"status"`)
+		args = append(args, f.row.Status)
+	}
+	if f.set["tags"] {
+		cols = append(cols, `"tags"`)
+		args = append(args, pq.Array(f.row.Tags))
+	}
+	if f.set["created_at"] {
+		cols = append(cols, `"created_at"`)
+		args = append(args, f.row.CreatedAt)
+	}
+	query := `INSERT INTO "public"."users"`
+	if len(cols) == 0 {
+		query += " DEFAULT VALUES"
+	} else {
+		params := make([]string, len(cols))
+		for i := range params {
+			params[i] = fmt.Sprintf("$%d", i+1)
+		}
+		query += " (" + strings.Join(cols, ", ") + ") VALUES (" + strings.Join(params, ", ") + ")"
+	}
+	row := conn.QueryRowContext(ctx, query+` RETURNING "id", "email", "name", "status", "tags", "created_at"`, args...)
+	var i querytest.User
+	err := row.Scan(&i.ID, &i.Email, &i.Name, &i.Status, pq.Array(&i.Tags), &i.CreatedAt)
+	return i, err
+}
@@ -0,0 +1,17 @@
+CREATE TYPE status AS ENUM ('active', 'banned');
+CREATE SCHEMA billing;
+CREATE TABLE users (
+    id         SERIAL PRIMARY KEY,
+    email      TEXT NOT NULL,
+    name       TEXT,
+    status     status NOT NULL DEFAULT 'active',
+    tags       TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE TABLE billing.invoices (
+    id      BIGSERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (id),
+    "order" INT NOT NULL
+);
+ALTER TABLE users ALTER COLUMN name SET DEFAULT 'anon';
+ALTER TABLE users ALTER COLUMN name DROP DEFAULT;
@@ -0,0 +1,80 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// QueryHook observes the queries run through HookedQueries, such as to record
+// their latency or to start a tracing span for each one.
+type QueryHook interface {
+	// BeforeQuery is called before the query runs. The returned context is
+	// used to run the query and is passed to AfterQuery.
+	BeforeQuery(ctx context.Context, name string) context.Context
+
+	// AfterQuery is called once the query has finished
+	AfterQuery(ctx context.Context, name string, duration time.Duration, err error)
+}
+
+// HookedQueries runs the queries of Queries, calling the hook around each one
+type HookedQueries struct {
+	q    *Queries
+	hook QueryHook
+}
+
+func NewHooked(q *Queries, hook QueryHook) *HookedQueries {
+	return &HookedQueries{q: q, hook: hook}
+}
+
+func (h *HookedQueries) WithTx(tx *sql.Tx) *HookedQueries {
+	return &HookedQueries{q: h.q.WithTx(tx), hook: h.hook}
+}
+
+type queryCall struct {
+	ctx   context.Context
+	hook  QueryHook
+	name  string
+	start time.Time
+}
+
+func (h *HookedQueries) before(ctx context.Context, name string) (context.Context, queryCall) {
+	ctx = h.hook.BeforeQuery(ctx, name)
+	return ctx, queryCall{ctx: ctx, hook: h.hook, name: name, start: time.Now()}
+}
+
+func (c queryCall) end(err error) {
+	c.hook.AfterQuery(c.ctx, c.name, time.Since(c.start), err)
+}
+
+var _ Querier = (*HookedQueries)(nil)
+
+func (h *HookedQueries) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	ctx, call := h.before(ctx, "DeleteExpiredSessions")
+	n, err := h.q.DeleteExpiredSessions(ctx)
+	call.end(err)
+	return n, err
+}
+
+func (h *HookedQueries) ExtendSession(ctx context.Context, arg ExtendSessionParams) error {
+	ctx, call := h.before(ctx, "ExtendSession")
+	err := h.q.ExtendSession(ctx, arg)
+	call.end(err)
+	return err
+}
+
+func (h *HookedQueries) GetSession(ctx context.Context, id int32) (Session, error) {
+	ctx, call := h.before(ctx, "GetSession")
+	i, err := h.q.GetSession(ctx, id)
+	call.end(err)
+	return i, err
+}
+
+func (h *HookedQueries) ListSessions(ctx context.Context, userID int32, orderBy ListSessionsSort) ([]Session, error) {
+	ctx, call := h.before(ctx, "ListSessions")
+	items, err := h.q.ListSessions(ctx, userID, orderBy)
+	call.end(err)
+	return items, err
+}
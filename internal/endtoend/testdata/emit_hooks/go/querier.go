@@ -0,0 +1,16 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+)
+
+type Querier interface {
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+	ExtendSession(ctx context.Context, arg ExtendSessionParams) error
+	GetSession(ctx context.Context, id int32) (Session, error)
+	ListSessions(ctx context.Context, userID int32, orderBy ListSessionsSort) ([]Session, error)
+}
+
+var _ Querier = (*Queries)(nil)
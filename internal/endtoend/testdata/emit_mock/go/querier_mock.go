@@ -0,0 +1,79 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MockQuerier implements Querier for tests. Each method calls the function in
+// the matching field, which panics if the field is nil, and every call is
+// recorded.
+type MockQuerier struct {
+	DeleteExpiredSessionsFunc func(ctx context.Context) (int64, error)
+	ExtendSessionFunc         func(ctx context.Context, arg ExtendSessionParams) error
+	GetSessionFunc            func(ctx context.Context, id int32) (Session, error)
+	ListSessionsFunc          func(ctx context.Context, userID int32, orderBy ListSessionsSort) ([]Session, error)
+
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+// A call to a method of MockQuerier, with the arguments following the context
+type MockCall struct {
+	Method string
+	Args   []interface{}
+}
+
+var _ Querier = (*MockQuerier)(nil)
+
+// Calls returns the calls made so far, in order
+func (m *MockQuerier) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall(nil), m.calls...)
+}
+
+func (m *MockQuerier) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+}
+
+func mockNotSet(method string) string {
+	return fmt.Sprintf("MockQuerier.%sFunc is not set", method)
+}
+
+func (m *MockQuerier) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	m.record("DeleteExpiredSessions")
+	if m.DeleteExpiredSessionsFunc == nil {
+		panic(mockNotSet("DeleteExpiredSessions"))
+	}
+	return m.DeleteExpiredSessionsFunc(ctx)
+}
+
+func (m *MockQuerier) ExtendSession(ctx context.Context, arg ExtendSessionParams) error {
+	m.record("ExtendSession", arg)
+	if m.ExtendSessionFunc == nil {
+		panic(mockNotSet("ExtendSession"))
+	}
+	return m.ExtendSessionFunc(ctx, arg)
+}
+
+func (m *MockQuerier) GetSession(ctx context.Context, id int32) (Session, error) {
+	m.record("GetSession", id)
+	if m.GetSessionFunc == nil {
+		panic(mockNotSet("GetSession"))
+	}
+	return m.GetSessionFunc(ctx, id)
+}
+
+func (m *MockQuerier) ListSessions(ctx context.Context, userID int32, orderBy ListSessionsSort) ([]Session, error) {
+	m.record("ListSessions", userID, orderBy)
+	if m.ListSessionsFunc == nil {
+		panic(mockNotSet("ListSessions"))
+	}
+	return m.ListSessionsFunc(ctx, userID, orderBy)
+}
@@ -0,0 +1,122 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions WHERE expires_at < now()
+`
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const extendSession = `-- name: ExtendSession :exec
+UPDATE sessions SET expires_at = $2 WHERE id = $1
+`
+
+type ExtendSessionParams struct {
+	ID        int32
+	ExpiresAt time.Time
+}
+
+func (q *Queries) ExtendSession(ctx context.Context, arg ExtendSessionParams) error {
+	_, err := q.db.ExecContext(ctx, extendSession, arg.ID, arg.ExpiresAt)
+	return err
+}
+
+const getSession = `-- name: GetSession :one
+SELECT id, user_id, expires_at FROM sessions WHERE id = $1
+`
+
+func (q *Queries) GetSession(ctx context.Context, id int32) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSession, id)
+	var i Session
+	err := row.Scan(&i.ID, &i.UserID, &i.ExpiresAt)
+	return i, err
+}
+
+const listSessions = `-- name: ListSessions :many
+SELECT id, user_id, expires_at FROM sessions WHERE user_id = $1 ORDER BY sqlc_sort
+`
+
+type ListSessionsSort string
+
+const (
+	ListSessionsSortIDAsc         ListSessionsSort = "id ASC"
+	ListSessionsSortIDDesc        ListSessionsSort = "id DESC"
+	ListSessionsSortExpiresAtAsc  ListSessionsSort = "expires_at ASC"
+	ListSessionsSortExpiresAtDesc ListSessionsSort = "expires_at DESC"
+)
+
+func (s ListSessionsSort) Valid() bool {
+	switch s {
+	case ListSessionsSortIDAsc, ListSessionsSortIDDesc, ListSessionsSortExpiresAtAsc, ListSessionsSortExpiresAtDesc:
+		return true
+	}
+	return false
+}
+
+func (q *Queries) ListSessions(ctx context.Context, userID int32, orderBy ListSessionsSort) ([]Session, error) {
+	if !orderBy.Valid() {
+		return nil, fmt.Errorf("invalid sort order: %q", orderBy)
+	}
+	rows, err := q.db.QueryContext(ctx, strings.Replace(listSessions, "sqlc_sort", string(orderBy), 1), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(&i.ID, &i.UserID, &i.ExpiresAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserIDs = `-- name: ListUserIDs :many
+SELECT user_id FROM sessions
+`
+
+func (q *Queries) listUserIDs(ctx context.Context) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,16 @@
+-- name: GetSession :one
+SELECT * FROM sessions WHERE id = $1;
+
+-- name: ListSessions :many
+-- sort: id, expires_at
+SELECT * FROM sessions WHERE user_id = $1 ORDER BY id;
+
+-- name: ExtendSession :exec
+UPDATE sessions SET expires_at = $2 WHERE id = $1;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions WHERE expires_at < now();
+
+-- name: ListUserIDs :many
+-- method: listUserIDs
+SELECT user_id FROM sessions;
@@ -0,0 +1,8 @@
+CREATE TABLE bar (id serial not null);
+
+-- name: ListBar :many
+SELECT id FROM bar;
+
+-- stderr
+-- # package querytest
+-- error generating code: emit_mock requires emit_interface
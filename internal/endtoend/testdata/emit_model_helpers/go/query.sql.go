@@ -0,0 +1,58 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, "firstName", tags, bio FROM authors WHERE id = $1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		pq.Array(&i.Tags),
+		&i.Bio,
+	)
+	return i, err
+}
+
+const listRunOrders = `-- name: ListRunOrders :many
+SELECT id, "order" FROM jobs.runs
+`
+
+type ListRunOrdersRow struct {
+	ID    int64
+	Order int32
+}
+
+func (q *Queries) ListRunOrders(ctx context.Context) ([]ListRunOrdersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRunOrders)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRunOrdersRow
+	for rows.Next() {
+		var i ListRunOrdersRow
+		if err := rows.Scan(&i.ID, &i.Order); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,5 @@
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
+
+-- name: ListRunOrders :many
+SELECT id, "order" FROM jobs.runs;
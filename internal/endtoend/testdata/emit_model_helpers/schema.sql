@@ -0,0 +1,14 @@
+CREATE SCHEMA jobs;
+
+CREATE TABLE authors (
+    id          BIGSERIAL PRIMARY KEY,
+    "firstName" text      NOT NULL,
+    tags        text[]    NOT NULL,
+    bio         text
+);
+
+CREATE TABLE jobs.runs (
+    id      BIGSERIAL PRIMARY KEY,
+    "order" integer   NOT NULL,
+    timeout interval  NOT NULL
+);
@@ -0,0 +1,6 @@
+-- name: ListReports :many
+SELECT * FROM reports;
+
+-- stderr
+-- # package querytest
+-- error generating code: emit_model_helpers: the TableName field of Report conflicts with its TableName method
@@ -0,0 +1,4 @@
+CREATE TABLE reports (
+    id         BIGSERIAL PRIMARY KEY,
+    table_name text      NOT NULL
+);
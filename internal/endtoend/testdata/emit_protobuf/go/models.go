@@ -0,0 +1,173 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/geo"
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusOpen   Status = "open"
+	StatusClosed Status = "closed"
+)
+
+func (e *Status) Scan(src interface{}) error {
+	*e = Status(src.([]byte))
+	return nil
+}
+
+type Account struct {
+	ID    int64
+	Email string
+	// @sensitive
+	PasswordHash string `log:"-"`
+}
+
+// String formats the Account without its sensitive fields
+func (s Account) String() string {
+	return fmt.Sprintf("Account{ID:%v Email:%v PasswordHash:[redacted]}", s.ID, s.Email)
+}
+
+// Authors of the books
+type Author struct {
+	ID        int64
+	AccountID uuid.UUID
+	// The full name
+	Name      string
+	Bio       sql.NullString
+	Age       sql.NullInt32
+	Rank      int16
+	Score     sql.NullFloat64
+	Active    sql.NullBool
+	Status    Status
+	Tags      []string
+	Ranks     []int16
+	Friends   []uuid.UUID
+	Metadata  json.RawMessage
+	Avatar    []byte
+	Ip        net.IP
+	Mac       net.HardwareAddr
+	CreatedAt time.Time
+	DeletedAt sql.NullTime
+	Timeout   time.Duration
+	Grace     NullDuration
+	Location  geo.Point
+}
+
+type Tag struct {
+	Name string
+}
+
+// NullDuration is an interval that may be NULL. Intervals are read in the
+// default postgres IntervalStyle, counting a month as 30 days and a year as
+// 365.25 days. They're written in microseconds, the precision of an interval.
+type NullDuration struct {
+	Duration time.Duration
+	Valid    bool // Valid is true if Duration is not NULL
+}
+
+func (n *NullDuration) Scan(src interface{}) error {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		n.Duration, n.Valid = 0, false
+		return nil
+	case []byte:
+		s = string(src)
+	case string:
+		s = src
+	default:
+		return fmt.Errorf("cannot scan %T into NullDuration", src)
+	}
+	d, err := parseInterval(s)
+	if err != nil {
+		return err
+	}
+	n.Duration, n.Valid = d, true
+	return nil
+}
+
+func (n NullDuration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return fmt.Sprintf("%d microseconds", int64(n.Duration/time.Microsecond)), nil
+}
+
+// parseInterval reads an interval such as "1 year 2 mons -3 days +04:05:06.5"
+func parseInterval(s string) (time.Duration, error) {
+	var d time.Duration
+	add := func(n int64, unit time.Duration) error {
+		v := time.Duration(n) * unit
+		if n != 0 && v/unit != time.Duration(n) || v > 0 && d > math.MaxInt64-v || v < 0 && d < math.MinInt64-v {
+			return fmt.Errorf("interval %q overflows time.Duration", s)
+		}
+		d += v
+		return nil
+	}
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			sign := int64(1)
+			if strings.HasPrefix(fields[i], "-") {
+				sign = -1
+			}
+			hms := strings.Split(strings.TrimLeft(fields[i], "+-"), ":")
+			if len(hms) != 3 {
+				return 0, fmt.Errorf("invalid interval %q", s)
+			}
+			sec := strings.SplitN(hms[2], ".", 2)
+			frac := "0"
+			if len(sec) == 2 {
+				frac = (sec[1] + "000000000")[:9]
+			}
+			units := []time.Duration{time.Hour, time.Minute, time.Second, time.Nanosecond}
+			for j, value := range []string{hms[0], hms[1], sec[0], frac} {
+				n, err := strconv.ParseInt(value, 10, 64)
+				if err != nil || n < 0 {
+					return 0, fmt.Errorf("invalid interval %q", s)
+				}
+				if err := add(sign*n, units[j]); err != nil {
+					return 0, err
+				}
+			}
+			continue
+		}
+		if i+1 == len(fields) {
+			return 0, fmt.Errorf("invalid interval %q", s)
+		}
+		n, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q", s)
+		}
+		var unit time.Duration
+		switch fields[i+1] {
+		case "year", "years":
+			unit = 8766 * time.Hour
+		case "mon", "mons":
+			unit = 720 * time.Hour
+		case "day", "days":
+			unit = 24 * time.Hour
+		default:
+			return 0, fmt.Errorf("invalid interval %q, expected the postgres IntervalStyle", s)
+		}
+		if err := add(n, unit); err != nil {
+			return 0, err
+		}
+		i++
+	}
+	return d, nil
+}
@@ -0,0 +1,153 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	pb "example.com/api/querytestpb"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// AccountToProto converts the model to its protobuf message
+func AccountToProto(m Account) *pb.Account {
+	p := &pb.Account{
+		Id:    m.ID,
+		Email: m.Email,
+	}
+	return p
+}
+
+// AccountFromProto converts the protobuf message to its model
+func AccountFromProto(p *pb.Account) (Account, error) {
+	m := Account{
+		ID:    p.GetId(),
+		Email: p.GetEmail(),
+	}
+	return m, nil
+}
+
+// AuthorToProto converts the model to its protobuf message
+func AuthorToProto(m Author) *pb.Author {
+	p := &pb.Author{
+		Id:        m.ID,
+		AccountId: m.AccountID.String(),
+		Name:      m.Name,
+		Rank:      int32(m.Rank),
+		Status:    string(m.Status),
+		Tags:      m.Tags,
+		Metadata:  []byte(m.Metadata),
+		Avatar:    m.Avatar,
+		CreatedAt: timestamppb.New(m.CreatedAt),
+		Timeout:   durationpb.New(m.Timeout),
+	}
+	if m.Bio.Valid {
+		p.Bio = wrapperspb.String(m.Bio.String)
+	}
+	if m.Age.Valid {
+		p.Age = wrapperspb.Int32(m.Age.Int32)
+	}
+	if m.Score.Valid {
+		p.Score = wrapperspb.Double(m.Score.Float64)
+	}
+	if m.Active.Valid {
+		p.Active = wrapperspb.Bool(m.Active.Bool)
+	}
+	for _, v := range m.Ranks {
+		p.Ranks = append(p.Ranks, int32(v))
+	}
+	for _, v := range m.Friends {
+		p.Friends = append(p.Friends, v.String())
+	}
+	if m.Ip != nil {
+		p.Ip = m.Ip.String()
+	}
+	if m.Mac != nil {
+		p.Mac = m.Mac.String()
+	}
+	if m.DeletedAt.Valid {
+		p.DeletedAt = timestamppb.New(m.DeletedAt.Time)
+	}
+	if m.Grace.Valid {
+		p.Grace = durationpb.New(m.Grace.Duration)
+	}
+	return p
+}
+
+// AuthorFromProto converts the protobuf message to its model
+func AuthorFromProto(p *pb.Author) (Author, error) {
+	m := Author{
+		ID:        p.GetId(),
+		Name:      p.GetName(),
+		Rank:      int16(p.GetRank()),
+		Status:    Status(p.GetStatus()),
+		Tags:      p.GetTags(),
+		Metadata:  json.RawMessage(p.GetMetadata()),
+		Avatar:    p.GetAvatar(),
+		CreatedAt: p.GetCreatedAt().AsTime(),
+		Timeout:   p.GetTimeout().AsDuration(),
+	}
+	var err error
+	if m.AccountID, err = uuid.Parse(p.GetAccountId()); err != nil {
+		return Author{}, fmt.Errorf("account_id: %w", err)
+	}
+	if v := p.GetBio(); v != nil {
+		m.Bio = sql.NullString{String: v.GetValue(), Valid: true}
+	}
+	if v := p.GetAge(); v != nil {
+		m.Age = sql.NullInt32{Int32: v.GetValue(), Valid: true}
+	}
+	if v := p.GetScore(); v != nil {
+		m.Score = sql.NullFloat64{Float64: v.GetValue(), Valid: true}
+	}
+	if v := p.GetActive(); v != nil {
+		m.Active = sql.NullBool{Bool: v.GetValue(), Valid: true}
+	}
+	for _, v := range p.GetRanks() {
+		m.Ranks = append(m.Ranks, int16(v))
+	}
+	for _, v := range p.GetFriends() {
+		x, err := uuid.Parse(v)
+		if err != nil {
+			return Author{}, fmt.Errorf("friends: %w", err)
+		}
+		m.Friends = append(m.Friends, x)
+	}
+	if v := p.GetIp(); v != "" {
+		m.Ip = net.ParseIP(v)
+	}
+	if v := p.GetMac(); v != "" {
+		if m.Mac, err = net.ParseMAC(v); err != nil {
+			return Author{}, fmt.Errorf("mac: %w", err)
+		}
+	}
+	if v := p.GetDeletedAt(); v != nil {
+		m.DeletedAt = sql.NullTime{Time: v.AsTime(), Valid: true}
+	}
+	if v := p.GetGrace(); v != nil {
+		m.Grace = NullDuration{Duration: v.AsDuration(), Valid: true}
+	}
+	return m, nil
+}
+
+// TagToProto converts the model to its protobuf message
+func TagToProto(m Tag) *pb.Tag {
+	p := &pb.Tag{
+		Name: m.Name,
+	}
+	return p
+}
+
+// TagFromProto converts the protobuf message to its model
+func TagFromProto(p *pb.Tag) (Tag, error) {
+	m := Tag{
+		Name: p.GetName(),
+	}
+	return m, nil
+}
@@ -0,0 +1,43 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, account_id, name, bio, age, rank, score, active, status, tags, ranks, friends, metadata, avatar, ip, mac, created_at, deleted_at, timeout, grace, location FROM authors WHERE id = $1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Name,
+		&i.Bio,
+		&i.Age,
+		&i.Rank,
+		&i.Score,
+		&i.Active,
+		&i.Status,
+		pq.Array(&i.Tags),
+		pq.Array(&i.Ranks),
+		pq.Array(&i.Friends),
+		&i.Metadata,
+		&i.Avatar,
+		&i.Ip,
+		&i.Mac,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		intervalScan{&i.Timeout},
+		&i.Grace,
+		&i.Location,
+	)
+	return i, err
+}
@@ -0,0 +1,38 @@
+CREATE TYPE status AS ENUM ('open', 'closed');
+
+CREATE TABLE authors (
+    id          BIGSERIAL PRIMARY KEY,
+    account_id  uuid NOT NULL,
+    name        text NOT NULL,
+    bio         text,
+    age         integer,
+    rank        smallint NOT NULL,
+    score       double precision,
+    active      bool,
+    status      status NOT NULL,
+    tags        text[] NOT NULL,
+    ranks       smallint[] NOT NULL,
+    friends     uuid[] NOT NULL,
+    metadata    jsonb NOT NULL,
+    avatar      bytea,
+    ip          inet,
+    mac         macaddr,
+    created_at  timestamptz NOT NULL,
+    deleted_at  timestamptz,
+    timeout     interval NOT NULL,
+    grace       interval,
+    location    text
+);
+COMMENT ON COLUMN authors.name IS 'The full name';
+COMMENT ON TABLE authors IS 'Authors of the books';
+
+CREATE TABLE tags (
+    name text NOT NULL
+);
+
+CREATE TABLE accounts (
+    id            BIGSERIAL PRIMARY KEY,
+    email         text NOT NULL,
+    password_hash text NOT NULL
+);
+COMMENT ON COLUMN accounts.password_hash IS '@sensitive';
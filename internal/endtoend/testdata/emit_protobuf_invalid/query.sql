@@ -0,0 +1,6 @@
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
+
+-- stderr
+-- # package querytest
+-- error generating code: emit_protobuf: "example-api.v1" isn't a valid protobuf package
@@ -0,0 +1,4 @@
+CREATE TABLE authors (
+    id   BIGSERIAL PRIMARY KEY,
+    name text NOT NULL
+);
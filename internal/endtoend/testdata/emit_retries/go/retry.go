@@ -0,0 +1,152 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// RetryPolicy controls how RetryQueries runs queries
+type RetryPolicy struct {
+	// Timeout of each attempt. Attempts don't time out if zero.
+	Timeout time.Duration
+
+	// Number of attempts, including the first. Queries are only attempted
+	// once if zero.
+	MaxAttempts int
+
+	// Delay before the second attempt, doubled before each attempt after it
+	Backoff time.Duration
+
+	// Retryable reports whether a failed attempt should be retried. If nil,
+	// serialization failures and deadlocks are retried.
+	Retryable func(error) bool
+}
+
+// RetryQueries runs the queries of Queries with the timeout of its policy, and
+// runs them again when they fail with a retryable error
+type RetryQueries struct {
+	q      *Queries
+	policy RetryPolicy
+	inTx   bool
+}
+
+func NewRetry(q *Queries, policy RetryPolicy) *RetryQueries {
+	return &RetryQueries{q: q, policy: policy}
+}
+
+// WithTx runs the queries in a transaction. Once a statement fails, the
+// transaction is aborted, so queries are only attempted once. Use RunInTx to
+// retry the whole transaction instead.
+func (r *RetryQueries) WithTx(tx *sql.Tx) *RetryQueries {
+	return &RetryQueries{q: r.q.WithTx(tx), policy: r.policy, inTx: true}
+}
+
+// RunInTx runs fn in a transaction and commits it. The transaction is rolled
+// back and fn is run again in a new transaction when it fails with a
+// retryable error, as SERIALIZABLE transactions must be.
+func (r *RetryQueries) RunInTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(context.Context, *RetryQueries) error) error {
+	return r.run(ctx, func(ctx context.Context) error {
+		tx, err := db.BeginTx(ctx, opts)
+		if err != nil {
+			return err
+		}
+		if err := fn(ctx, r.WithTx(tx)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+func (r *RetryQueries) run(ctx context.Context, fn func(context.Context) error) error {
+	attempts := r.policy.MaxAttempts
+	if attempts < 1 || r.inTx {
+		attempts = 1
+	}
+	backoff := r.policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := r.attempt(ctx, fn)
+		if err == nil || attempt >= attempts || !r.retryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (r *RetryQueries) attempt(ctx context.Context, fn func(context.Context) error) error {
+	if r.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.policy.Timeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+func (r *RetryQueries) retryable(err error) bool {
+	if r.policy.Retryable != nil {
+		return r.policy.Retryable(err)
+	}
+	return IsSerializationFailure(err)
+}
+
+// IsSerializationFailure reports whether err is a PostgreSQL serialization
+// failure (40001) or deadlock (40P01). Both lib/pq and pgx errors report their
+// SQLSTATE code.
+func IsSerializationFailure(err error) bool {
+	var state interface{ SQLState() string }
+	if !errors.As(err, &state) {
+		return false
+	}
+	switch state.SQLState() {
+	case "40001", "40P01":
+		return true
+	}
+	return false
+}
+
+var _ Querier = (*RetryQueries)(nil)
+
+func (r *RetryQueries) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	var n int64
+	err := r.run(ctx, func(ctx context.Context) error {
+		var err error
+		n, err = r.q.DeleteExpiredSessions(ctx)
+		return err
+	})
+	return n, err
+}
+
+func (r *RetryQueries) ExtendSession(ctx context.Context, arg ExtendSessionParams) error {
+	return r.run(ctx, func(ctx context.Context) error {
+		return r.q.ExtendSession(ctx, arg)
+	})
+}
+
+func (r *RetryQueries) GetSession(ctx context.Context, id int32) (Session, error) {
+	var i Session
+	err := r.run(ctx, func(ctx context.Context) error {
+		var err error
+		i, err = r.q.GetSession(ctx, id)
+		return err
+	})
+	return i, err
+}
+
+func (r *RetryQueries) ListSessions(ctx context.Context, userID int32, orderBy ListSessionsSort) ([]Session, error) {
+	var items []Session
+	err := r.run(ctx, func(ctx context.Context) error {
+		var err error
+		items, err = r.q.ListSessions(ctx, userID, orderBy)
+		return err
+	})
+	return items, err
+}
@@ -0,0 +1,10 @@
+CREATE TABLE users (id BIGSERIAL PRIMARY KEY, name TEXT NOT NULL, bio TEXT);
+
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: ListUsers :many
+SELECT * FROM users;
+
+-- name: GetUserName :one
+SELECT name FROM users WHERE id = $1;
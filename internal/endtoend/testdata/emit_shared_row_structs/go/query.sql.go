@@ -0,0 +1,111 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getUserName = `-- name: GetUserName :one
+SELECT id, name FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUserName(ctx context.Context, id int64) (ListUserNamesRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserName, id)
+	var i ListUserNamesRow
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const listUserBios = `-- name: ListUserBios :many
+SELECT id, bio FROM users
+`
+
+type ListUserBiosRow struct {
+	ID  int64
+	Bio sql.NullString
+}
+
+func (q *Queries) ListUserBios(ctx context.Context) ([]ListUserBiosRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserBios)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserBiosRow
+	for rows.Next() {
+		var i ListUserBiosRow
+		if err := rows.Scan(&i.ID, &i.Bio); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserNames = `-- name: ListUserNames :many
+SELECT id, name FROM users
+`
+
+type ListUserNamesRow struct {
+	ID   int64
+	Name string
+}
+
+func (q *Queries) ListUserNames(ctx context.Context) ([]ListUserNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserNamesRow
+	for rows.Next() {
+		var i ListUserNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchUserNames = `-- name: SearchUserNames :many
+SELECT id, name FROM users WHERE name LIKE $1
+`
+
+func (q *Queries) SearchUserNames(ctx context.Context, name string) ([]ListUserNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchUserNames, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserNamesRow
+	for rows.Next() {
+		var i ListUserNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
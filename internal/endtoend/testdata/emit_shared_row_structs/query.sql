@@ -0,0 +1,13 @@
+CREATE TABLE users (id BIGSERIAL PRIMARY KEY, name TEXT NOT NULL, bio TEXT);
+
+-- name: ListUserNames :many
+SELECT id, name FROM users;
+
+-- name: GetUserName :one
+SELECT id, name FROM users WHERE id = $1;
+
+-- name: SearchUserNames :many
+SELECT id, name FROM users WHERE name LIKE $1;
+
+-- name: ListUserBios :many
+SELECT id, bio FROM users;
@@ -0,0 +1,95 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package enum
+
+import (
+	"fmt"
+)
+
+type JobStatus string
+
+const (
+	Queued        JobStatus = "queued"
+	InProgress    JobStatus = "in-progress"
+	OnHold        JobStatus = "on hold"
+	NotApplicable JobStatus = "n/a"
+)
+
+func (e *JobStatus) Scan(src interface{}) error {
+	*e = JobStatus(src.([]byte))
+	return nil
+}
+
+// AllJobStatusValues returns the values of JobStatus in the order they're declared
+func AllJobStatusValues() []JobStatus {
+	return []JobStatus{
+		Queued,
+		InProgress,
+		OnHold,
+		NotApplicable,
+	}
+}
+
+// Valid reports whether e is one of the values of JobStatus
+func (e JobStatus) Valid() bool {
+	switch e {
+	case Queued, InProgress, OnHold, NotApplicable:
+		return true
+	}
+	return false
+}
+
+// ParseJobStatus converts s to a JobStatus, failing if it isn't one of its values
+func ParseJobStatus(s string) (JobStatus, error) {
+	e := JobStatus(s)
+	if !e.Valid() {
+		return "", fmt.Errorf("invalid JobStatus value: %q", s)
+	}
+	return e, nil
+}
+
+type JobsPriority string
+
+const (
+	JobsPriority1st JobsPriority = "1st"
+	SecondPriority  JobsPriority = "2nd"
+	High            JobsPriority = "high"
+)
+
+func (e *JobsPriority) Scan(src interface{}) error {
+	*e = JobsPriority(src.([]byte))
+	return nil
+}
+
+// AllJobsPriorityValues returns the values of JobsPriority in the order they're declared
+func AllJobsPriorityValues() []JobsPriority {
+	return []JobsPriority{
+		JobsPriority1st,
+		SecondPriority,
+		High,
+	}
+}
+
+// Valid reports whether e is one of the values of JobsPriority
+func (e JobsPriority) Valid() bool {
+	switch e {
+	case JobsPriority1st, SecondPriority, High:
+		return true
+	}
+	return false
+}
+
+// ParseJobsPriority converts s to a JobsPriority, failing if it isn't one of its values
+func ParseJobsPriority(s string) (JobsPriority, error) {
+	e := JobsPriority(s)
+	if !e.Valid() {
+		return "", fmt.Errorf("invalid JobsPriority value: %q", s)
+	}
+	return e, nil
+}
+
+type Job struct {
+	ID       int32
+	Status   JobStatus
+	Priority JobsPriority
+}
@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package enum
+
+import (
+	"context"
+)
+
+const listJobsByStatus = `-- name: ListJobsByStatus :many
+SELECT id, status, priority FROM jobs WHERE status = $1
+`
+
+func (q *Queries) ListJobsByStatus(ctx context.Context, status JobStatus) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, listJobsByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(&i.ID, &i.Status, &i.Priority); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
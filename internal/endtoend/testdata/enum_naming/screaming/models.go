@@ -0,0 +1,38 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package screaming
+
+import ()
+
+type JobStatus string
+
+const (
+	JOB_STATUS_QUEUED      JobStatus = "queued"
+	JOB_STATUS_IN_PROGRESS JobStatus = "in-progress"
+	JOB_STATUS_ON_HOLD     JobStatus = "on hold"
+	JOB_STATUS_N_A         JobStatus = "n/a"
+)
+
+func (e *JobStatus) Scan(src interface{}) error {
+	*e = JobStatus(src.([]byte))
+	return nil
+}
+
+type JobsPriority string
+
+const (
+	JOBS_PRIORITY_1ST  JobsPriority = "1st"
+	JOBS_PRIORITY_2ND  JobsPriority = "2nd"
+	JOBS_PRIORITY_HIGH JobsPriority = "high"
+)
+
+func (e *JobsPriority) Scan(src interface{}) error {
+	*e = JobsPriority(src.([]byte))
+	return nil
+}
+
+type Job struct {
+	ID       int32
+	Status   JobStatus
+	Priority JobsPriority
+}
@@ -0,0 +1,2 @@
+-- name: ListJobsByStatus :many
+SELECT * FROM jobs WHERE status = $1;
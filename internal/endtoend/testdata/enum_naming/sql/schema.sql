@@ -0,0 +1,10 @@
+CREATE TYPE job_status AS ENUM ('queued', 'in-progress', 'on hold', 'n/a');
+
+CREATE SCHEMA jobs;
+CREATE TYPE jobs.priority AS ENUM ('1st', '2nd', 'high');
+
+CREATE TABLE jobs (
+    id     SERIAL PRIMARY KEY,
+    status job_status NOT NULL,
+    priority jobs.priority NOT NULL
+);
@@ -0,0 +1,16 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+)
+
+type Querier interface {
+	DeleteAuthor(ctx context.Context, authorID int64) error
+	GetAuthor(ctx context.Context, id int64) (Author, error)
+	RenameAuthor(ctx context.Context, arg RenameAuthorParams) error
+	UpdateAuthorBioAs(ctx context.Context, arg UpdateAuthorBioAsParams) error
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,108 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const deleteAuthor = `-- name: DeleteAuthor :execscript
+SET LOCAL lock_timeout = '1s'
+`
+
+const deleteAuthorStep2 = `-- name: DeleteAuthor :execscript
+DELETE FROM audit_log WHERE author_id = $1
+`
+
+const deleteAuthorStep3 = `-- name: DeleteAuthor :execscript
+DELETE FROM authors WHERE id = $1
+`
+
+func (q *Queries) DeleteAuthor(ctx context.Context, authorID int64) error {
+	return q.script(ctx, func(db DBTX) error {
+		if _, err := db.ExecContext(ctx, deleteAuthor); err != nil {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, deleteAuthorStep2, authorID); err != nil {
+			return err
+		}
+		_, err := db.ExecContext(ctx, deleteAuthorStep3, authorID)
+		return err
+	})
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, bio FROM authors WHERE id = $1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name, &i.Bio)
+	return i, err
+}
+
+const renameAuthor = `-- name: RenameAuthor :execscript
+SET LOCAL statement_timeout = '5s'
+`
+
+const renameAuthorStep2 = `-- name: RenameAuthor :execscript
+UPDATE authors SET name = $2 WHERE id = $1
+`
+
+const renameAuthorStep3 = `-- name: RenameAuthor :execscript
+INSERT INTO audit_log (author_id, action) VALUES ($1, $2)
+`
+
+type RenameAuthorParams struct {
+	ID     int64
+	Name   string
+	Action string
+}
+
+func (q *Queries) RenameAuthor(ctx context.Context, arg RenameAuthorParams) error {
+	return q.script(ctx, func(db DBTX) error {
+		if _, err := db.ExecContext(ctx, renameAuthor); err != nil {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, renameAuthorStep2, arg.ID, arg.Name); err != nil {
+			return err
+		}
+		_, err := db.ExecContext(ctx, renameAuthorStep3, arg.ID, arg.Action)
+		return err
+	})
+}
+
+const updateAuthorBioAs = `-- name: UpdateAuthorBioAs :execscript
+SELECT set_config('app.user_id', $1::text, true)
+`
+
+const updateAuthorBioAsStep2 = `-- name: UpdateAuthorBioAs :execscript
+UPDATE authors SET bio = $1 WHERE id = $2
+`
+
+const updateAuthorBioAsStep3 = `-- name: UpdateAuthorBioAs :execscript
+INSERT INTO audit_log (author_id, action) VALUES ($1, 'update bio')
+`
+
+type UpdateAuthorBioAsParams struct {
+	UserID string
+	Bio    sql.NullString
+	ID     int64
+}
+
+// Update a bio as an application user, for row-level security policies
+func (q *Queries) UpdateAuthorBioAs(ctx context.Context, arg UpdateAuthorBioAsParams) error {
+	return q.script(ctx, func(db DBTX) error {
+		if _, err := db.ExecContext(ctx, updateAuthorBioAs, arg.UserID); err != nil {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, updateAuthorBioAsStep2, arg.Bio, arg.ID); err != nil {
+			return err
+		}
+		_, err := db.ExecContext(ctx, updateAuthorBioAsStep3, arg.ID)
+		return err
+	})
+}
@@ -0,0 +1,18 @@
+-- name: UpdateAuthorBioAs :execscript
+-- Update a bio as an application user, for row-level security policies
+SELECT set_config('app.user_id', @user_id::text, true);
+UPDATE authors SET bio = @bio WHERE id = @id;
+INSERT INTO audit_log (author_id, action) VALUES (@id, 'update bio');
+
+-- name: DeleteAuthor :execscript
+SET LOCAL lock_timeout = '1s';
+DELETE FROM audit_log WHERE author_id = $1;
+DELETE FROM authors WHERE id = $1;
+
+-- name: RenameAuthor :execscript
+SET LOCAL statement_timeout = '5s';
+UPDATE authors SET name = $2 WHERE id = $1;
+INSERT INTO audit_log (author_id, action) VALUES ($1, $3);
+
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
@@ -0,0 +1,11 @@
+CREATE TABLE authors (
+    id   BIGSERIAL PRIMARY KEY,
+    name text      NOT NULL,
+    bio  text
+);
+
+CREATE TABLE audit_log (
+    id        BIGSERIAL PRIMARY KEY,
+    author_id bigint    NOT NULL,
+    action    text      NOT NULL
+);
@@ -0,0 +1,22 @@
+-- name: MixedParams :execscript
+UPDATE authors SET bio = @bio WHERE id = @id;
+DELETE FROM audit_log WHERE author_id = $1;
+
+-- name: MissingParam :execscript
+SET LOCAL lock_timeout = '1s';
+DELETE FROM authors WHERE id = $2;
+
+-- name: MissingColumn :execscript
+SET LOCAL lock_timeout = '1s';
+UPDATE authors SET title = $1 WHERE id = $2;
+
+-- name: SortedScript :execscript
+-- sort: name
+DELETE FROM authors WHERE id = $1;
+
+-- stderr
+-- # package querytest
+-- query.sql:1:1: script "MixedParams" mixes positional parameters ($1) and named parameters (sqlc.arg or @arg)
+-- query.sql:6:1: could not determine data type of parameter $1
+-- query.sql:11:20: column "title" does not exist
+-- query.sql:15:1: script "SortedScript" can't have a sort annotation
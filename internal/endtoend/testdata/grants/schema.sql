@@ -0,0 +1,20 @@
+CREATE ROLE reader NOLOGIN;
+CREATE USER app WITH PASSWORD 'x';
+CREATE TABLE books (id serial primary key, title text not null, secret text);
+GRANT SELECT ON books TO reader;
+GRANT SELECT (id, title) ON TABLE books TO app;
+GRANT ALL ON ALL TABLES IN SCHEMA public TO app;
+REVOKE ALL ON books FROM PUBLIC;
+GRANT reader TO app;
+REVOKE reader FROM app;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT ON TABLES TO reader;
+ALTER ROLE app SET search_path = public;
+ALTER ROLE app WITH LOGIN;
+GRANT USAGE ON SCHEMA public TO reader;
+GRANT EXECUTE ON FUNCTION now() TO reader;
+DROP ROLE IF EXISTS old;
+DROP OWNED BY old;
+REASSIGN OWNED BY old TO app;
+SET ROLE app;
+RESET ROLE;
+ALTER TABLE books OWNER TO app;
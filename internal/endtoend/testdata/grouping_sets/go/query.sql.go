@@ -0,0 +1,153 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const salesByCube = `-- name: SalesByCube :many
+SELECT s.region, s.product
+FROM sales s
+GROUP BY CUBE (s.region, s.product)
+`
+
+type SalesByCubeRow struct {
+	Region  sql.NullString
+	Product sql.NullString
+}
+
+func (q *Queries) SalesByCube(ctx context.Context) ([]SalesByCubeRow, error) {
+	rows, err := q.db.QueryContext(ctx, salesByCube)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SalesByCubeRow
+	for rows.Next() {
+		var i SalesByCubeRow
+		if err := rows.Scan(&i.Region, &i.Product); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const salesByGroupingSets = `-- name: SalesByGroupingSets :many
+SELECT region, product, count(*)
+FROM sales
+GROUP BY GROUPING SETS ((region, product), (region))
+`
+
+type SalesByGroupingSetsRow struct {
+	Region  string
+	Product sql.NullString
+	Count   int64
+}
+
+func (q *Queries) SalesByGroupingSets(ctx context.Context) ([]SalesByGroupingSetsRow, error) {
+	rows, err := q.db.QueryContext(ctx, salesByGroupingSets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SalesByGroupingSetsRow
+	for rows.Next() {
+		var i SalesByGroupingSetsRow
+		if err := rows.Scan(&i.Region, &i.Product, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const salesByRegion = `-- name: SalesByRegion :many
+SELECT region, count(*)
+FROM sales
+GROUP BY region
+`
+
+type SalesByRegionRow struct {
+	Region string
+	Count  int64
+}
+
+func (q *Queries) SalesByRegion(ctx context.Context) ([]SalesByRegionRow, error) {
+	rows, err := q.db.QueryContext(ctx, salesByRegion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SalesByRegionRow
+	for rows.Next() {
+		var i SalesByRegionRow
+		if err := rows.Scan(&i.Region, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const salesByRollup = `-- name: SalesByRollup :many
+SELECT region, product, count(*), GROUPING(region, product)
+FROM sales
+GROUP BY ROLLUP (region, product)
+`
+
+type SalesByRollupRow struct {
+	Region   sql.NullString
+	Product  sql.NullString
+	Count    int64
+	Grouping int32
+}
+
+func (q *Queries) SalesByRollup(ctx context.Context) ([]SalesByRollupRow, error) {
+	rows, err := q.db.QueryContext(ctx, salesByRollup)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SalesByRollupRow
+	for rows.Next() {
+		var i SalesByRollupRow
+		if err := rows.Scan(
+			&i.Region,
+			&i.Product,
+			&i.Count,
+			&i.Grouping,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
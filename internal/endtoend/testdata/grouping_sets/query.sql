@@ -0,0 +1,19 @@
+-- name: SalesByRollup :many
+SELECT region, product, count(*), GROUPING(region, product)
+FROM sales
+GROUP BY ROLLUP (region, product);
+
+-- name: SalesByCube :many
+SELECT s.region, s.product
+FROM sales s
+GROUP BY CUBE (s.region, s.product);
+
+-- name: SalesByGroupingSets :many
+SELECT region, product, count(*)
+FROM sales
+GROUP BY GROUPING SETS ((region, product), (region));
+
+-- name: SalesByRegion :many
+SELECT region, count(*)
+FROM sales
+GROUP BY region;
@@ -0,0 +1 @@
+CREATE TABLE sales (region text NOT NULL, product text NOT NULL, amount integer NOT NULL);
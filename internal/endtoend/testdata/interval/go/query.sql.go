@@ -0,0 +1,89 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const getJob = `-- name: GetJob :one
+SELECT id, timeout, retry_backoff, backoffs, starts_at, created_at, finished_at FROM jobs WHERE id = $1
+`
+
+func (q *Queries) GetJob(ctx context.Context, id int32) (Job, error) {
+	row := q.db.QueryRowContext(ctx, getJob, id)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		intervalScan{&i.Timeout},
+		&i.RetryBackoff,
+		pq.Array(&i.Backoffs),
+		&i.StartsAt,
+		&i.CreatedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const getJobTimeout = `-- name: GetJobTimeout :one
+SELECT timeout FROM jobs WHERE id = $1
+`
+
+func (q *Queries) GetJobTimeout(ctx context.Context, id int32) (time.Duration, error) {
+	row := q.db.QueryRowContext(ctx, getJobTimeout, id)
+	var timeout time.Duration
+	err := row.Scan(intervalScan{&timeout})
+	return timeout, err
+}
+
+const listJobsWithTimeout = `-- name: ListJobsWithTimeout :many
+SELECT id, timeout, retry_backoff FROM jobs WHERE timeout > $1
+`
+
+type ListJobsWithTimeoutRow struct {
+	ID           int32
+	Timeout      time.Duration
+	RetryBackoff NullDuration
+}
+
+func (q *Queries) ListJobsWithTimeout(ctx context.Context, timeout time.Duration) ([]ListJobsWithTimeoutRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJobsWithTimeout, NullDuration{Duration: timeout, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListJobsWithTimeoutRow
+	for rows.Next() {
+		var i ListJobsWithTimeoutRow
+		if err := rows.Scan(&i.ID, intervalScan{&i.Timeout}, &i.RetryBackoff); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateJobTimeouts = `-- name: UpdateJobTimeouts :exec
+UPDATE jobs SET timeout = $2, retry_backoff = $3 WHERE id = $1
+`
+
+type UpdateJobTimeoutsParams struct {
+	ID           int32
+	Timeout      time.Duration
+	RetryBackoff NullDuration
+}
+
+func (q *Queries) UpdateJobTimeouts(ctx context.Context, arg UpdateJobTimeoutsParams) error {
+	_, err := q.db.ExecContext(ctx, updateJobTimeouts, arg.ID, NullDuration{Duration: arg.Timeout, Valid: true}, arg.RetryBackoff)
+	return err
+}
@@ -0,0 +1,138 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+// Package querytesttest inserts rows of the tables in package querytest for tests.
+package querytesttest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyleconroy/sqlc/internal/endtoend/testdata/interval/go"
+	"github.com/lib/pq"
+)
+
+// JobFixture inserts a querytest.Job. The columns that were set
+// are inserted, along with the NOT NULL columns without a default. The others
+// get their default value.
+type JobFixture struct {
+	row querytest.Job
+	set map[string]bool
+}
+
+func NewJobFixture() *JobFixture {
+	return &JobFixture{set: map[string]bool{
+		"timeout":   true,
+		"backoffs":  true,
+		"starts_at": true,
+	}}
+}
+
+func (f *JobFixture) WithID(v int32) *JobFixture {
+	f.row.ID = v
+	f.set["id"] = true
+	return f
+}
+
+func (f *JobFixture) WithTimeout(v time.Duration) *JobFixture {
+	f.row.Timeout = v
+	f.set["timeout"] = true
+	return f
+}
+
+func (f *JobFixture) WithRetryBackoff(v querytest.NullDuration) *JobFixture {
+	f.row.RetryBackoff = v
+	f.set["retry_backoff"] = true
+	return f
+}
+
+func (f *JobFixture) WithBackoffs(v []querytest.NullDuration) *JobFixture {
+	f.row.Backoffs = v
+	f.set["backoffs"] = true
+	return f
+}
+
+func (f *JobFixture) WithStartsAt(v time.Time) *JobFixture {
+	f.row.StartsAt = v
+	f.set["starts_at"] = true
+	return f
+}
+
+func (f *JobFixture) WithCreatedAt(v time.Time) *JobFixture {
+	f.row.CreatedAt = v
+	f.set["created_at"] = true
+	return f
+}
+
+func (f *JobFixture) WithFinishedAt(v sql.NullTime) *JobFixture {
+	f.row.FinishedAt = v
+	f.set["finished_at"] = true
+	return f
+}
+
+// Insert the row, returning it as stored by the database
+func (f *JobFixture) Insert(ctx context.Context, conn querytest.DBTX) (querytest.Job, error) {
+	var cols []string
+	var args []interface{}
+	if f.set["id"] {
+		cols = append(cols, `"id"`)
+		args = append(args, f.row.ID)
+	}
+	if f.set["timeout"] {
+		cols = append(cols, `"timeout"`)
+		args = append(args, querytest.NullDuration{Duration: f.row.Timeout, Valid: true})
+	}
+	if f.set["retry_backoff"] {
+		cols = append(cols, `"retry_backoff"`)
+		args = append(args, f.row.RetryBackoff)
+	}
+	if f.set["backoffs"] {
+		cols = append(cols, `"backoffs"`)
+		args = append(args, pq.Array(f.row.Backoffs))
+	}
+	if f.set["starts_at"] {
+		cols = append(cols, `"starts_at"`)
+		args = append(args, f.row.StartsAt)
+	}
+	if f.set["created_at"] {
+		cols = append(cols, `"created_at"`)
+		args = append(args, f.row.CreatedAt)
+	}
+	if f.set["finished_at"] {
+		cols = append(cols, `"finished_at"`)
+		args = append(args, f.row.FinishedAt)
+	}
+	query := `INSERT INTO "public"."jobs"`
+	if len(cols) == 0 {
+		query += " DEFAULT VALUES"
+	} else {
+		params := make([]string, len(cols))
+		for i := range params {
+			params[i] = fmt.Sprintf("$%d", i+1)
+		}
+		query += " (" + strings.Join(cols, ", ") + ") VALUES (" + strings.Join(params, ", ") + ")"
+	}
+	row := conn.QueryRowContext(ctx, query+` RETURNING "id", "timeout", "retry_backoff", "backoffs", "starts_at", "created_at", "finished_at"`, args...)
+	var i querytest.Job
+	err := row.Scan(&i.ID, intervalScan{&i.Timeout}, &i.RetryBackoff, pq.Array(&i.Backoffs), &i.StartsAt, &i.CreatedAt, &i.FinishedAt)
+	return i, err
+}
+
+// intervalScan scans a NOT NULL interval column into dest
+type intervalScan struct {
+	dest *time.Duration
+}
+
+func (s intervalScan) Scan(src interface{}) error {
+	var n querytest.NullDuration
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	if !n.Valid {
+		return fmt.Errorf("cannot scan NULL into %T", s.dest)
+	}
+	*s.dest = n.Duration
+	return nil
+}
@@ -0,0 +1,11 @@
+-- name: GetJob :one
+SELECT * FROM jobs WHERE id = $1;
+
+-- name: GetJobTimeout :one
+SELECT timeout FROM jobs WHERE id = $1;
+
+-- name: ListJobsWithTimeout :many
+SELECT id, timeout, retry_backoff FROM jobs WHERE timeout > $1;
+
+-- name: UpdateJobTimeouts :exec
+UPDATE jobs SET timeout = $2, retry_backoff = $3 WHERE id = $1;
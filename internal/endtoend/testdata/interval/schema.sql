@@ -0,0 +1,9 @@
+CREATE TABLE jobs (
+    id            SERIAL PRIMARY KEY,
+    timeout       interval NOT NULL,
+    retry_backoff interval,
+    backoffs      interval[] NOT NULL,
+    starts_at     time(3) NOT NULL,
+    created_at    timestamptz(6) NOT NULL DEFAULT now(),
+    finished_at   timestamp(0) with time zone
+);
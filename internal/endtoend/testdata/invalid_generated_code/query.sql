@@ -0,0 +1,10 @@
+CREATE TABLE authors (id BIGSERIAL PRIMARY KEY, name TEXT NOT NULL, bio TEXT);
+CREATE TABLE list_authors_rows (id BIGSERIAL PRIMARY KEY);
+
+-- name: ListAuthors :many
+SELECT id, name FROM authors;
+
+-- stderr
+-- # package querytest
+-- error generating code: query.sql.go:14:6: ListAuthorsRow redeclared in this block
+-- error generating code: query.sql.go:28:33: i.Name undefined (type ListAuthorsRow has no field or method Name)
@@ -0,0 +1,17 @@
+CREATE TABLE bar (id serial not null);
+
+-- name: ListBar :many
+-- method: 1listBar
+SELECT id FROM bar;
+
+-- name: GetBar :one
+SELECT id FROM bar WHERE id = $1;
+
+-- name: FindBar :one
+-- method: GetBar
+SELECT id FROM bar WHERE id = $1;
+
+-- stderr
+-- # package querytest
+-- query.sql:5:1: invalid method name: "1listBar"
+-- query.sql:12:1: duplicate method name: GetBar, first defined at query.sql:8:1
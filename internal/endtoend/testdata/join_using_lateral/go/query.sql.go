@@ -0,0 +1,231 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const fullJoinUsing = `-- name: FullJoinUsing :many
+SELECT author_id FROM books FULL JOIN reviews USING (author_id)
+`
+
+func (q *Queries) FullJoinUsing(ctx context.Context) ([]sql.NullInt32, error) {
+	rows, err := q.db.QueryContext(ctx, fullJoinUsing)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []sql.NullInt32
+	for rows.Next() {
+		var author_id sql.NullInt32
+		if err := rows.Scan(&author_id); err != nil {
+			return nil, err
+		}
+		items = append(items, author_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const joinUsing = `-- name: JoinUsing :many
+SELECT author_id, id, title, book_id, stars FROM books JOIN reviews USING (author_id)
+`
+
+type JoinUsingRow struct {
+	AuthorID int32
+	ID       int32
+	Title    string
+	BookID   int32
+	Stars    int32
+}
+
+func (q *Queries) JoinUsing(ctx context.Context) ([]JoinUsingRow, error) {
+	rows, err := q.db.QueryContext(ctx, joinUsing)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JoinUsingRow
+	for rows.Next() {
+		var i JoinUsingRow
+		if err := rows.Scan(
+			&i.AuthorID,
+			&i.ID,
+			&i.Title,
+			&i.BookID,
+			&i.Stars,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const joinUsingFilter = `-- name: JoinUsingFilter :many
+SELECT author_id, books.author_id AS book_author_id, title, stars
+FROM books
+JOIN reviews USING (author_id)
+WHERE author_id = $1
+`
+
+type JoinUsingFilterRow struct {
+	AuthorID     int32
+	BookAuthorID int32
+	Title        string
+	Stars        int32
+}
+
+func (q *Queries) JoinUsingFilter(ctx context.Context, authorID int32) ([]JoinUsingFilterRow, error) {
+	rows, err := q.db.QueryContext(ctx, joinUsingFilter, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JoinUsingFilterRow
+	for rows.Next() {
+		var i JoinUsingFilterRow
+		if err := rows.Scan(
+			&i.AuthorID,
+			&i.BookAuthorID,
+			&i.Title,
+			&i.Stars,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lateral = `-- name: Lateral :many
+SELECT a.name, b.title
+FROM authors a,
+LATERAL (SELECT title FROM books WHERE books.author_id = a.id LIMIT 1) b
+`
+
+type LateralRow struct {
+	Name  string
+	Title string
+}
+
+func (q *Queries) Lateral(ctx context.Context) ([]LateralRow, error) {
+	rows, err := q.db.QueryContext(ctx, lateral)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LateralRow
+	for rows.Next() {
+		var i LateralRow
+		if err := rows.Scan(&i.Name, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lateralJoin = `-- name: LateralJoin :many
+SELECT a.id, latest.book_id, latest.book_title
+FROM authors a
+JOIN LATERAL (
+  SELECT id, title FROM books WHERE author_id = a.id ORDER BY id DESC LIMIT $1
+) AS latest(book_id, book_title) ON true
+`
+
+type LateralJoinRow struct {
+	ID        int32
+	BookID    int32
+	BookTitle string
+}
+
+func (q *Queries) LateralJoin(ctx context.Context, limit int32) ([]LateralJoinRow, error) {
+	rows, err := q.db.QueryContext(ctx, lateralJoin, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LateralJoinRow
+	for rows.Next() {
+		var i LateralJoinRow
+		if err := rows.Scan(&i.ID, &i.BookID, &i.BookTitle); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const naturalJoin = `-- name: NaturalJoin :many
+SELECT author_id, id, title, book_id, stars FROM books NATURAL JOIN reviews
+`
+
+type NaturalJoinRow struct {
+	AuthorID int32
+	ID       int32
+	Title    string
+	BookID   int32
+	Stars    int32
+}
+
+func (q *Queries) NaturalJoin(ctx context.Context) ([]NaturalJoinRow, error) {
+	rows, err := q.db.QueryContext(ctx, naturalJoin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NaturalJoinRow
+	for rows.Next() {
+		var i NaturalJoinRow
+		if err := rows.Scan(
+			&i.AuthorID,
+			&i.ID,
+			&i.Title,
+			&i.BookID,
+			&i.Stars,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
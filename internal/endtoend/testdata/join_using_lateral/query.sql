@@ -0,0 +1,26 @@
+-- name: JoinUsing :many
+SELECT * FROM books JOIN reviews USING (author_id);
+
+-- name: JoinUsingFilter :many
+SELECT author_id, books.author_id AS book_author_id, title, stars
+FROM books
+JOIN reviews USING (author_id)
+WHERE author_id = $1;
+
+-- name: NaturalJoin :many
+SELECT * FROM books NATURAL JOIN reviews;
+
+-- name: Lateral :many
+SELECT a.name, b.title
+FROM authors a,
+LATERAL (SELECT title FROM books WHERE books.author_id = a.id LIMIT 1) b;
+
+-- name: LateralJoin :many
+SELECT a.id, latest.*
+FROM authors a
+JOIN LATERAL (
+  SELECT id, title FROM books WHERE author_id = a.id ORDER BY id DESC LIMIT $1
+) AS latest(book_id, book_title) ON true;
+
+-- name: FullJoinUsing :many
+SELECT author_id FROM books FULL JOIN reviews USING (author_id);
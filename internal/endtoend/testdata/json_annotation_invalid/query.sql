@@ -0,0 +1,14 @@
+CREATE TABLE authors (id bigserial PRIMARY KEY, name text NOT NULL, meta jsonb);
+
+-- name: GetAuthorName :one
+-- json: name string
+SELECT name FROM authors WHERE id = $1;
+
+-- name: GetAuthorMeta :one
+-- json: bio string
+SELECT meta FROM authors WHERE id = $1;
+
+-- stderr
+-- # package querytest
+-- query.sql:5:1: json annotation names column name of type text, expected json or jsonb
+-- query.sql:9:1: json annotation names an unknown column: bio
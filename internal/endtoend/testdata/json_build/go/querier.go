@@ -0,0 +1,15 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+)
+
+type Querier interface {
+	GetAuthorSummary(ctx context.Context, id int64) (AuthorSummary, error)
+	ListAuthorsWithBooks(ctx context.Context) ([]ListAuthorsWithBooksRow, error)
+	ListBookTitles(ctx context.Context) ([]ListBookTitlesRow, error)
+}
+
+var _ Querier = (*Queries)(nil)
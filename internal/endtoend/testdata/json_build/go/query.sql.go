@@ -0,0 +1,103 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const getAuthorSummary = `-- name: GetAuthorSummary :one
+SELECT json_build_object('id', a.id, 'name', a.name, 'count', (SELECT count(*) FROM books WHERE author_id = a.id)) AS summary
+FROM authors a WHERE a.id = $1
+`
+
+type AuthorSummary struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) GetAuthorSummary(ctx context.Context, id int64) (AuthorSummary, error) {
+	row := q.db.QueryRowContext(ctx, getAuthorSummary, id)
+	var summary AuthorSummary
+	err := row.Scan(jsonScan{&summary})
+	return summary, err
+}
+
+const listAuthorsWithBooks = `-- name: ListAuthorsWithBooks :many
+SELECT a.id, a.name, jsonb_agg(b) AS books
+FROM authors a JOIN books b ON b.author_id = a.id
+GROUP BY a.id
+`
+
+type AuthorBook struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	Published time.Time `json:"published"`
+	Tags      []string  `json:"tags"`
+}
+
+type ListAuthorsWithBooksRow struct {
+	ID    int64
+	Name  string
+	Books []AuthorBook
+}
+
+func (q *Queries) ListAuthorsWithBooks(ctx context.Context) ([]ListAuthorsWithBooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsWithBooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsWithBooksRow
+	for rows.Next() {
+		var i ListAuthorsWithBooksRow
+		if err := rows.Scan(&i.ID, &i.Name, jsonScan{&i.Books}); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBookTitles = `-- name: ListBookTitles :many
+SELECT json_agg(title) AS titles, jsonb_build_array(1, 2, 3) AS a, row_to_json(b) AS r FROM books b GROUP BY b.id
+`
+
+type ListBookTitlesRow struct {
+	Titles json.RawMessage
+	A      json.RawMessage
+	R      json.RawMessage
+}
+
+func (q *Queries) ListBookTitles(ctx context.Context) ([]ListBookTitlesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBookTitles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBookTitlesRow
+	for rows.Next() {
+		var i ListBookTitlesRow
+		if err := rows.Scan(&i.Titles, &i.A, &i.R); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
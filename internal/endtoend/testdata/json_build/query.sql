@@ -0,0 +1,16 @@
+CREATE TABLE authors (id bigserial primary key, name text not null, meta jsonb);
+CREATE TABLE books (id bigserial primary key, author_id bigint not null references authors(id), title text not null, published timestamptz, tags text[]);
+
+-- name: ListAuthorsWithBooks :many
+-- json: books []AuthorBook(id bigint, title text, published timestamptz, tags text[])
+SELECT a.id, a.name, jsonb_agg(b) AS books
+FROM authors a JOIN books b ON b.author_id = a.id
+GROUP BY a.id;
+
+-- name: GetAuthorSummary :one
+-- json: summary AuthorSummary(id bigint, name text, count bigint)
+SELECT json_build_object('id', a.id, 'name', a.name, 'count', (SELECT count(*) FROM books WHERE author_id = a.id)) AS summary
+FROM authors a WHERE a.id = $1;
+
+-- name: ListBookTitles :many
+SELECT json_agg(title) AS titles, jsonb_build_array(1, 2, 3) AS a, row_to_json(b) AS r FROM books b GROUP BY b.id;
@@ -11,8 +11,8 @@ const limit = `-- name: Limit :many
 SELECT bar FROM foo LIMIT $1
 `
 
-func (q *Queries) Limit(ctx context.Context, limit int32) ([]bool, error) {
-	rows, err := q.db.QueryContext(ctx, limit, limit)
+func (q *Queries) Limit(ctx context.Context, limitArg int32) ([]bool, error) {
+	rows, err := q.db.QueryContext(ctx, limit, limitArg)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,14 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+)
+
+type Querier interface {
+	GetSession(ctx context.Context, id int32) (Session, error)
+	RemoveSession(ctx context.Context, id int32) error
+}
+
+var _ Querier = (*Queries)(nil)
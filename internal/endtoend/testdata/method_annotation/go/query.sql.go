@@ -0,0 +1,62 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"time"
+)
+
+const getSession = `-- name: GetSession :one
+SELECT id, user_id, expires_at FROM sessions WHERE id = $1
+`
+
+func (q *Queries) GetSession(ctx context.Context, id int32) (Session, error) {
+	row := q.queryRow(ctx, q.getSessionStmt, getSession, id)
+	var i Session
+	err := row.Scan(&i.ID, &i.UserID, &i.ExpiresAt)
+	return i, err
+}
+
+const deleteSession = `-- name: DeleteSession :exec
+DELETE FROM sessions WHERE id = $1
+`
+
+func (q *Queries) RemoveSession(ctx context.Context, id int32) error {
+	_, err := q.exec(ctx, q.deleteSessionStmt, deleteSession, id)
+	return err
+}
+
+const listStaleSessions = `-- name: ListStaleSessions :many
+SELECT id, user_id, expires_at FROM sessions WHERE expires_at < $1 AND user_id = $2
+`
+
+type listStaleSessionsParams struct {
+	ExpiresAt time.Time
+	UserID    int32
+}
+
+// Only used by the cleanup job
+func (q *Queries) listStaleSessions(ctx context.Context, arg listStaleSessionsParams) ([]Session, error) {
+	rows, err := q.query(ctx, q.listStaleSessionsStmt, listStaleSessions, arg.ExpiresAt, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(&i.ID, &i.UserID, &i.ExpiresAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
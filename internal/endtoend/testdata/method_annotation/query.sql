@@ -0,0 +1,11 @@
+-- name: GetSession :one
+SELECT * FROM sessions WHERE id = $1;
+
+-- name: ListStaleSessions :many
+-- method: listStaleSessions
+-- Only used by the cleanup job
+SELECT * FROM sessions WHERE expires_at < $1 AND user_id = $2;
+
+-- name: DeleteSession :exec
+-- method: RemoveSession
+DELETE FROM sessions WHERE id = $1;
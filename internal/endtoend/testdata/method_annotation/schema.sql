@@ -0,0 +1,5 @@
+CREATE TABLE sessions (
+    id          SERIAL PRIMARY KEY,
+    user_id     INT NOT NULL,
+    expires_at  TIMESTAMP NOT NULL
+);
@@ -0,0 +1,58 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createUser = `-- name: CreateUser :one
+insert into users(name, bio, settings) values (?, ?, ?) returning id, name, bio, settings
+`
+
+type CreateUserParams struct {
+	Name     string
+	Bio      sql.NullString
+	Settings string
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, createUser, arg.Name, arg.Bio, arg.Settings)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Bio,
+		&i.Settings,
+	)
+	return i, err
+}
+
+const createUserID = `-- name: CreateUserID :one
+insert into users(name) values (?) returning id
+`
+
+func (q *Queries) CreateUserID(ctx context.Context, name string) (int, error) {
+	row := q.db.QueryRowContext(ctx, createUserID, name)
+	var id int
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteUser = `-- name: DeleteUser :one
+delete from users where id = ? returning id, name as deleted_name
+`
+
+type DeleteUserRow struct {
+	ID          int
+	DeletedName string
+}
+
+func (q *Queries) DeleteUser(ctx context.Context, id int) (DeleteUserRow, error) {
+	row := q.db.QueryRowContext(ctx, deleteUser, id)
+	var i DeleteUserRow
+	err := row.Scan(&i.ID, &i.DeletedName)
+	return i, err
+}
@@ -0,0 +1,8 @@
+/* name: CreateUser :one */
+INSERT INTO users (name, bio, settings) VALUES (?, ?, ?) RETURNING *;
+
+/* name: DeleteUser :one */
+DELETE FROM users WHERE id = ? RETURNING id, name AS deleted_name;
+
+/* name: CreateUserID :one */
+INSERT INTO users (name) VALUES (?) RETURNING id;
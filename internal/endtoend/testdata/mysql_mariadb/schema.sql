@@ -0,0 +1,6 @@
+CREATE TABLE users (
+    id integer NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    bio text,
+    settings json NOT NULL
+) ENGINE=InnoDB;
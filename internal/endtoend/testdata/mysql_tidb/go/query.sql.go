@@ -0,0 +1,73 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getUser = `-- name: GetUser :one
+select id, name, region from users where id = ?
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Region)
+	return i, err
+}
+
+const listUserEventClusters = `-- name: ListUserEventClusters :many
+select clustered from events where user_id = ?
+`
+
+func (q *Queries) ListUserEventClusters(ctx context.Context, user_id int) ([]bool, error) {
+	rows, err := q.db.QueryContext(ctx, listUserEventClusters, user_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []bool
+	for rows.Next() {
+		var clustered bool
+		if err := rows.Scan(&clustered); err != nil {
+			return nil, err
+		}
+		items = append(items, clustered)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserEvents = `-- name: ListUserEvents :many
+select id from events where user_id = ?
+`
+
+func (q *Queries) ListUserEvents(ctx context.Context, user_id int) ([]int, error) {
+	rows, err := q.db.QueryContext(ctx, listUserEvents, user_id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
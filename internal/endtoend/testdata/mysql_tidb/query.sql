@@ -0,0 +1,8 @@
+/* name: GetUser :one */
+SELECT id, name, region FROM users WHERE id = ?;
+
+/* name: ListUserEvents :many */
+SELECT id FROM events WHERE user_id = ?;
+
+/* name: ListUserEventClusters :many */
+SELECT clustered FROM events WHERE user_id = ?;
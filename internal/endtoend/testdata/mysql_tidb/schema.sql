@@ -0,0 +1,12 @@
+CREATE TABLE users (
+    id bigint NOT NULL AUTO_RANDOM(5),
+    name varchar(255) NOT NULL,
+    region varchar(32),
+    PRIMARY KEY (id) CLUSTERED
+) SHARD_ROW_ID_BITS = 4 PRE_SPLIT_REGIONS = 2;
+
+CREATE TABLE events (
+    id bigint NOT NULL AUTO_RANDOM PRIMARY KEY NONCLUSTERED,
+    user_id bigint NOT NULL,
+    clustered boolean NOT NULL
+);
@@ -0,0 +1,18 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Querier interface {
+	Notify(ctx context.Context, arg NotifyParams) error
+	NotifyJobDone(ctx context.Context, payload string) error
+	NotifyJobEvent(ctx context.Context, payload json.RawMessage) error
+	NotifyJobsChanged(ctx context.Context) error
+	NotifyJobsRefreshed(ctx context.Context) error
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,59 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const notify = `-- name: Notify :exec
+SELECT pg_notify($1, $2)
+`
+
+type NotifyParams struct {
+	Channel string
+	Payload string
+}
+
+func (q *Queries) Notify(ctx context.Context, arg NotifyParams) error {
+	_, err := q.db.ExecContext(ctx, notify, arg.Channel, arg.Payload)
+	return err
+}
+
+const notifyJobDone = `-- name: NotifyJobDone :exec
+SELECT pg_notify('job_done', $1)
+`
+
+func (q *Queries) NotifyJobDone(ctx context.Context, payload string) error {
+	_, err := q.db.ExecContext(ctx, notifyJobDone, payload)
+	return err
+}
+
+const notifyJobEvent = `-- name: NotifyJobEvent :exec
+SELECT pg_notify('job_events', $1)
+`
+
+func (q *Queries) NotifyJobEvent(ctx context.Context, payload json.RawMessage) error {
+	_, err := q.db.ExecContext(ctx, notifyJobEvent, payload)
+	return err
+}
+
+const notifyJobsChanged = `-- name: NotifyJobsChanged :exec
+NOTIFY jobs_changed
+`
+
+func (q *Queries) NotifyJobsChanged(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, notifyJobsChanged)
+	return err
+}
+
+const notifyJobsRefreshed = `-- name: NotifyJobsRefreshed :exec
+NOTIFY jobs_changed, 'refresh'
+`
+
+func (q *Queries) NotifyJobsRefreshed(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, notifyJobsRefreshed)
+	return err
+}
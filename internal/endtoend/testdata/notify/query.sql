@@ -0,0 +1,17 @@
+CREATE TABLE jobs (id SERIAL PRIMARY KEY, state text NOT NULL);
+
+-- name: NotifyJobsChanged :exec
+NOTIFY jobs_changed;
+
+-- name: NotifyJobsRefreshed :exec
+NOTIFY jobs_changed, 'refresh';
+
+-- name: NotifyJobDone :exec
+SELECT pg_notify('job_done', $1);
+
+-- name: NotifyJobEvent :exec
+-- param: payload json.RawMessage
+SELECT pg_notify('job_events', $1);
+
+-- name: Notify :exec
+SELECT pg_notify($1, $2);
@@ -0,0 +1,73 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// QueryHook observes the queries run through HookedQueries, such as to record
+// their latency or to start a tracing span for each one.
+type QueryHook interface {
+	// BeforeQuery is called before the query runs. The returned context is
+	// used to run the query and is passed to AfterQuery.
+	BeforeQuery(ctx context.Context, name string) context.Context
+
+	// AfterQuery is called once the query has finished
+	AfterQuery(ctx context.Context, name string, duration time.Duration, err error)
+}
+
+// HookedQueries runs the queries of Queries, calling the hook around each one
+type HookedQueries struct {
+	q    *Queries
+	hook QueryHook
+}
+
+func NewHooked(q *Queries, hook QueryHook) *HookedQueries {
+	return &HookedQueries{q: q, hook: hook}
+}
+
+func (h *HookedQueries) WithTx(tx *sql.Tx) *HookedQueries {
+	return &HookedQueries{q: h.q.WithTx(tx), hook: h.hook}
+}
+
+type queryCall struct {
+	ctx   context.Context
+	hook  QueryHook
+	name  string
+	start time.Time
+}
+
+func (h *HookedQueries) before(ctx context.Context, name string) (context.Context, queryCall) {
+	ctx = h.hook.BeforeQuery(ctx, name)
+	return ctx, queryCall{ctx: ctx, hook: h.hook, name: name, start: time.Now()}
+}
+
+func (c queryCall) end(err error) {
+	c.hook.AfterQuery(c.ctx, c.name, time.Since(c.start), err)
+}
+
+var _ Querier = (*HookedQueries)(nil)
+
+func (h *HookedQueries) ListAuthorIDs(ctx context.Context, after *ListAuthorIDsCursor, limit int32) ([]int32, *ListAuthorIDsCursor, error) {
+	ctx, call := h.before(ctx, "ListAuthorIDs")
+	items, next, err := h.q.ListAuthorIDs(ctx, after, limit)
+	call.end(err)
+	return items, next, err
+}
+
+func (h *HookedQueries) ListAuthors(ctx context.Context, after *ListAuthorsCursor, limit int32) ([]Author, *ListAuthorsCursor, error) {
+	ctx, call := h.before(ctx, "ListAuthors")
+	items, next, err := h.q.ListAuthors(ctx, after, limit)
+	call.end(err)
+	return items, next, err
+}
+
+func (h *HookedQueries) ListAuthorsByName(ctx context.Context, name string, after *ListAuthorsByNameCursor, limit int32) ([]ListAuthorsByNameRow, *ListAuthorsByNameCursor, error) {
+	ctx, call := h.before(ctx, "ListAuthorsByName")
+	items, next, err := h.q.ListAuthorsByName(ctx, name, after, limit)
+	call.end(err)
+	return items, next, err
+}
@@ -0,0 +1,15 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+)
+
+type Querier interface {
+	ListAuthorIDs(ctx context.Context, after *ListAuthorIDsCursor, limit int32) ([]int32, *ListAuthorIDsCursor, error)
+	ListAuthors(ctx context.Context, after *ListAuthorsCursor, limit int32) ([]Author, *ListAuthorsCursor, error)
+	ListAuthorsByName(ctx context.Context, name string, after *ListAuthorsByNameCursor, limit int32) ([]ListAuthorsByNameRow, *ListAuthorsByNameCursor, error)
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,70 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MockQuerier implements Querier for tests. Each method calls the function in
+// the matching field, which panics if the field is nil, and every call is
+// recorded.
+type MockQuerier struct {
+	ListAuthorIDsFunc     func(ctx context.Context, after *ListAuthorIDsCursor, limit int32) ([]int32, *ListAuthorIDsCursor, error)
+	ListAuthorsFunc       func(ctx context.Context, after *ListAuthorsCursor, limit int32) ([]Author, *ListAuthorsCursor, error)
+	ListAuthorsByNameFunc func(ctx context.Context, name string, after *ListAuthorsByNameCursor, limit int32) ([]ListAuthorsByNameRow, *ListAuthorsByNameCursor, error)
+
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+// A call to a method of MockQuerier, with the arguments following the context
+type MockCall struct {
+	Method string
+	Args   []interface{}
+}
+
+var _ Querier = (*MockQuerier)(nil)
+
+// Calls returns the calls made so far, in order
+func (m *MockQuerier) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall(nil), m.calls...)
+}
+
+func (m *MockQuerier) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+}
+
+func mockNotSet(method string) string {
+	return fmt.Sprintf("MockQuerier.%sFunc is not set", method)
+}
+
+func (m *MockQuerier) ListAuthorIDs(ctx context.Context, after *ListAuthorIDsCursor, limit int32) ([]int32, *ListAuthorIDsCursor, error) {
+	m.record("ListAuthorIDs", after, limit)
+	if m.ListAuthorIDsFunc == nil {
+		panic(mockNotSet("ListAuthorIDs"))
+	}
+	return m.ListAuthorIDsFunc(ctx, after, limit)
+}
+
+func (m *MockQuerier) ListAuthors(ctx context.Context, after *ListAuthorsCursor, limit int32) ([]Author, *ListAuthorsCursor, error) {
+	m.record("ListAuthors", after, limit)
+	if m.ListAuthorsFunc == nil {
+		panic(mockNotSet("ListAuthors"))
+	}
+	return m.ListAuthorsFunc(ctx, after, limit)
+}
+
+func (m *MockQuerier) ListAuthorsByName(ctx context.Context, name string, after *ListAuthorsByNameCursor, limit int32) ([]ListAuthorsByNameRow, *ListAuthorsByNameCursor, error) {
+	m.record("ListAuthorsByName", name, after, limit)
+	if m.ListAuthorsByNameFunc == nil {
+		panic(mockNotSet("ListAuthorsByName"))
+	}
+	return m.ListAuthorsByNameFunc(ctx, name, after, limit)
+}
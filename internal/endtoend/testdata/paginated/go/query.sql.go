@@ -0,0 +1,279 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const listAuthorIDs = `-- name: ListAuthorIDs :paginated
+SELECT * FROM (
+SELECT id FROM authors WHERE bio IS NOT NULL ORDER BY id
+) AS page
+ORDER BY id
+LIMIT $1
+`
+
+const listAuthorIDsNext = `-- name: ListAuthorIDs :paginated
+SELECT * FROM (
+SELECT id FROM authors WHERE bio IS NOT NULL ORDER BY id
+) AS page
+WHERE (id) > ($1)
+ORDER BY id
+LIMIT $2
+`
+
+// ListAuthorIDsCursor holds the ORDER BY columns of the last row of a page of
+// ListAuthorIDs, where the next page starts
+type ListAuthorIDsCursor struct {
+	ID int32 `json:"id"`
+}
+
+// Encode returns the cursor as an opaque string, such as for an API response
+func (c ListAuthorIDsCursor) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeListAuthorIDsCursor reads a cursor returned by ListAuthorIDsCursor.Encode
+func DecodeListAuthorIDsCursor(s string) (*ListAuthorIDsCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var c ListAuthorIDsCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return &c, nil
+}
+
+func (q *Queries) ListAuthorIDs(ctx context.Context, after *ListAuthorIDsCursor, limit int32) ([]int32, *ListAuthorIDsCursor, error) {
+	var rows *sql.Rows
+	var err error
+	if after == nil {
+		rows, err = q.db.QueryContext(ctx, listAuthorIDs, limit)
+	} else {
+		rows, err = q.db.QueryContext(ctx, listAuthorIDsNext, after.ID, limit)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	var next *ListAuthorIDsCursor
+	if limit > 0 && len(items) == int(limit) {
+		last := items[len(items)-1]
+		next = &ListAuthorIDsCursor{
+			ID: last,
+		}
+	}
+	return items, next, nil
+}
+
+const listAuthors = `-- name: ListAuthors :paginated
+SELECT * FROM (
+SELECT id, name, bio, created_at FROM authors ORDER BY created_at, id
+) AS page
+ORDER BY created_at, id
+LIMIT $1
+`
+
+const listAuthorsNext = `-- name: ListAuthors :paginated
+SELECT * FROM (
+SELECT id, name, bio, created_at FROM authors ORDER BY created_at, id
+) AS page
+WHERE (created_at, id) > ($1, $2)
+ORDER BY created_at, id
+LIMIT $3
+`
+
+// ListAuthorsCursor holds the ORDER BY columns of the last row of a page of
+// ListAuthors, where the next page starts
+type ListAuthorsCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int32     `json:"id"`
+}
+
+// Encode returns the cursor as an opaque string, such as for an API response
+func (c ListAuthorsCursor) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeListAuthorsCursor reads a cursor returned by ListAuthorsCursor.Encode
+func DecodeListAuthorsCursor(s string) (*ListAuthorsCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var c ListAuthorsCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return &c, nil
+}
+
+func (q *Queries) ListAuthors(ctx context.Context, after *ListAuthorsCursor, limit int32) ([]Author, *ListAuthorsCursor, error) {
+	var rows *sql.Rows
+	var err error
+	if after == nil {
+		rows, err = q.db.QueryContext(ctx, listAuthors, limit)
+	} else {
+		rows, err = q.db.QueryContext(ctx, listAuthorsNext, after.CreatedAt, after.ID, limit)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Bio,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	var next *ListAuthorsCursor
+	if limit > 0 && len(items) == int(limit) {
+		last := items[len(items)-1]
+		next = &ListAuthorsCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}
+	}
+	return items, next, nil
+}
+
+const listAuthorsByName = `-- name: ListAuthorsByName :paginated
+SELECT * FROM (
+SELECT id, name FROM authors
+WHERE name LIKE $1
+ORDER BY name DESC, id DESC
+) AS page
+ORDER BY name DESC, id DESC
+LIMIT $2
+`
+
+type ListAuthorsByNameRow struct {
+	ID   int32
+	Name string
+}
+
+const listAuthorsByNameNext = `-- name: ListAuthorsByName :paginated
+SELECT * FROM (
+SELECT id, name FROM authors
+WHERE name LIKE $1
+ORDER BY name DESC, id DESC
+) AS page
+WHERE (name, id) < ($2, $3)
+ORDER BY name DESC, id DESC
+LIMIT $4
+`
+
+// ListAuthorsByNameCursor holds the ORDER BY columns of the last row of a page of
+// ListAuthorsByName, where the next page starts
+type ListAuthorsByNameCursor struct {
+	Name string `json:"name"`
+	ID   int32  `json:"id"`
+}
+
+// Encode returns the cursor as an opaque string, such as for an API response
+func (c ListAuthorsByNameCursor) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeListAuthorsByNameCursor reads a cursor returned by ListAuthorsByNameCursor.Encode
+func DecodeListAuthorsByNameCursor(s string) (*ListAuthorsByNameCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var c ListAuthorsByNameCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return &c, nil
+}
+
+func (q *Queries) ListAuthorsByName(ctx context.Context, name string, after *ListAuthorsByNameCursor, limit int32) ([]ListAuthorsByNameRow, *ListAuthorsByNameCursor, error) {
+	var rows *sql.Rows
+	var err error
+	if after == nil {
+		rows, err = q.db.QueryContext(ctx, listAuthorsByName, name, limit)
+	} else {
+		rows, err = q.db.QueryContext(ctx, listAuthorsByNameNext,
+			name,
+			after.Name,
+			after.ID,
+			limit,
+		)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsByNameRow
+	for rows.Next() {
+		var i ListAuthorsByNameRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	var next *ListAuthorsByNameCursor
+	if limit > 0 && len(items) == int(limit) {
+		last := items[len(items)-1]
+		next = &ListAuthorsByNameCursor{
+			Name: last.Name,
+			ID:   last.ID,
+		}
+	}
+	return items, next, nil
+}
@@ -0,0 +1,17 @@
+CREATE TABLE authors (
+    id         SERIAL PRIMARY KEY,
+    name       TEXT NOT NULL,
+    bio        TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- name: ListAuthors :paginated
+SELECT * FROM authors ORDER BY created_at, id;
+
+-- name: ListAuthorsByName :paginated
+SELECT id, name FROM authors
+WHERE name LIKE $1
+ORDER BY name DESC, id DESC;
+
+-- name: ListAuthorIDs :paginated
+SELECT id FROM authors WHERE bio IS NOT NULL ORDER BY id;
@@ -0,0 +1,20 @@
+CREATE TABLE authors (id SERIAL PRIMARY KEY, name text NOT NULL, bio text);
+
+-- name: ListAuthors :paginated
+SELECT * FROM authors;
+
+-- name: ListAuthorsByName :paginated
+SELECT * FROM authors ORDER BY name DESC, id;
+
+-- name: ListAuthorsByBio :paginated
+SELECT * FROM authors ORDER BY bio, id;
+
+-- name: ListFirstAuthors :paginated
+SELECT * FROM authors ORDER BY id LIMIT 10;
+
+-- stderr
+-- # package querytest
+-- query.sql:4:1: paginated query "ListAuthors" must have an ORDER BY clause
+-- query.sql:7:1: paginated query "ListAuthorsByName" must sort every ORDER BY column in the same direction
+-- query.sql:10:1: ORDER BY column "bio" of paginated query "ListAuthorsByBio" must be NOT NULL
+-- query.sql:13:1: paginated query "ListFirstAuthors" must not have a LIMIT or OFFSET
@@ -0,0 +1,37 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"database/sql"
+)
+
+type BookStatus string
+
+const (
+	BookStatusDraft     BookStatus = "draft"
+	BookStatusPublished BookStatus = "published"
+)
+
+func (e *BookStatus) Scan(src interface{}) error {
+	*e = BookStatus(src.([]byte))
+	return nil
+}
+
+// People who write books
+type Author struct {
+	ID   int64
+	Name string
+	Bio  sql.NullString
+}
+
+// Row-level security is enabled. Policies:
+//
+//	CREATE POLICY books_published ON bookstore.books FOR SELECT USING ((status = 'published'::public.book_status))
+type BookstoreBook struct {
+	ID        int64
+	AuthorID  int64
+	Title     string
+	Status    BookStatus
+	UpdatedAt sql.NullTime
+}
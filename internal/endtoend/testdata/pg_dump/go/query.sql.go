@@ -0,0 +1,58 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, bio FROM authors WHERE id = $1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name, &i.Bio)
+	return i, err
+}
+
+const listBooks = `-- name: ListBooks :many
+SELECT b.id, b.title, b.status, a.name FROM bookstore.books b JOIN authors a ON a.id = b.author_id
+`
+
+type ListBooksRow struct {
+	ID     int64
+	Title  string
+	Status BookStatus
+	Name   string
+}
+
+func (q *Queries) ListBooks(ctx context.Context) ([]ListBooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBooksRow
+	for rows.Next() {
+		var i ListBooksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Status,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,4 @@
+-- name: ListBooks :many
+SELECT b.id, b.title, b.status, a.name FROM bookstore.books b JOIN authors a ON a.id = b.author_id;
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
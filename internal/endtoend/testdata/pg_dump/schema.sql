@@ -0,0 +1,150 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 12.2
+-- Dumped by pg_dump version 12.2
+
+SET statement_timeout = 0;
+SET lock_timeout = 0;
+SET idle_in_transaction_session_timeout = 0;
+SET client_encoding = 'UTF8';
+SET standard_conforming_strings = on;
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+SET xmloption = content;
+SET client_min_messages = warning;
+SET row_security = off;
+
+\connect bookstore
+
+--
+-- Name: bookstore; Type: SCHEMA; Schema: -; Owner: postgres
+--
+
+CREATE SCHEMA bookstore;
+
+
+ALTER SCHEMA bookstore OWNER TO postgres;
+
+--
+-- Name: book_status; Type: TYPE; Schema: public; Owner: postgres
+--
+
+CREATE TYPE public.book_status AS ENUM (
+    'draft',
+    'published'
+);
+
+
+ALTER TYPE public.book_status OWNER TO postgres;
+
+--
+-- Name: touch(); Type: FUNCTION; Schema: public; Owner: postgres
+--
+
+CREATE FUNCTION public.touch() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+  NEW.updated_at = now();
+  RETURN NEW;
+END;
+$$;
+
+
+ALTER FUNCTION public.touch() OWNER TO postgres;
+
+SET default_tablespace = '';
+
+SET default_table_access_method = heap;
+
+--
+-- Name: authors; Type: TABLE; Schema: public; Owner: postgres
+--
+
+CREATE TABLE public.authors (
+    id bigint NOT NULL,
+    name text NOT NULL,
+    bio text
+);
+
+
+ALTER TABLE public.authors OWNER TO postgres;
+
+--
+-- Name: TABLE authors; Type: COMMENT; Schema: public; Owner: postgres
+--
+
+COMMENT ON TABLE public.authors IS 'People who write books';
+
+--
+-- Name: authors_id_seq; Type: SEQUENCE; Schema: public; Owner: postgres
+--
+
+CREATE SEQUENCE public.authors_id_seq
+    AS bigint
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER TABLE public.authors_id_seq OWNER TO postgres;
+
+ALTER SEQUENCE public.authors_id_seq OWNED BY public.authors.id;
+
+CREATE TABLE bookstore.books (
+    id bigint NOT NULL,
+    author_id bigint NOT NULL,
+    title text NOT NULL,
+    status public.book_status DEFAULT 'draft'::public.book_status NOT NULL,
+    updated_at timestamp with time zone
+);
+
+
+ALTER TABLE bookstore.books OWNER TO postgres;
+
+ALTER TABLE ONLY public.authors ALTER COLUMN id SET DEFAULT nextval('public.authors_id_seq'::regclass);
+
+ALTER TABLE ONLY public.authors
+    ADD CONSTRAINT authors_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY bookstore.books
+    ADD CONSTRAINT books_pkey PRIMARY KEY (id);
+
+CREATE INDEX books_title_idx ON bookstore.books USING btree (title);
+
+CREATE TRIGGER books_touch BEFORE UPDATE ON bookstore.books FOR EACH ROW EXECUTE FUNCTION public.touch();
+
+ALTER TABLE bookstore.books ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY (
+    SEQUENCE NAME bookstore.books_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1
+);
+
+ALTER TABLE ONLY bookstore.books
+    ADD CONSTRAINT books_author_id_fkey FOREIGN KEY (author_id) REFERENCES public.authors(id);
+
+ALTER TABLE bookstore.books ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY books_published ON bookstore.books FOR SELECT USING ((status = 'published'::public.book_status));
+
+COPY public.authors (id, name, bio) FROM stdin;
+1	Ursula	\N
+\.
+
+SELECT pg_catalog.setval('public.authors_id_seq', 1, true);
+
+REVOKE ALL ON SCHEMA public FROM PUBLIC;
+GRANT ALL ON SCHEMA public TO PUBLIC;
+GRANT SELECT ON TABLE public.authors TO reader;
+
+--
+-- PostgreSQL database dump complete
+--
+
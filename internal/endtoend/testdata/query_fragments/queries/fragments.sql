@@ -0,0 +1,5 @@
+-- fragment: visible_books
+deleted_at IS NULL AND published
+
+-- fragment: by_author
+author_id = $1
@@ -0,0 +1,13 @@
+-- name: ListBooks :many
+SELECT * FROM books
+WHERE
+  -- include: visible_books
+ORDER BY title;
+
+-- name: ListBooksByAuthor :many
+SELECT title FROM books
+WHERE
+  -- include: visible_books
+  AND
+  -- include: by_author
+ORDER BY title;
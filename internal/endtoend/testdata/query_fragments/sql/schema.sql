@@ -0,0 +1,7 @@
+CREATE TABLE books (
+    id         SERIAL PRIMARY KEY,
+    author_id  integer NOT NULL,
+    title      text NOT NULL,
+    published  boolean NOT NULL,
+    deleted_at timestamp
+);
@@ -0,0 +1,19 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type Querier interface {
+	GetAuthor(ctx context.Context, id uuid.UUID) (Author, error)
+	ListAuthors(ctx context.Context) ([]Author, error)
+	TouchAuthors(ctx context.Context, updatedAt time.Time) error
+	UpdateBio(ctx context.Context, arg UpdateBioParams) (int64, error)
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,92 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, bio, updated_at FROM authors WHERE id = $1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id uuid.UUID) (Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Bio,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT id, name, bio, updated_at FROM authors ORDER BY name
+`
+
+// Authors are listed by name
+func (q *Queries) ListAuthors(ctx context.Context) ([]Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1500*time.Millisecond)
+	defer cancel()
+	rows, err := q.db.QueryContext(ctx, listAuthors)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Bio,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const touchAuthors = `-- name: TouchAuthors :exec
+UPDATE authors SET updated_at = now() WHERE updated_at < $1
+`
+
+func (q *Queries) TouchAuthors(ctx context.Context, updatedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	_, err := q.db.ExecContext(ctx, touchAuthors, updatedAt)
+	return err
+}
+
+const updateBio = `-- name: UpdateBio :execrows
+UPDATE authors SET bio = $2 WHERE id = $1
+`
+
+type UpdateBioParams struct {
+	ID  uuid.UUID
+	Bio string
+}
+
+func (q *Queries) UpdateBio(ctx context.Context, arg UpdateBioParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateBio, arg.ID, arg.Bio)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
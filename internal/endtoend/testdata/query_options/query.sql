@@ -0,0 +1,25 @@
+CREATE TABLE authors (
+    id         uuid PRIMARY KEY,
+    name       text NOT NULL,
+    bio        text,
+    updated_at timestamptz NOT NULL
+);
+
+-- name: GetAuthor :one
+-- param: id github.com/gofrs/uuid.UUID
+-- timeout: 5s
+SELECT * FROM authors WHERE id = $1;
+
+-- name: ListAuthors :many
+-- Authors are listed by name
+-- timeout: 1500ms
+SELECT * FROM authors ORDER BY name;
+
+-- name: UpdateBio :execrows
+-- param: id github.com/gofrs/uuid.UUID
+-- param: bio string
+UPDATE authors SET bio = $2 WHERE id = $1;
+
+-- name: TouchAuthors :exec
+-- timeout: 2m
+UPDATE authors SET updated_at = now() WHERE updated_at < $1;
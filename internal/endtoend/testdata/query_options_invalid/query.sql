@@ -0,0 +1,14 @@
+CREATE TABLE authors (id uuid PRIMARY KEY, name text NOT NULL);
+
+-- name: GetAuthor :one
+-- param: id
+SELECT * FROM authors WHERE id = $1;
+
+-- name: ListAuthors :many
+-- timeout: soon
+SELECT * FROM authors;
+
+-- stderr
+-- # package querytest
+-- query.sql:5:1: invalid param annotation, expected a name and a Go type: -- param: id
+-- query.sql:9:1: invalid timeout: "soon"
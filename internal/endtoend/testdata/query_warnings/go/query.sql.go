@@ -0,0 +1,136 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const createAuthor = `-- name: CreateAuthor :exec
+INSERT INTO authors (name) VALUES ($1) RETURNING id
+`
+
+func (q *Queries) CreateAuthor(ctx context.Context, name string) error {
+	_, err := q.db.ExecContext(ctx, createAuthor, name)
+	return err
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, bio FROM authors WHERE id = $1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name, &i.Bio)
+	return i, err
+}
+
+const listAuthorBooks = `-- name: ListAuthorBooks :many
+SELECT authors.id, books.id, books.title
+FROM authors JOIN books ON books.author_id = authors.id
+`
+
+type ListAuthorBooksRow struct {
+	ID    int64
+	ID_2  int64
+	Title string
+}
+
+func (q *Queries) ListAuthorBooks(ctx context.Context) ([]ListAuthorBooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorBooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorBooksRow
+	for rows.Next() {
+		var i ListAuthorBooksRow
+		if err := rows.Scan(&i.ID, &i.ID_2, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT id, name, bio FROM authors
+ORDER BY $1
+`
+
+func (q *Queries) ListAuthors(ctx context.Context) ([]Author, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthors)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(&i.ID, &i.Name, &i.Bio); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooks = `-- name: ListBooks :many
+SELECT books.id, books.title AS id FROM books
+`
+
+type ListBooksRow struct {
+	ID   int64
+	ID_2 string
+}
+
+func (q *Queries) ListBooks(ctx context.Context) ([]ListBooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBooksRow
+	for rows.Next() {
+		var i ListBooksRow
+		if err := rows.Scan(&i.ID, &i.ID_2); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const renameAuthor = `-- name: RenameAuthor :exec
+UPDATE authors SET name = $1 WHERE id = $2
+`
+
+type RenameAuthorParams struct {
+	Name string
+	ID   int64
+}
+
+func (q *Queries) RenameAuthor(ctx context.Context, arg RenameAuthorParams) error {
+	_, err := q.db.ExecContext(ctx, renameAuthor, arg.Name, arg.ID)
+	return err
+}
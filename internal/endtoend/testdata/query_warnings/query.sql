@@ -0,0 +1,33 @@
+CREATE TABLE authors (id bigserial PRIMARY KEY, name text NOT NULL, bio text);
+CREATE TABLE books (id bigserial PRIMARY KEY, author_id bigint NOT NULL, title text NOT NULL);
+
+-- name: ListAuthors :many
+SELECT * FROM authors
+ORDER BY sqlc.arg(sort_column);
+
+-- name: CreateAuthor :exec
+INSERT INTO authors (name) VALUES ($1) RETURNING id;
+
+-- name: ListBooks :many
+SELECT books.id, books.title AS id FROM books;
+
+-- name: ListAuthorBooks :many
+SELECT authors.id, books.id, books.title
+FROM authors JOIN books ON books.author_id = authors.id;
+
+-- name: GetAuthor :one
+-- param: author_id int64
+SELECT * FROM authors WHERE id = $1;
+
+-- name: RenameAuthor :exec
+-- param: bio string
+UPDATE authors SET name = @name WHERE id = @id;
+UPDATE authors SET bio = @bio WHERE id = @id;
+
+-- stderr
+-- # package querytest
+-- query.sql:6:10: warning: parameter "sort_column" in ORDER BY is a constant, not a column name
+-- query.sql:9:50: warning: query "CreateAuthor" discards the columns of its RETURNING clause; use :one or :many to read them
+-- query.sql:12:18: warning: query "ListBooks" aliases a column to "id", the name of another column
+-- query.sql:20:1: warning: query "GetAuthor" annotates parameter "author_id", which it doesn't use
+-- query.sql:24:1: warning: query "RenameAuthor" annotates parameter "bio", which it doesn't use
@@ -0,0 +1,8 @@
+CREATE TABLE authors (id bigserial PRIMARY KEY, name text NOT NULL);
+
+-- name: CreateAuthor :exec
+INSERT INTO authors (name) VALUES ($1) RETURNING id;
+
+-- stderr
+-- # package querytest
+-- query.sql:4:50: query "CreateAuthor" discards the columns of its RETURNING clause; use :one or :many to read them
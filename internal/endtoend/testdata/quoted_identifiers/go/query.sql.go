@@ -0,0 +1,124 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createAccount = `-- name: CreateAccount :one
+INSERT INTO "UserAccounts" ("firstName", last_name, "order") VALUES ($1, $2, $3) RETURNING "ID", "firstName", last_name, "order"
+`
+
+type CreateAccountParams struct {
+	FirstName string
+	LastName  sql.NullString
+	Order     int32
+}
+
+func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (UserAccount, error) {
+	row := q.db.QueryRowContext(ctx, createAccount, arg.FirstName, arg.LastName, arg.Order)
+	var i UserAccount
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Order,
+	)
+	return i, err
+}
+
+const getAccount = `-- name: GetAccount :one
+SELECT "ID", "firstName", last_name, "order" FROM "UserAccounts" WHERE "ID" = $1
+`
+
+func (q *Queries) GetAccount(ctx context.Context, id int32) (UserAccount, error) {
+	row := q.db.QueryRowContext(ctx, getAccount, id)
+	var i UserAccount
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Order,
+	)
+	return i, err
+}
+
+const listAccountsByFirstName = `-- name: ListAccountsByFirstName :many
+SELECT "UserAccounts"."ID", "UserAccounts"."firstName", "UserAccounts".last_name, "UserAccounts"."order" FROM "UserAccounts" WHERE "firstName" = $1
+`
+
+func (q *Queries) ListAccountsByFirstName(ctx context.Context, firstName string) ([]UserAccount, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsByFirstName, firstName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserAccount
+	for rows.Next() {
+		var i UserAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Order,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInvoices = `-- name: ListInvoices :many
+SELECT a."ID", a."firstName", a.last_name, a."order", i."InvoiceID", i."accountID" FROM "UserAccounts" a
+JOIN "Billing"."Invoices" i ON i."accountID" = a."ID"
+WHERE i."accountID" = $1
+`
+
+type ListInvoicesRow struct {
+	ID        int32
+	FirstName string
+	LastName  sql.NullString
+	Order     int32
+	InvoiceID int32
+	AccountID int32
+}
+
+func (q *Queries) ListInvoices(ctx context.Context, accountID int32) ([]ListInvoicesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInvoices, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListInvoicesRow
+	for rows.Next() {
+		var i ListInvoicesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Order,
+			&i.InvoiceID,
+			&i.AccountID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,27 @@
+CREATE SCHEMA "Billing";
+
+CREATE TABLE "UserAccounts" (
+    "ID"         SERIAL PRIMARY KEY,
+    "firstName"  text NOT NULL,
+    last_name    text,
+    "order"      int NOT NULL
+);
+
+CREATE TABLE "Billing"."Invoices" (
+    "InvoiceID" SERIAL PRIMARY KEY,
+    "accountID" int NOT NULL REFERENCES "UserAccounts" ("ID")
+);
+
+-- name: GetAccount :one
+SELECT * FROM "UserAccounts" WHERE "ID" = $1;
+
+-- name: ListAccountsByFirstName :many
+SELECT "UserAccounts".* FROM "UserAccounts" WHERE "firstName" = $1;
+
+-- name: CreateAccount :one
+INSERT INTO "UserAccounts" ("firstName", last_name, "order") VALUES ($1, $2, $3) RETURNING *;
+
+-- name: ListInvoices :many
+SELECT a.*, i.* FROM "UserAccounts" a
+JOIN "Billing"."Invoices" i ON i."accountID" = a."ID"
+WHERE i."accountID" = $1;
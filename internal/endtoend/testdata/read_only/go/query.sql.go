@@ -0,0 +1,56 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const countUsers = `-- name: CountUsers :one
+WITH named AS (SELECT id FROM users WHERE name <> '')
+SELECT count(*) FROM named
+`
+
+func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, visits FROM users ORDER BY name
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(&i.ID, &i.Name, &i.Visits); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordVisit = `-- name: RecordVisit :exec
+UPDATE users SET visits = visits + 1 WHERE id = $1
+`
+
+func (q *Queries) RecordVisit(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, recordVisit, id)
+	return err
+}
@@ -0,0 +1,10 @@
+-- name: ListUsers :many
+SELECT * FROM users ORDER BY name;
+
+-- name: CountUsers :one
+WITH named AS (SELECT id FROM users WHERE name <> '')
+SELECT count(*) FROM named;
+
+-- name: RecordVisit :exec
+-- read_only: false
+UPDATE users SET visits = visits + 1 WHERE id = $1;
@@ -0,0 +1 @@
+CREATE TABLE users (id bigserial PRIMARY KEY, name text NOT NULL, visits int NOT NULL DEFAULT 0);
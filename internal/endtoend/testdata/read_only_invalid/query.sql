@@ -0,0 +1,24 @@
+-- name: CreateUser :exec
+INSERT INTO users (name) VALUES ($1);
+
+-- name: PruneUsers :many
+WITH deleted AS (DELETE FROM users WHERE visits = 0 RETURNING id)
+SELECT id FROM deleted;
+
+-- name: LockUser :one
+SELECT * FROM users WHERE id = $1 FOR KEY SHARE;
+
+-- name: CopyUsers :exec
+SELECT * INTO users_copy FROM users;
+
+-- name: ListUsers :many
+-- read_only: maybe
+SELECT * FROM users;
+
+-- stderr
+-- # package querytest
+-- query.sql:1:1: read-only query "CreateUser" performs INSERT
+-- query.sql:5:1: read-only query "PruneUsers" performs DELETE
+-- query.sql:9:1: read-only query "LockUser" performs SELECT FOR SHARE
+-- query.sql:12:1: read-only query "CopyUsers" performs SELECT INTO
+-- query.sql:16:1: invalid read_only: "maybe"
@@ -0,0 +1,79 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"time"
+)
+
+const countEventsByName = `-- name: CountEventsByName :many
+SELECT name, count(*) FROM events GROUP BY name
+`
+
+type CountEventsByNameRow struct {
+	Name  string
+	Count int64
+}
+
+func (q *Queries) CountEventsByName(ctx context.Context) ([]CountEventsByNameRow, error) {
+	rows, err := q.db.QueryContext(ctx, countEventsByName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountEventsByNameRow
+	for rows.Next() {
+		var i CountEventsByNameRow
+		if err := rows.Scan(&i.Name, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEvents = `-- name: ListEvents :many
+SELECT id, user_id, name, payload, created_at FROM events WHERE user_id = $1 AND created_at > $2
+`
+
+type ListEventsParams struct {
+	UserID    int32
+	CreatedAt time.Time
+}
+
+func (q *Queries) ListEvents(ctx context.Context, arg ListEventsParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listEvents, arg.UserID, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Payload,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,5 @@
+-- name: ListEvents :many
+SELECT * FROM events WHERE user_id = $1 AND created_at > $2;
+
+-- name: CountEventsByName :many
+SELECT name, count(*) FROM events GROUP BY name;
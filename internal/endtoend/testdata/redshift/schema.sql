@@ -0,0 +1,10 @@
+CREATE TABLE events (
+    id BIGINT IDENTITY(1, 1) ENCODE az64,
+    user_id INT NOT NULL ENCODE az64 DISTKEY,
+    name VARCHAR(MAX) NOT NULL ENCODE zstd,
+    payload SUPER,
+    created_at TIMESTAMP NOT NULL DEFAULT GETDATE() ENCODE az64
+)
+BACKUP NO
+DISTSTYLE KEY
+COMPOUND SORTKEY (user_id, created_at);
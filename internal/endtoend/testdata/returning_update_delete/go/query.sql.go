@@ -0,0 +1,137 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const deleteAuthorCascade = `-- name: DeleteAuthorCascade :many
+WITH deleted AS (
+  DELETE FROM books WHERE author_id = $1 RETURNING id, author_id, title
+)
+DELETE FROM authors
+WHERE id IN (SELECT author_id FROM deleted)
+RETURNING id
+`
+
+func (q *Queries) DeleteAuthorCascade(ctx context.Context, authorID int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, deleteAuthorCascade, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteAuthorUsing = `-- name: DeleteAuthorUsing :many
+DELETE FROM authors USING books
+WHERE books.author_id = authors.id AND books.title = $1
+RETURNING authors.name, books.title
+`
+
+type DeleteAuthorUsingRow struct {
+	Name  string
+	Title string
+}
+
+func (q *Queries) DeleteAuthorUsing(ctx context.Context, title string) ([]DeleteAuthorUsingRow, error) {
+	rows, err := q.db.QueryContext(ctx, deleteAuthorUsing, title)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeleteAuthorUsingRow
+	for rows.Next() {
+		var i DeleteAuthorUsingRow
+		if err := rows.Scan(&i.Name, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAuthorAlias = `-- name: UpdateAuthorAlias :one
+UPDATE authors a SET bio = $2 WHERE a.id = $1 RETURNING a.id, a.name, a.bio
+`
+
+type UpdateAuthorAliasParams struct {
+	ID  int32
+	Bio sql.NullString
+}
+
+func (q *Queries) UpdateAuthorAlias(ctx context.Context, arg UpdateAuthorAliasParams) (Author, error) {
+	row := q.db.QueryRowContext(ctx, updateAuthorAlias, arg.ID, arg.Bio)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name, &i.Bio)
+	return i, err
+}
+
+const updateAuthorFrom = `-- name: UpdateAuthorFrom :many
+UPDATE authors SET bio = books.title
+FROM books
+WHERE books.author_id = authors.id AND books.id = $1
+RETURNING authors.id, name, bio, books.id, author_id, title
+`
+
+type UpdateAuthorFromRow struct {
+	ID       int32
+	Name     string
+	Bio      sql.NullString
+	ID_2     int32
+	AuthorID int32
+	Title    string
+}
+
+func (q *Queries) UpdateAuthorFrom(ctx context.Context, id int32) ([]UpdateAuthorFromRow, error) {
+	rows, err := q.db.QueryContext(ctx, updateAuthorFrom, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UpdateAuthorFromRow
+	for rows.Next() {
+		var i UpdateAuthorFromRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Bio,
+			&i.ID_2,
+			&i.AuthorID,
+			&i.Title,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,21 @@
+-- name: UpdateAuthorAlias :one
+UPDATE authors a SET bio = $2 WHERE a.id = $1 RETURNING a.*;
+
+-- name: UpdateAuthorFrom :many
+UPDATE authors SET bio = books.title
+FROM books
+WHERE books.author_id = authors.id AND books.id = $1
+RETURNING *;
+
+-- name: DeleteAuthorUsing :many
+DELETE FROM authors USING books
+WHERE books.author_id = authors.id AND books.title = $1
+RETURNING authors.name, books.title;
+
+-- name: DeleteAuthorCascade :many
+WITH deleted AS (
+  DELETE FROM books WHERE author_id = $1 RETURNING *
+)
+DELETE FROM authors
+WHERE id IN (SELECT author_id FROM deleted)
+RETURNING id;
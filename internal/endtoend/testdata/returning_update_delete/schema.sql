@@ -0,0 +1,2 @@
+CREATE TABLE authors (id SERIAL PRIMARY KEY, name text NOT NULL, bio text);
+CREATE TABLE books (id SERIAL PRIMARY KEY, author_id integer NOT NULL, title text NOT NULL);
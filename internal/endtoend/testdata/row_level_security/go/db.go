@@ -0,0 +1,54 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db: tx,
+	}
+}
+
+// SetAppTenantID sets the app.tenant_id session variable until the end of the
+// transaction, for the queries that read it with current_setting. It must be
+// called on Queries made with WithTx, as the setting wouldn't outlast the
+// statement otherwise.
+func (q *Queries) SetAppTenantID(ctx context.Context, value int64) error {
+	if _, ok := q.db.(*sql.DB); ok {
+		return errors.New("SetAppTenantID must be called in a transaction")
+	}
+	_, err := q.db.ExecContext(ctx, "SELECT set_config('app.tenant_id', $1, true)", value)
+	return err
+}
+
+// SetAppUserName sets the app.user_name session variable until the end of the
+// transaction, for the queries that read it with current_setting. It must be
+// called on Queries made with WithTx, as the setting wouldn't outlast the
+// statement otherwise.
+func (q *Queries) SetAppUserName(ctx context.Context, value string) error {
+	if _, ok := q.db.(*sql.DB); ok {
+		return errors.New("SetAppUserName must be called in a transaction")
+	}
+	_, err := q.db.ExecContext(ctx, "SELECT set_config('app.user_name', $1, true)", value)
+	return err
+}
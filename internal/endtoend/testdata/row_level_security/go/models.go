@@ -0,0 +1,27 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import ()
+
+// Row-level security is enabled, and forced for the table's owner.
+// The table has no policies, so its rows can't be read or changed.
+type AuditLog struct {
+	ID       int64
+	TenantID int64
+	Message  string
+}
+
+// Row-level security is enabled. Policies:
+//
+//	CREATE POLICY tenant_isolation ON documents
+//	    USING (tenant_id = current_setting('app.tenant_id')::bigint)
+//
+//	CREATE POLICY tenant_insert ON documents AS RESTRICTIVE FOR INSERT TO app_user
+//	    WITH CHECK (tenant_id = current_setting('app.tenant_id')::bigint)
+//	ALTER POLICY tenant_insert ON documents TO app_user, app_admin
+type Document struct {
+	ID       int64
+	TenantID int64
+	Title    string
+}
@@ -0,0 +1,57 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const currentUser = `-- name: CurrentUser :one
+SELECT current_setting('app.user_name', true)
+`
+
+func (q *Queries) CurrentUser(ctx context.Context) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, currentUser)
+	var current_setting sql.NullString
+	err := row.Scan(&current_setting)
+	return current_setting, err
+}
+
+const insertAuditLog = `-- name: InsertAuditLog :exec
+INSERT INTO audit_log (tenant_id, message)
+VALUES (current_setting('app.tenant_id')::bigint, $1)
+`
+
+func (q *Queries) InsertAuditLog(ctx context.Context, message string) error {
+	_, err := q.db.ExecContext(ctx, insertAuditLog, message)
+	return err
+}
+
+const listDocuments = `-- name: ListDocuments :many
+SELECT id, tenant_id, title FROM documents WHERE tenant_id = current_setting('app.tenant_id')::bigint
+`
+
+func (q *Queries) ListDocuments(ctx context.Context) ([]Document, error) {
+	rows, err := q.db.QueryContext(ctx, listDocuments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Document
+	for rows.Next() {
+		var i Document
+		if err := rows.Scan(&i.ID, &i.TenantID, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
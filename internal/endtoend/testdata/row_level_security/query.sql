@@ -0,0 +1,9 @@
+-- name: ListDocuments :many
+SELECT * FROM documents WHERE tenant_id = current_setting('app.tenant_id')::bigint;
+
+-- name: CurrentUser :one
+SELECT current_setting('app.user_name', true);
+
+-- name: InsertAuditLog :exec
+INSERT INTO audit_log (tenant_id, message)
+VALUES (current_setting('app.tenant_id')::bigint, @message);
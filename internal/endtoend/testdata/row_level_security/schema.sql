@@ -0,0 +1,19 @@
+CREATE TABLE documents (
+    id        BIGSERIAL PRIMARY KEY,
+    tenant_id bigint NOT NULL,
+    title     text NOT NULL
+);
+ALTER TABLE documents ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON documents
+    USING (tenant_id = current_setting('app.tenant_id')::bigint);
+CREATE POLICY tenant_insert ON documents AS RESTRICTIVE FOR INSERT TO app_user
+    WITH CHECK (tenant_id = current_setting('app.tenant_id')::bigint);
+ALTER POLICY tenant_insert ON documents TO app_user, app_admin;
+
+CREATE TABLE audit_log (
+    id         BIGSERIAL PRIMARY KEY,
+    tenant_id  bigint NOT NULL,
+    message    text NOT NULL
+);
+ALTER TABLE audit_log ENABLE ROW LEVEL SECURITY;
+ALTER TABLE audit_log FORCE ROW LEVEL SECURITY;
@@ -0,0 +1,84 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const createEvent = `-- name: CreateEvent :one
+INSERT INTO events (user_id) VALUES ($1) RETURNING id, user_id
+`
+
+func (q *Queries) CreateEvent(ctx context.Context, userID int32) (Event, error) {
+	row := q.db.QueryRowContext(ctx, createEvent, userID)
+	var i Event
+	err := row.Scan(&i.ID, &i.UserID)
+	return i, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, email FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (AppUser, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i AppUser
+	err := row.Scan(&i.ID, &i.Email)
+	return i, err
+}
+
+const listAuditEntries = `-- name: ListAuditEntries :many
+SELECT id, event_id FROM audit.entries
+`
+
+func (q *Queries) ListAuditEntries(ctx context.Context) ([]AuditEntry, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEntry
+	for rows.Next() {
+		var i AuditEntry
+		if err := rows.Scan(&i.ID, &i.EventID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserEvents = `-- name: ListUserEvents :many
+SELECT e.id, e.user_id FROM events e JOIN users u ON u.id = e.user_id WHERE u.email = $1
+`
+
+func (q *Queries) ListUserEvents(ctx context.Context, email string) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listUserEvents, email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
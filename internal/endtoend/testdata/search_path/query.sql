@@ -0,0 +1,19 @@
+CREATE SCHEMA app;
+CREATE SCHEMA audit;
+
+CREATE TABLE users (id SERIAL PRIMARY KEY, name text NOT NULL);
+CREATE TABLE app.users (id SERIAL PRIMARY KEY, email text NOT NULL);
+CREATE TABLE events (id SERIAL PRIMARY KEY, user_id int NOT NULL REFERENCES app.users (id));
+CREATE TABLE audit.entries (id SERIAL PRIMARY KEY, event_id int NOT NULL REFERENCES events (id));
+
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: ListUserEvents :many
+SELECT e.* FROM events e JOIN users u ON u.id = e.user_id WHERE u.email = $1;
+
+-- name: ListAuditEntries :many
+SELECT * FROM audit.entries;
+
+-- name: CreateEvent :one
+INSERT INTO events (user_id) VALUES ($1) RETURNING *;
@@ -0,0 +1,14 @@
+CREATE SCHEMA app;
+
+CREATE TABLE app.users (id SERIAL PRIMARY KEY, email text NOT NULL);
+CREATE TABLE events (id SERIAL PRIMARY KEY, user_id int NOT NULL);
+
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: ListEvents :many
+SELECT * FROM events;
+
+-- stderr
+-- # package querytest
+-- query.sql:10:15: relation "events" does not exist
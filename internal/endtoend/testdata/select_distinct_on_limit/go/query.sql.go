@@ -0,0 +1,191 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const distinctOn = `-- name: DistinctOn :many
+SELECT DISTINCT ON (author_id) author_id, title FROM books ORDER BY author_id, id DESC
+`
+
+type DistinctOnRow struct {
+	AuthorID int32
+	Title    string
+}
+
+func (q *Queries) DistinctOn(ctx context.Context) ([]DistinctOnRow, error) {
+	rows, err := q.db.QueryContext(ctx, distinctOn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DistinctOnRow
+	for rows.Next() {
+		var i DistinctOnRow
+		if err := rows.Scan(&i.AuthorID, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const limitExpr = `-- name: LimitExpr :many
+SELECT title FROM books LIMIT $1 OFFSET ($2 - 1) * $1
+`
+
+type LimitExprParams struct {
+	Limit  int32
+	Offset int32
+}
+
+func (q *Queries) LimitExpr(ctx context.Context, arg LimitExprParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, limitExpr, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, err
+		}
+		items = append(items, title)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const limitOffset = `-- name: LimitOffset :many
+SELECT title FROM books WHERE author_id = $1 LIMIT $2 OFFSET $3
+`
+
+type LimitOffsetParams struct {
+	AuthorID int32
+	Limit    int32
+	Offset   int32
+}
+
+func (q *Queries) LimitOffset(ctx context.Context, arg LimitOffsetParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, limitOffset, arg.AuthorID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, err
+		}
+		items = append(items, title)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const orderByExpr = `-- name: OrderByExpr :many
+SELECT title FROM books ORDER BY abs(id - $1)
+`
+
+func (q *Queries) OrderByExpr(ctx context.Context, id int32) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, orderByExpr, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, err
+		}
+		items = append(items, title)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const orderByParam = `-- name: OrderByParam :many
+SELECT title FROM books ORDER BY CASE WHEN $1::bool THEN title END
+`
+
+func (q *Queries) OrderByParam(ctx context.Context, dollar_1 bool) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, orderByParam, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, err
+		}
+		items = append(items, title)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const subLimit = `-- name: SubLimit :many
+SELECT title FROM books WHERE id IN (SELECT id FROM books LIMIT $1) LIMIT $2
+`
+
+type SubLimitParams struct {
+	Limit   int32
+	Limit_2 int32
+}
+
+func (q *Queries) SubLimit(ctx context.Context, arg SubLimitParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, subLimit, arg.Limit, arg.Limit_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, err
+		}
+		items = append(items, title)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,12 @@
+-- name: DistinctOn :many
+SELECT DISTINCT ON (author_id) author_id, title FROM books ORDER BY author_id, id DESC;
+-- name: LimitOffset :many
+SELECT title FROM books WHERE author_id = $1 LIMIT $2 OFFSET $3;
+-- name: LimitExpr :many
+SELECT title FROM books LIMIT $1 OFFSET ($2 - 1) * $1;
+-- name: OrderByParam :many
+SELECT title FROM books ORDER BY CASE WHEN $1::bool THEN title END;
+-- name: SubLimit :many
+SELECT title FROM books WHERE id IN (SELECT id FROM books LIMIT $1) LIMIT $2;
+-- name: OrderByExpr :many
+SELECT title FROM books ORDER BY abs(id - $1);
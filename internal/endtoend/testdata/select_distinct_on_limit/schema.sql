@@ -0,0 +1,3 @@
+CREATE TABLE authors (id SERIAL PRIMARY KEY, name text NOT NULL, bio text);
+CREATE TABLE books (id SERIAL PRIMARY KEY, author_id integer NOT NULL, title text NOT NULL);
+CREATE TABLE reviews (book_id integer NOT NULL, author_id integer, stars integer NOT NULL);
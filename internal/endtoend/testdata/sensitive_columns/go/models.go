@@ -0,0 +1,21 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type User struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+	// bcrypt hash, @sensitive
+	PasswordHash string         `json:"password_hash" log:"-"`
+	ApiToken     sql.NullString `json:"api_token" log:"-"`
+}
+
+// String formats the User without its sensitive fields
+func (s User) String() string {
+	return fmt.Sprintf("User{ID:%v Email:%v PasswordHash:[redacted] ApiToken:[redacted]}", s.ID, s.Email)
+}
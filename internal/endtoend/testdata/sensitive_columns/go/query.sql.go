@@ -0,0 +1,117 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (email, password_hash) VALUES ($1, $2)
+RETURNING id
+`
+
+type CreateUserParams struct {
+	Email        string `json:"email"`
+	PasswordHash string `json:"password_hash" log:"-"`
+}
+
+// String formats the CreateUserParams without its sensitive fields
+func (s CreateUserParams) String() string {
+	return fmt.Sprintf("CreateUserParams{Email:%v PasswordHash:[redacted]}", s.Email)
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createUser, arg.Email, arg.PasswordHash)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, email, password_hash, api_token FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.ApiToken,
+	)
+	return i, err
+}
+
+const listEmails = `-- name: ListEmails :many
+SELECT id, email FROM users
+`
+
+type ListEmailsRow struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+}
+
+func (q *Queries) ListEmails(ctx context.Context) ([]ListEmailsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEmails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmailsRow
+	for rows.Next() {
+		var i ListEmailsRow
+		if err := rows.Scan(&i.ID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTokens = `-- name: ListTokens :many
+SELECT id, api_token AS token FROM users
+`
+
+type ListTokensRow struct {
+	ID    int64          `json:"id"`
+	Token sql.NullString `json:"token" log:"-"`
+}
+
+// String formats the ListTokensRow without its sensitive fields
+func (s ListTokensRow) String() string {
+	return fmt.Sprintf("ListTokensRow{ID:%v Token:[redacted]}", s.ID)
+}
+
+func (q *Queries) ListTokens(ctx context.Context) ([]ListTokensRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTokens)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTokensRow
+	for rows.Next() {
+		var i ListTokensRow
+		if err := rows.Scan(&i.ID, &i.Token); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
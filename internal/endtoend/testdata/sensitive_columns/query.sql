@@ -0,0 +1,21 @@
+CREATE TABLE users (
+    id bigserial PRIMARY KEY,
+    email text NOT NULL,
+    password_hash text NOT NULL,
+    api_token text
+);
+
+COMMENT ON COLUMN users.password_hash IS 'bcrypt hash, @sensitive';
+
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: CreateUser :one
+INSERT INTO users (email, password_hash) VALUES ($1, $2)
+RETURNING id;
+
+-- name: ListTokens :many
+SELECT id, api_token AS token FROM users;
+
+-- name: ListEmails :many
+SELECT id, email FROM users;
@@ -0,0 +1,23 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type Querier interface {
+	BulkInsertAuthors(ctx context.Context, arg BulkInsertAuthorsParams) error
+	ListDays(ctx context.Context, arg ListDaysParams) ([]time.Time, error)
+	ListElements(ctx context.Context, dollar_1 json.RawMessage) ([]json.RawMessage, error)
+	ListNames(ctx context.Context, dollar_1 []string) ([]ListNamesRow, error)
+	ListNamesByPosition(ctx context.Context, names []string) ([]ListNamesByPositionRow, error)
+	ListNumbers(ctx context.Context) ([]int32, error)
+	ListPairs(ctx context.Context, labels []string) ([]ListPairsRow, error)
+	ListRecords(ctx context.Context, dollar_1 json.RawMessage) ([]ListRecordsRow, error)
+	ListSettings(ctx context.Context, dollar_1 json.RawMessage) ([]ListSettingsRow, error)
+}
+
+var _ Querier = (*Queries)(nil)
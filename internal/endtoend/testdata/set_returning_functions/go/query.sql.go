@@ -0,0 +1,276 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const bulkInsertAuthors = `-- name: BulkInsertAuthors :exec
+INSERT INTO authors (name, bio)
+SELECT * FROM unnest($1::text[], $2::text[])
+`
+
+type BulkInsertAuthorsParams struct {
+	Names []string
+	Bios  []string
+}
+
+func (q *Queries) BulkInsertAuthors(ctx context.Context, arg BulkInsertAuthorsParams) error {
+	_, err := q.db.ExecContext(ctx, bulkInsertAuthors, pq.Array(arg.Names), pq.Array(arg.Bios))
+	return err
+}
+
+const listDays = `-- name: ListDays :many
+SELECT day FROM generate_series($1::timestamptz, $2::timestamptz, '1 day') AS day
+`
+
+type ListDaysParams struct {
+	Start time.Time
+	Stop  time.Time
+}
+
+func (q *Queries) ListDays(ctx context.Context, arg ListDaysParams) ([]time.Time, error) {
+	rows, err := q.db.QueryContext(ctx, listDays, arg.Start, arg.Stop)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []time.Time
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, err
+		}
+		items = append(items, day)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listElements = `-- name: ListElements :many
+SELECT value FROM jsonb_array_elements($1::jsonb)
+`
+
+func (q *Queries) ListElements(ctx context.Context, dollar_1 json.RawMessage) ([]json.RawMessage, error) {
+	rows, err := q.db.QueryContext(ctx, listElements, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []json.RawMessage
+	for rows.Next() {
+		var value json.RawMessage
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNames = `-- name: ListNames :many
+SELECT unnest, ordinality FROM unnest($1::text[]) WITH ORDINALITY
+`
+
+type ListNamesRow struct {
+	Unnest     string
+	Ordinality int64
+}
+
+func (q *Queries) ListNames(ctx context.Context, dollar_1 []string) ([]ListNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listNames, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNamesRow
+	for rows.Next() {
+		var i ListNamesRow
+		if err := rows.Scan(&i.Unnest, &i.Ordinality); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNamesByPosition = `-- name: ListNamesByPosition :many
+SELECT n.name, n.position
+FROM unnest($1::text[]) WITH ORDINALITY AS n(name, position)
+`
+
+type ListNamesByPositionRow struct {
+	Name     string
+	Position int64
+}
+
+func (q *Queries) ListNamesByPosition(ctx context.Context, names []string) ([]ListNamesByPositionRow, error) {
+	rows, err := q.db.QueryContext(ctx, listNamesByPosition, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNamesByPositionRow
+	for rows.Next() {
+		var i ListNamesByPositionRow
+		if err := rows.Scan(&i.Name, &i.Position); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNumbers = `-- name: ListNumbers :many
+SELECT generate_series FROM generate_series(1, 10)
+`
+
+func (q *Queries) ListNumbers(ctx context.Context) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listNumbers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var generate_series int32
+		if err := rows.Scan(&generate_series); err != nil {
+			return nil, err
+		}
+		items = append(items, generate_series)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPairs = `-- name: ListPairs :many
+SELECT num, label FROM ROWS FROM (generate_series(1, 3), unnest($1::text[])) AS p(num, label)
+`
+
+type ListPairsRow struct {
+	Num   int32
+	Label string
+}
+
+func (q *Queries) ListPairs(ctx context.Context, labels []string) ([]ListPairsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPairs, pq.Array(labels))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPairsRow
+	for rows.Next() {
+		var i ListPairsRow
+		if err := rows.Scan(&i.Num, &i.Label); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecords = `-- name: ListRecords :many
+SELECT r.id, r.name
+FROM jsonb_to_recordset($1::jsonb) AS r(id int, name text)
+`
+
+type ListRecordsRow struct {
+	ID   sql.NullInt32
+	Name sql.NullString
+}
+
+func (q *Queries) ListRecords(ctx context.Context, dollar_1 json.RawMessage) ([]ListRecordsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRecords, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecordsRow
+	for rows.Next() {
+		var i ListRecordsRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSettings = `-- name: ListSettings :many
+SELECT key, value FROM jsonb_each_text($1::jsonb)
+`
+
+type ListSettingsRow struct {
+	Key   string
+	Value sql.NullString
+}
+
+func (q *Queries) ListSettings(ctx context.Context, dollar_1 json.RawMessage) ([]ListSettingsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSettings, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSettingsRow
+	for rows.Next() {
+		var i ListSettingsRow
+		if err := rows.Scan(&i.Key, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,35 @@
+CREATE TABLE authors (
+    id   SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    bio  TEXT
+);
+
+-- name: ListNames :many
+SELECT * FROM unnest($1::text[]) WITH ORDINALITY;
+
+-- name: ListNamesByPosition :many
+SELECT n.name, n.position
+FROM unnest(@names::text[]) WITH ORDINALITY AS n(name, position);
+
+-- name: BulkInsertAuthors :exec
+INSERT INTO authors (name, bio)
+SELECT * FROM unnest(@names::text[], @bios::text[]);
+
+-- name: ListDays :many
+SELECT day FROM generate_series(@start::timestamptz, @stop::timestamptz, '1 day') AS day;
+
+-- name: ListNumbers :many
+SELECT * FROM generate_series(1, 10);
+
+-- name: ListElements :many
+SELECT value FROM jsonb_array_elements($1::jsonb);
+
+-- name: ListSettings :many
+SELECT key, value FROM jsonb_each_text($1::jsonb);
+
+-- name: ListRecords :many
+SELECT r.id, r.name
+FROM jsonb_to_recordset($1::jsonb) AS r(id int, name text);
+
+-- name: ListPairs :many
+SELECT * FROM ROWS FROM (generate_series(1, 3), unnest(@labels::text[])) AS p(num, label);
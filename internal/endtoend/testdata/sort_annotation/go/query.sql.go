@@ -0,0 +1,159 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const firstBook = `-- name: FirstBook :one
+SELECT id, author_id, title, published_at FROM books WHERE author_id = $1 ORDER BY sqlc_sort LIMIT 1
+`
+
+type FirstBookSort string
+
+const (
+	FirstBookSortTitleAsc        FirstBookSort = "title ASC"
+	FirstBookSortTitleDesc       FirstBookSort = "title DESC"
+	FirstBookSortPublishedAtAsc  FirstBookSort = "published_at ASC"
+	FirstBookSortPublishedAtDesc FirstBookSort = "published_at DESC"
+)
+
+func (s FirstBookSort) Valid() bool {
+	switch s {
+	case FirstBookSortTitleAsc, FirstBookSortTitleDesc, FirstBookSortPublishedAtAsc, FirstBookSortPublishedAtDesc:
+		return true
+	}
+	return false
+}
+
+func (q *Queries) FirstBook(ctx context.Context, authorID int32, orderBy FirstBookSort) (Book, error) {
+	if !orderBy.Valid() {
+		var i Book
+		return i, fmt.Errorf("invalid sort order: %q", orderBy)
+	}
+	row := q.db.QueryRowContext(ctx, strings.Replace(firstBook, "sqlc_sort", string(orderBy), 1), authorID)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.Title,
+		&i.PublishedAt,
+	)
+	return i, err
+}
+
+const listBooks = `-- name: ListBooks :many
+SELECT id, author_id, title, published_at FROM books ORDER BY sqlc_sort
+`
+
+type ListBooksSort string
+
+const (
+	ListBooksSortTitleAsc        ListBooksSort = "title ASC"
+	ListBooksSortTitleDesc       ListBooksSort = "title DESC"
+	ListBooksSortPublishedAtAsc  ListBooksSort = "published_at ASC"
+	ListBooksSortPublishedAtDesc ListBooksSort = "published_at DESC"
+)
+
+func (s ListBooksSort) Valid() bool {
+	switch s {
+	case ListBooksSortTitleAsc, ListBooksSortTitleDesc, ListBooksSortPublishedAtAsc, ListBooksSortPublishedAtDesc:
+		return true
+	}
+	return false
+}
+
+func (q *Queries) ListBooks(ctx context.Context, orderBy ListBooksSort) ([]Book, error) {
+	if !orderBy.Valid() {
+		return nil, fmt.Errorf("invalid sort order: %q", orderBy)
+	}
+	rows, err := q.db.QueryContext(ctx, strings.Replace(listBooks, "sqlc_sort", string(orderBy), 1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.Title,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByAuthor = `-- name: ListBooksByAuthor :many
+SELECT b.title, a.name FROM books b JOIN authors a ON a.id = b.author_id
+WHERE b.author_id = $1
+ORDER BY sqlc_sort, b.id
+LIMIT $2
+`
+
+type ListBooksByAuthorParams struct {
+	AuthorID int32
+	Limit    int32
+}
+
+type ListBooksByAuthorRow struct {
+	Title string
+	Name  string
+}
+
+type ListBooksByAuthorSort string
+
+const (
+	ListBooksByAuthorSortBTitleAsc  ListBooksByAuthorSort = "b.title ASC"
+	ListBooksByAuthorSortBTitleDesc ListBooksByAuthorSort = "b.title DESC"
+	ListBooksByAuthorSortANameAsc   ListBooksByAuthorSort = "a.name ASC"
+	ListBooksByAuthorSortANameDesc  ListBooksByAuthorSort = "a.name DESC"
+)
+
+func (s ListBooksByAuthorSort) Valid() bool {
+	switch s {
+	case ListBooksByAuthorSortBTitleAsc, ListBooksByAuthorSortBTitleDesc, ListBooksByAuthorSortANameAsc, ListBooksByAuthorSortANameDesc:
+		return true
+	}
+	return false
+}
+
+func (q *Queries) ListBooksByAuthor(ctx context.Context, arg ListBooksByAuthorParams, orderBy ListBooksByAuthorSort) ([]ListBooksByAuthorRow, error) {
+	if !orderBy.Valid() {
+		return nil, fmt.Errorf("invalid sort order: %q", orderBy)
+	}
+	rows, err := q.db.QueryContext(ctx, strings.Replace(listBooksByAuthor, "sqlc_sort", string(orderBy), 1), arg.AuthorID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBooksByAuthorRow
+	for rows.Next() {
+		var i ListBooksByAuthorRow
+		if err := rows.Scan(&i.Title, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,14 @@
+-- name: ListBooks :many
+-- sort: title, published_at
+SELECT * FROM books ORDER BY title;
+
+-- name: ListBooksByAuthor :many
+-- sort: b.title, a.name
+SELECT b.title, a.name FROM books b JOIN authors a ON a.id = b.author_id
+WHERE b.author_id = $1
+ORDER BY a.name, b.id
+LIMIT $2;
+
+-- name: FirstBook :one
+-- sort: title, published_at
+SELECT * FROM books WHERE author_id = $1 ORDER BY published_at LIMIT 1;
@@ -0,0 +1,11 @@
+CREATE TABLE authors (
+    id   SERIAL PRIMARY KEY,
+    name text NOT NULL
+);
+
+CREATE TABLE books (
+    id           SERIAL PRIMARY KEY,
+    author_id    integer NOT NULL REFERENCES authors(id),
+    title        text NOT NULL,
+    published_at timestamp NOT NULL
+);
@@ -0,0 +1,24 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.0.1
+// inputs:
+//   schema.sql sha256:851253f60655c403a39468c410ff80d41012602984148ad903308b96e7e61f4e
+//   query.sql sha256:479812ed1ee775c1bec041942d75d6f60b6fbbfbd5ac874cf559ae5f116309d1
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name FROM authors WHERE id = $1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
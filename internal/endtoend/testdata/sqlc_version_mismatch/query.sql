@@ -0,0 +1,5 @@
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
+
+-- stderr
+-- sqlc_version is v0.9.0, but this is sqlc v1.0.1; install sqlc v0.9.0 or pass --skip-version-check
@@ -2,3 +2,8 @@ CREATE TABLE foo (a text, b text);
 
 -- name: StarExpansion :many
 SELECT *, *, foo.* FROM foo;
+
+-- stderr
+-- # package querytest
+-- query.sql:4:1: warning: query "StarExpansion" selects column "a" more than once
+-- query.sql:4:1: warning: query "StarExpansion" selects column "b" more than once
@@ -0,0 +1,17 @@
+CREATE TABLE authors (
+    id   BIGSERIAL PRIMARY KEY,
+    name text NOT NULL
+);
+
+ALTER TABLE authors ADD COLUMN bio text;
+ALTER TABLE authors DROP COLUMN name;
+ALTER TABLE authors ADD COLUMN name text NOT NULL;
+
+-- name: ListAuthors :many
+SELECT * FROM authors;
+
+-- name: CreateAuthor :one
+INSERT INTO authors (name, bio) VALUES ($1, $2) RETURNING *;
+
+-- name: DeleteAuthor :one
+DELETE FROM authors WHERE id = $1 RETURNING *;
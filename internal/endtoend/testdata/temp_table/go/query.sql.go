@@ -0,0 +1,77 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createStaging = `-- name: CreateStaging :exec
+CREATE TEMP TABLE staging (id integer NOT NULL, email text) ON COMMIT DROP
+`
+
+func (q *Queries) CreateStaging(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, createStaging)
+	return err
+}
+
+const insertStaging = `-- name: InsertStaging :exec
+INSERT INTO staging (id, email) VALUES ($1, $2)
+`
+
+type InsertStagingParams struct {
+	ID    int32
+	Email sql.NullString
+}
+
+func (q *Queries) InsertStaging(ctx context.Context, arg InsertStagingParams) error {
+	_, err := q.db.ExecContext(ctx, insertStaging, arg.ID, arg.Email)
+	return err
+}
+
+const listStaging = `-- name: ListStaging :many
+SELECT id, email FROM staging
+`
+
+type ListStagingRow struct {
+	ID    int32
+	Email sql.NullString
+}
+
+func (q *Queries) ListStaging(ctx context.Context) ([]ListStagingRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStaging)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListStagingRow
+	for rows.Next() {
+		var i ListStagingRow
+		if err := rows.Scan(&i.ID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const mergeStaging = `-- name: MergeStaging :execrows
+INSERT INTO users (id, email)
+SELECT id, email FROM staging WHERE email IS NOT NULL
+`
+
+func (q *Queries) MergeStaging(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, mergeStaging)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
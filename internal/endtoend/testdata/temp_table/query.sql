@@ -0,0 +1,12 @@
+-- name: CreateStaging :exec
+CREATE TEMP TABLE staging (id integer NOT NULL, email text) ON COMMIT DROP;
+
+-- name: InsertStaging :exec
+INSERT INTO staging (id, email) VALUES ($1, $2);
+
+-- name: ListStaging :many
+SELECT * FROM staging;
+
+-- name: MergeStaging :execrows
+INSERT INTO users (id, email)
+SELECT id, email FROM staging WHERE email IS NOT NULL;
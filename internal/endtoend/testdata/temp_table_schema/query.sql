@@ -0,0 +1,6 @@
+-- name: CreateStaging :exec
+CREATE TEMP TABLE public.staging (id integer NOT NULL, email text);
+
+-- stderr
+-- # package querytest
+-- query.sql:1:1: cannot create temporary relation in non-temporary schema
@@ -0,0 +1,21 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"database/sql"
+
+	"github.com/example/clock"
+	"github.com/shopspring/decimal"
+)
+
+type Order struct {
+	ID         string
+	CustomerID sql.NullString
+	PlacedAt   clock.Time
+	ShippedAt  sql.NullTime
+	Subtotal   decimal.Decimal
+	Discount   decimal.NullDecimal
+	Total      int64
+	Tip        sql.NullString
+}
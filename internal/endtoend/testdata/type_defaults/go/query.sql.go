@@ -0,0 +1,46 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/example/clock"
+)
+
+const listOrdersPlacedAfter = `-- name: ListOrdersPlacedAfter :many
+SELECT id, customer_id, placed_at, shipped_at, subtotal, discount, total, tip FROM orders WHERE placed_at > $1
+`
+
+func (q *Queries) ListOrdersPlacedAfter(ctx context.Context, placedAt clock.Time) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOrdersPlacedAfter, placedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.CustomerID,
+			&i.PlacedAt,
+			&i.ShippedAt,
+			&i.Subtotal,
+			&i.Discount,
+			&i.Total,
+			&i.Tip,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
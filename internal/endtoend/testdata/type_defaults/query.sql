@@ -0,0 +1,13 @@
+CREATE TABLE orders (
+    id          uuid PRIMARY KEY,
+    customer_id uuid,
+    placed_at   timestamptz NOT NULL,
+    shipped_at  timestamptz,
+    subtotal    numeric(10, 2) NOT NULL,
+    discount    numeric(10, 2),
+    total       numeric(10, 2) NOT NULL,
+    tip         money
+);
+
+-- name: ListOrdersPlacedAfter :many
+SELECT * FROM orders WHERE placed_at > $1;
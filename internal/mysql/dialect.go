@@ -0,0 +1,165 @@
+package mysql
+
+import (
+	"fmt"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/sql/token"
+)
+
+// MariaDB and TiDB share the MySQL engine. Their extensions to MySQL's syntax
+// are removed before a file is parsed, replaced with spaces so that error
+// positions don't move.
+
+// TiDB table options that take a number
+var tidbTableOptions = []string{"SHARD_ROW_ID_BITS", "PRE_SPLIT_REGIONS", "AUTO_ID_CACHE", "AUTO_RANDOM_BASE"}
+
+// Blank out the TiDB extensions of a schema: the AUTO_RANDOM column attribute,
+// CLUSTERED and NONCLUSTERED primary keys, and the table options that control
+// how rows are split into regions. An AUTO_RANDOM column is a BIGINT like any
+// other. Only CREATE TABLE statements are rewritten, so a column named like
+// one of the extensions can still be queried.
+func removeTiDBClauses(contents string) string {
+	b := []byte(contents)
+	tokens := token.SplitMySQL(contents)
+	createTable, options := false, false
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.Text == ";":
+			createTable, options = false, false
+			depth = 0
+
+		case t.Text == "(":
+			depth++
+
+		case t.Text == ")":
+			depth--
+			// The table options follow the list of definitions
+			options = createTable && depth == 0
+
+		case tokens.At(i, "CREATE", "TABLE"), tokens.At(i, "CREATE", "TEMPORARY", "TABLE"):
+			createTable = true
+
+		// The words are left alone outside of CREATE TABLE, and where they
+		// name a column
+		case !createTable || depth == 1 && (tokens[i-1].Text == "(" || tokens[i-1].Text == ","):
+
+		case depth == 1 && t.Is("AUTO_RANDOM"):
+			end := i
+			if tokens.At(i+1, "(") {
+				end = tokens.Closing(i + 1)
+			}
+			token.Blank(b, t.Start, tokens[end].End)
+			i = end
+
+		case depth == 1 && (t.Is("CLUSTERED") || t.Is("NONCLUSTERED")):
+			token.Blank(b, t.Start, t.End)
+
+		case options:
+			for _, opt := range tidbTableOptions {
+				if !t.Is(opt) {
+					continue
+				}
+				end := i + 1
+				if tokens.At(end, "=") {
+					end++
+				}
+				if end >= len(tokens) {
+					end = len(tokens) - 1
+				}
+				token.Blank(b, t.Start, tokens[end].End)
+				i = end
+			}
+		}
+	}
+	return string(b)
+}
+
+// A RETURNING clause removed from a MariaDB INSERT, REPLACE or DELETE
+// statement
+type returningClause struct {
+	pos   int
+	exprs string
+}
+
+// Remove the RETURNING clauses of MariaDB statements, which the MySQL parser
+// doesn't understand. The clauses are added back to the parsed statements.
+func removeReturning(contents string) (string, []returningClause) {
+	b := []byte(contents)
+	tokens := token.SplitMySQL(contents)
+	var clauses []returningClause
+	stmtStart, dml := true, false
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if stmtStart {
+			dml = t.Is("INSERT") || t.Is("REPLACE") || t.Is("DELETE")
+			stmtStart = false
+		}
+		switch {
+		case t.Text == "(":
+			depth++
+		case t.Text == ")":
+			depth--
+		case t.Text == ";":
+			stmtStart = true
+			depth = 0
+		case dml && depth == 0 && t.Is("RETURNING"):
+			end := i
+			for end+1 < len(tokens) && tokens[end+1].Text != ";" {
+				end++
+			}
+			if end == i {
+				continue
+			}
+			clauses = append(clauses, returningClause{
+				pos:   t.Start,
+				exprs: contents[tokens[i+1].Start:tokens[end].End],
+			})
+			token.Blank(b, t.Start, tokens[end].End)
+			i = end
+		}
+	}
+	return string(b), clauses
+}
+
+// Prepare the contents of a file for the MySQL parser
+func (pGen PackageGenerator) removeDialectClauses(contents string) (string, []returningClause) {
+	switch pGen.Package.Engine {
+	case config.EngineMariaDB:
+		return removeReturning(contents)
+	case config.EngineTiDB:
+		return removeTiDBClauses(contents), nil
+	}
+	return contents, nil
+}
+
+// Add the RETURNING clause of a MariaDB statement to its parsed query. The
+// returned columns are typed by parsing them as a select list of the table.
+func (pGen PackageGenerator) addReturning(q *Query, clause returningClause) error {
+	table := sqlparser.TableName{Name: sqlparser.NewTableIdent(q.DefaultTableName)}
+	stmt, err := sqlparser.Parse("SELECT " + clause.exprs + " FROM " + sqlparser.String(table))
+	if err != nil {
+		return fmt.Errorf("failed to parse RETURNING clause: %w", err)
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return fmt.Errorf("failed to parse RETURNING clause")
+	}
+	tableAliasMap, defaultTableName, err := parseFrom(sel.From, false)
+	if err != nil {
+		return err
+	}
+	sel.SelectExprs = pGen.expandStar(sel.SelectExprs, defaultTableName)
+	cols, err := pGen.parseSelectAliasExpr(sel.SelectExprs, tableAliasMap, defaultTableName)
+	if err != nil {
+		return err
+	}
+	q.Columns = cols
+	q.SQL += " returning " + sqlparser.String(sel.SelectExprs)
+	return nil
+}
@@ -78,6 +78,7 @@ func (r *Result) Structs(settings config.CombinedSettings) []dinosql.GoStruct {
 				Type:    r.goTypeCol(Column{col, tableName}),
 				Tags:    map[string]string{"json:": col.Name.String()},
 				Comment: "",
+				Column:  col.Name.String(),
 			})
 		}
 		structs = append(structs, s)
@@ -104,7 +105,8 @@ func (r *Result) GoQueries(settings config.CombinedSettings) []dinosql.GoQuery {
 
 		gq := dinosql.GoQuery{
 			Cmd:          query.Cmd,
-			ConstantName: dinosql.LowerTitle(query.Name),
+			Name:         query.Name,
+			ConstantName: dinosql.ConstantName(query.Name, settings),
 			FieldName:    dinosql.LowerTitle(query.Name) + "Stmt",
 			MethodName:   query.Name,
 			SourceName:   query.Filename,
@@ -146,7 +148,7 @@ func (r *Result) GoQueries(settings config.CombinedSettings) []dinosql.GoQuery {
 			var emit bool
 
 			for _, s := range structs {
-				if len(s.Fields) != len(query.Columns) {
+				if settings.Go.EmitRowStructs || len(s.Fields) != len(query.Columns) {
 					continue
 				}
 				same := true
@@ -261,6 +263,12 @@ func (pGen PackageGenerator) goTypeCol(col Column) string {
 			return "time.Time"
 		}
 		return "sql.NullTime"
+	case "json" == t && pGen.Package.Engine == config.EngineMariaDB:
+		// MariaDB stores JSON as LONGTEXT, and returns it as a string
+		if col.Type.NotNull {
+			return "string"
+		}
+		return "sql.NullString"
 	case "boolean" == t, "bool" == t, "tinyint" == t:
 		if col.Type.NotNull {
 			return "bool"
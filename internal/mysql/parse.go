@@ -49,6 +49,7 @@ func parsePath(sqlPath string, generator PackageGenerator) (*Result, error) {
 			parseErrors.Add(filename, "", 0, err)
 			continue
 		}
+		contents, returning := generator.removeDialectClauses(contents)
 
 		t := sqlparser.NewStringTokenizer(contents)
 		var start int
@@ -75,6 +76,18 @@ func parsePath(sqlPath string, generator PackageGenerator) (*Result, error) {
 				start = t.Position
 				continue
 			}
+			if result != nil {
+				for _, clause := range returning {
+					if clause.pos >= start && clause.pos < t.Position-1 {
+						err = generator.addReturning(result, clause)
+					}
+				}
+			}
+			if err != nil {
+				parseErrors.Add(filename, contents, start, err)
+				start = t.Position
+				continue
+			}
 			start = t.Position
 			if result == nil {
 				continue
@@ -147,6 +160,9 @@ func (q *Query) parseNameAndCmd() error {
 	} else if name == "" || cmd == "" {
 		return fmt.Errorf("failed to parse query leading comment")
 	}
+	if cmd == ":paginated" || cmd == ":execscript" {
+		return fmt.Errorf("the %s command is only supported by the PostgreSQL engine", cmd)
+	}
 	q.Name = name
 	q.Cmd = cmd
 	return nil
@@ -159,19 +175,7 @@ func (pGen PackageGenerator) parseSelect(tree *sqlparser.Select, query string) (
 	}
 
 	// handle * expressions first by expanding all columns of the default table
-	_, ok := tree.SelectExprs[0].(*sqlparser.StarExpr)
-	if ok {
-		colNames := []sqlparser.SelectExpr{}
-		colDfns := pGen.Schema.tables[defaultTableName]
-		for _, col := range colDfns {
-			colNames = append(colNames, &sqlparser.AliasedExpr{
-				Expr: &sqlparser.ColName{
-					Name: col.Name,
-				}},
-			)
-		}
-		tree.SelectExprs = colNames
-	}
+	tree.SelectExprs = pGen.expandStar(tree.SelectExprs, defaultTableName)
 
 	parsedQuery := Query{
 		SQL:              query,
@@ -202,6 +206,22 @@ func (pGen PackageGenerator) parseSelect(tree *sqlparser.Select, query string) (
 	return &parsedQuery, nil
 }
 
+func (pGen PackageGenerator) expandStar(exprs sqlparser.SelectExprs, defaultTableName string) sqlparser.SelectExprs {
+	if _, ok := exprs[0].(*sqlparser.StarExpr); !ok {
+		return exprs
+	}
+	colNames := []sqlparser.SelectExpr{}
+	colDfns := pGen.Schema.tables[defaultTableName]
+	for _, col := range colDfns {
+		colNames = append(colNames, &sqlparser.AliasedExpr{
+			Expr: &sqlparser.ColName{
+				Name: col.Name,
+			}},
+		)
+	}
+	return colNames
+}
+
 // FromTable describes a table reference in the "FROM" clause of a query.
 type FromTable struct {
 	TrueName     string // the true table name as described in the schema
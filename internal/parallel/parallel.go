@@ -0,0 +1,41 @@
+// Package parallel runs independent pieces of work on a bounded number of
+// goroutines.
+package parallel
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Do calls fn once for each index in [0, n), running up to GOMAXPROCS calls
+// at the same time. It returns after every call has returned. Callers collect
+// results by index, so the order of the output doesn't depend on scheduling.
+func Do(n int, fn func(i int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+}
@@ -0,0 +1,20 @@
+package parallel
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestDo(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 100} {
+		seen := make([]int32, n)
+		Do(n, func(i int) {
+			atomic.AddInt32(&seen[i], 1)
+		})
+		for i, count := range seen {
+			if count != 1 {
+				t.Errorf("n=%d: index %d ran %d times", n, i, count)
+			}
+		}
+	}
+}
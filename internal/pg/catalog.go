@@ -46,6 +46,10 @@ func (f FQN) String() string {
 
 type Catalog struct {
 	Schemas map[string]Schema
+
+	// The schemas searched, in order, for the tables and views that queries
+	// refer to without a schema. Only public is searched if empty.
+	SearchPath []string
 }
 
 func (c Catalog) LookupFunctions(fqn FQN) ([]Function, error) {
@@ -80,11 +84,7 @@ func (c Catalog) LookupFunctionN(fqn FQN, argn int) (Function, error) {
 		return Function{}, err
 	}
 	for _, fun := range funs {
-		arity := fun.ArgN
-		if fun.Arguments != nil {
-			arity = len(fun.Arguments)
-		}
-		if arity == argn {
+		if fun.Accepts(argn) {
 			return fun, nil
 		}
 	}
@@ -114,6 +114,55 @@ type Table struct {
 	Name    string
 	Columns []Column
 	Comment string
+	Grants  []Grant
+
+	// Set by ALTER TABLE ... ENABLE ROW LEVEL SECURITY, and FORCE ROW LEVEL
+	// SECURITY for the policies to apply to the table's owner too
+	RowSecurity      bool
+	ForceRowSecurity bool
+	Policies         []Policy
+}
+
+// The roles that have been granted a privilege on a table
+type Grant struct {
+	Grantee   string // A role name, or "public" for every role
+	Privilege string // SELECT, INSERT, UPDATE, DELETE, TRUNCATE, REFERENCES or TRIGGER
+
+	// The columns the privilege applies to. Empty if it applies to the whole
+	// table.
+	Columns []string
+}
+
+// Report whether a role may use a privilege on a column of the table. An
+// empty column checks for the privilege on the whole table. Membership in
+// other roles isn't tracked.
+func (t Table) Allows(role, privilege, column string) bool {
+	for _, g := range t.Grants {
+		if g.Privilege != privilege || !(g.Grantee == role || g.Grantee == "public") {
+			continue
+		}
+		if len(g.Columns) == 0 {
+			return true
+		}
+		for _, c := range g.Columns {
+			if c == column && column != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// A row-level security policy of a table
+type Policy struct {
+	Name       string
+	Command    string   // ALL, SELECT, INSERT, UPDATE or DELETE
+	Permissive bool     // false for AS RESTRICTIVE
+	Roles      []string // A role name, or "public" for every role
+
+	// The CREATE POLICY and ALTER POLICY statements, as written, recorded
+	// for documentation
+	SQL []string
 }
 
 type Column struct {
@@ -123,6 +172,14 @@ type Column struct {
 	IsArray  bool
 	Comment  string
 
+	// The column has a DEFAULT value or a serial type, so INSERT statements
+	// can leave it out
+	HasDefault bool
+
+	// The column holds data that generated code keeps out of logs, such as a
+	// password hash
+	Sensitive bool
+
 	// XXX: Figure out what PostgreSQL calls `foo.id`
 	Scope string
 	Table FQN
@@ -151,14 +208,69 @@ func (e CompositeType) isType() {
 type Function struct {
 	Name       string
 	ArgN       int
+	Variadic   bool       // takes ArgN or more arguments
 	Arguments  []Argument // not recorded for builtins
 	ReturnType string
 	Comment    string
 	Desc       string
+
+	// The result can be NULL for arguments that aren't, such as the result
+	// of current_setting(name, true) for a setting that doesn't exist
+	NullableResult bool
+
+	// Created with CREATE PROCEDURE, so it's run with CALL instead of SELECT
+	Procedure bool
+}
+
+// Accepts reports whether the function can be called with argn arguments
+func (f Function) Accepts(argn int) bool {
+	arity := f.ArgN
+	if f.Arguments != nil {
+		arity = len(f.InArgs())
+	}
+	return arity == argn || (f.Variadic && argn > arity)
+}
+
+// InArgs returns the arguments that are passed in a call. The OUT arguments
+// of a function are part of its result instead, while a procedure is called
+// with a value for each of its arguments.
+func (f Function) InArgs() []Argument {
+	if f.Procedure {
+		return f.Arguments
+	}
+	var args []Argument
+	for _, arg := range f.Arguments {
+		if arg.Mode != ArgumentOut && arg.Mode != ArgumentTable {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// OutArgs returns the arguments that are part of the result
+func (f Function) OutArgs() []Argument {
+	var args []Argument
+	for _, arg := range f.Arguments {
+		if arg.Mode == ArgumentOut || arg.Mode == ArgumentInOut || arg.Mode == ArgumentTable {
+			args = append(args, arg)
+		}
+	}
+	return args
 }
 
 type Argument struct {
 	Name       string
 	DataType   string
 	HasDefault bool
+	Mode       ArgumentMode
 }
+
+type ArgumentMode int
+
+const (
+	ArgumentIn ArgumentMode = iota
+	ArgumentOut
+	ArgumentInOut
+	ArgumentVariadic
+	ArgumentTable
+)
@@ -29,6 +29,20 @@ func ErrorColumnDoesNotExist(rel, col string) Error {
 	}
 }
 
+func ErrorPolicyAlreadyExists(rel, name string) Error {
+	return Error{
+		Code:    "42710",
+		Message: fmt.Sprintf("policy \"%s\" for table \"%s\" already exists", name, rel),
+	}
+}
+
+func ErrorPolicyDoesNotExist(rel, name string) Error {
+	return Error{
+		Code:    "42704",
+		Message: fmt.Sprintf("policy \"%s\" for table \"%s\" does not exist", name, rel),
+	}
+}
+
 func ErrorRelationAlreadyExists(rel string) Error {
 	return Error{
 		Code:    "42P07",
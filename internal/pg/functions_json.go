@@ -0,0 +1,42 @@
+package pg
+
+// JSON Functions and Operators
+//
+// Functions that build JSON from SQL values, such as json_build_object and
+// jsonb_agg, return json or jsonb whatever their arguments are.
+//
+// https://www.postgresql.org/docs/current/functions-json.html
+//
+// Table 9.47. JSON Creation Functions
+// Table 9.49. JSON Processing Functions
+// Table 9.55. Aggregate Functions
+func jsonFunctions() []Function {
+	var fs []Function
+	for _, typ := range []string{"json", "jsonb"} {
+		fs = append(fs,
+			Function{Name: "to_" + typ, ArgN: 1, ReturnType: typ},
+			Function{Name: typ + "_build_array", ArgN: 0, Variadic: true, ReturnType: typ},
+			Function{Name: typ + "_build_object", ArgN: 0, Variadic: true, ReturnType: typ},
+			Function{Name: typ + "_object", ArgN: 1, ReturnType: typ},
+			Function{Name: typ + "_object", ArgN: 2, ReturnType: typ},
+			Function{Name: typ + "_agg", ArgN: 1, ReturnType: typ},
+			Function{Name: typ + "_object_agg", ArgN: 2, ReturnType: typ},
+			Function{Name: typ + "_strip_nulls", ArgN: 1, ReturnType: typ},
+			Function{Name: typ + "_extract_path", ArgN: 1, Variadic: true, ReturnType: typ},
+			Function{Name: typ + "_extract_path_text", ArgN: 1, Variadic: true, ReturnType: "text"},
+			Function{Name: typ + "_array_length", ArgN: 1, ReturnType: "integer"},
+			Function{Name: typ + "_typeof", ArgN: 1, ReturnType: "text"},
+		)
+	}
+	return append(fs,
+		Function{Name: "array_to_json", ArgN: 1, ReturnType: "json"},
+		Function{Name: "array_to_json", ArgN: 2, ReturnType: "json"},
+		Function{Name: "row_to_json", ArgN: 1, ReturnType: "json"},
+		Function{Name: "row_to_json", ArgN: 2, ReturnType: "json"},
+		Function{Name: "jsonb_set", ArgN: 3, ReturnType: "jsonb"},
+		Function{Name: "jsonb_set", ArgN: 4, ReturnType: "jsonb"},
+		Function{Name: "jsonb_insert", ArgN: 3, ReturnType: "jsonb"},
+		Function{Name: "jsonb_insert", ArgN: 4, ReturnType: "jsonb"},
+		Function{Name: "jsonb_pretty", ArgN: 1, ReturnType: "text"},
+	)
+}
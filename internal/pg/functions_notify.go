@@ -0,0 +1,27 @@
+package pg
+
+// Asynchronous Notification
+//
+// pg_notify sends a notification like the NOTIFY command, but takes the
+// channel and the payload as arguments, so they can be query parameters.
+//
+// https://www.postgresql.org/docs/current/sql-notify.html
+func notifyFunctions() []Function {
+	return []Function{
+		{
+			Name:       "pg_notify",
+			Desc:       "Send a notification event",
+			ReturnType: "void",
+			Arguments: []Argument{
+				{
+					Name:     "channel",
+					DataType: "text",
+				},
+				{
+					Name:     "payload",
+					DataType: "text",
+				},
+			},
+		},
+	}
+}
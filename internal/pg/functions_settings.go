@@ -0,0 +1,59 @@
+package pg
+
+// Configuration Settings Functions
+//
+// current_setting reads a setting, including custom settings such as
+// app.tenant_id that row-level security policies read, and set_config sets
+// one for the session or, if is_local is true, the current transaction.
+//
+// https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADMIN-SET
+func settingsFunctions() []Function {
+	return []Function{
+		{
+			Name:       "current_setting",
+			Desc:       "Get current value of setting",
+			ReturnType: "text",
+			Arguments: []Argument{
+				{
+					Name:     "setting_name",
+					DataType: "text",
+				},
+			},
+		},
+		{
+			Name:           "current_setting",
+			Desc:           "Get current value of setting, or NULL if it doesn't exist",
+			ReturnType:     "text",
+			NullableResult: true,
+			Arguments: []Argument{
+				{
+					Name:     "setting_name",
+					DataType: "text",
+				},
+				{
+					Name:     "missing_ok",
+					DataType: "bool",
+				},
+			},
+		},
+		{
+			Name:       "set_config",
+			Desc:       "Set parameter and return new value",
+			ReturnType: "text",
+			Arguments: []Argument{
+				{
+					Name:     "setting_name",
+					DataType: "text",
+				},
+				{
+					Name:     "new_value",
+					DataType: "text",
+				},
+				{
+					Name:     "is_local",
+					DataType: "bool",
+				},
+			},
+		},
+	}
+}
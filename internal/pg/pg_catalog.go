@@ -75,6 +75,9 @@ func pgCatalog() Schema {
 
 	fs = append(fs, stringFunctions()...)
 	fs = append(fs, advisoryLockFunctions()...)
+	fs = append(fs, notifyFunctions()...)
+	fs = append(fs, settingsFunctions()...)
+	fs = append(fs, jsonFunctions()...)
 
 	s.Funcs = make(map[string][]Function, len(fs))
 	for _, f := range fs {
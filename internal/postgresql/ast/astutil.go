@@ -1539,7 +1539,7 @@ func (a *application) applyList(parent nodes.Node, name string) {
 		// element x may be nil in a bad AST - be cautious
 		var x nodes.Node
 		if e := v.Index(a.iter.index); e.IsValid() {
-			x = e.Interface().(nodes.Node)
+			x, _ = e.Interface().(nodes.Node)
 		}
 
 		a.iter.step = 1
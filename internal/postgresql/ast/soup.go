@@ -161,7 +161,9 @@ func Walk(f Visitor, node nodes.Node) {
 		if n.Role != nil {
 			walkn(f, *n.Role)
 		}
-		walkn(f, n.Setstmt)
+		if n.Setstmt != nil {
+			walkn(f, *n.Setstmt)
+		}
 
 	case nodes.AlterRoleStmt:
 		if n.Role != nil {
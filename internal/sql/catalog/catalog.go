@@ -127,12 +127,19 @@ func (c *Catalog) alterTable(stmt *ast.AlterTableStmt) error {
 			switch cmd.Subtype {
 
 			case ast.AT_AddColumn:
+				exists := false
 				for _, c := range table.Columns {
 					if c.Name == cmd.Def.Colname {
-						// return wrap(pg.ErrorColumnAlreadyExists(table.Name, *d.Colname), d.Location)
-						return ErrColumnExists
+						exists = true
 					}
 				}
+				if exists && cmd.MissingOk {
+					continue
+				}
+				if exists {
+					// return wrap(pg.ErrorColumnAlreadyExists(table.Name, *d.Colname), d.Location)
+					return ErrColumnExists
+				}
 				table.Columns = append(table.Columns, &Column{
 					Name:      cmd.Def.Colname,
 					Type:      *cmd.Def.TypeName,
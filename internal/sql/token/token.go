@@ -0,0 +1,151 @@
+// Package token splits SQL into tokens for the engines that rewrite a schema
+// or query before it's parsed, such as the clauses of CockroachDB, Redshift
+// and TiDB that their parsers don't understand.
+package token
+
+import "strings"
+
+type Token struct {
+	Start, End int
+	Text       string
+}
+
+// Report whether the token is the given word, ignoring case
+func (t Token) Is(word string) bool {
+	return strings.EqualFold(t.Text, word)
+}
+
+type List []Token
+
+// Split PostgreSQL into words, quoted strings and identifiers, and single
+// punctuation characters. Comments are dropped.
+func Split(s string) List {
+	return split(s, false)
+}
+
+// SplitMySQL splits MySQL like Split, with its # and "-- " comments, backtick
+// quotes and backslash escapes.
+func SplitMySQL(s string) List {
+	return split(s, true)
+}
+
+func split(s string, mysql bool) List {
+	var tokens List
+	for i := 0; i < len(s); {
+		start := i
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case comment(s[i:], mysql):
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && i+1 < len(s) && s[i+1] == '*':
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += 2 + end + 2
+			continue
+		case c == '\'' || c == '"' || c == '`' && mysql:
+			for i++; i < len(s); i++ {
+				if s[i] == '\\' && c != '`' && mysql {
+					i++
+				} else if s[i] == c {
+					if i+1 < len(s) && s[i+1] == c {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			i++
+		case c == '$' && !mysql && (i == 0 || !isIdentChar(s[i-1], mysql)):
+			tag, ok := dollarTag(s[i:])
+			if !ok {
+				i++
+				break
+			}
+			end := strings.Index(s[i+len(tag):], tag)
+			if end < 0 {
+				return tokens
+			}
+			i += len(tag) + end + len(tag)
+		case isIdentChar(c, mysql):
+			for i < len(s) && isIdentChar(s[i], mysql) {
+				i++
+			}
+		default:
+			i++
+		}
+		if i > len(s) {
+			i = len(s)
+		}
+		tokens = append(tokens, Token{start, i, s[start:i]})
+	}
+	return tokens
+}
+
+// Report whether s starts with a comment that runs to the end of the line
+func comment(s string, mysql bool) bool {
+	if mysql {
+		return strings.HasPrefix(s, "#") || strings.HasPrefix(s, "-- ")
+	}
+	return strings.HasPrefix(s, "--")
+}
+
+func isIdentChar(c byte, mysql bool) bool {
+	return c == '_' || c == '$' && mysql || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// The tag opening a dollar-quoted string, such as $$ or $body$
+func dollarTag(s string) (string, bool) {
+	for j := 1; j < len(s); j++ {
+		if s[j] == '$' {
+			return s[:j+1], true
+		}
+		if !isIdentChar(s[j], false) || (j == 1 && s[j] >= '0' && s[j] <= '9') {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// Report whether the tokens from i on are the given words
+func (tokens List) At(i int, words ...string) bool {
+	for k, w := range words {
+		if i+k >= len(tokens) || !tokens[i+k].Is(w) {
+			return false
+		}
+	}
+	return true
+}
+
+// The index of the token closing the parenthesis opened at i
+func (tokens List) Closing(i int) int {
+	depth := 0
+	for j := i; j < len(tokens); j++ {
+		switch tokens[j].Text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// Replace b[start:end] with spaces, keeping newlines so that line numbers
+// don't change
+func Blank(b []byte, start, end int) {
+	for i := start; i < end; i++ {
+		if b[i] != '\n' {
+			b[i] = ' '
+		}
+	}
+}